@@ -16,9 +16,13 @@ package baggage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/label"
 )
@@ -27,10 +31,59 @@ import (
 // https://github.com/open-telemetry/opentelemetry-specification/blob/18b2752ebe6c7f0cdd8c7b2bcbdceb0ae3f5ad95/specification/correlationcontext/api.md#header-name
 const baggageHeader = "otcorrelations"
 
+// Limits of the W3C Baggage specification, which allows propagators to
+// drop members exceeding them.
+// https://w3c.github.io/baggage/#limits
+const (
+	// DefaultMaxMembers is the maximum number of members of a
+	// baggage header.
+	DefaultMaxMembers = 180
+	// DefaultMaxHeaderSize is the maximum size in bytes of a baggage
+	// header.
+	DefaultMaxHeaderSize = 8192
+)
+
+// ErrMembersDropped is reported to the ErrorHandler of a Baggage
+// propagator when members are dropped on inject to respect its limits.
+var ErrMembersDropped = errors.New("baggage members dropped")
+
 // Baggage propagates Key:Values in W3C CorrelationContext
 // format.
+//
+// The zero value injects all members.  Proxies may reject oversized
+// headers, so the number of members and the size of the header may be
+// limited, e.g., to the limits of the specification:
+//
+//	baggage.Baggage{
+//		MaxMembers:    baggage.DefaultMaxMembers,
+//		MaxHeaderSize: baggage.DefaultMaxHeaderSize,
+//		Priority:      []label.Key{"tenant"},
+//		ErrorHandler:  global.ErrorHandler(),
+//	}
+//
+// When limits are set, members are injected in a deterministic order:
+// the keys of Priority in their order first, then the other keys in
+// lexicographic order.  Members are kept in that order while they fit
+// into the limits, members that do not fit are dropped.
 // nolint:golint
-type Baggage struct{}
+type Baggage struct {
+	// MaxMembers, if positive, is the maximum number of members
+	// injected.
+	MaxMembers int
+
+	// MaxHeaderSize, if positive, is the maximum size in bytes of
+	// the injected header.
+	MaxHeaderSize int
+
+	// Priority lists the keys of the members to keep first when
+	// members are dropped.
+	Priority []label.Key
+
+	// ErrorHandler, if not nil, is reported an error wrapping
+	// ErrMembersDropped with the keys of the dropped members on
+	// every inject that drops members.
+	ErrorHandler otel.ErrorHandler
+}
 
 var _ propagation.HTTPPropagator = Baggage{}
 
@@ -43,6 +96,10 @@ func DefaultHTTPPropagator() propagation.HTTPPropagator {
 // Inject implements HTTPInjector.
 func (b Baggage) Inject(ctx context.Context, supplier propagation.HTTPSupplier) {
 	baggageMap := MapFromContext(ctx)
+	if b.MaxMembers > 0 || b.MaxHeaderSize > 0 {
+		b.injectLimited(baggageMap, supplier)
+		return
+	}
 	firstIter := true
 	var headerValueBuilder strings.Builder
 	baggageMap.Foreach(func(kv label.KeyValue) bool {
@@ -61,9 +118,67 @@ func (b Baggage) Inject(ctx context.Context, supplier propagation.HTTPSupplier)
 	}
 }
 
+// injectLimited injects the members of baggageMap that fit into the
+// limits of b.
+func (b Baggage) injectLimited(baggageMap Map, supplier propagation.HTTPSupplier) {
+	rank := make(map[label.Key]int, len(b.Priority))
+	for i, k := range b.Priority {
+		if _, ok := rank[k]; !ok {
+			rank[k] = i
+		}
+	}
+	kvs := make([]label.KeyValue, 0, baggageMap.Len())
+	baggageMap.Foreach(func(kv label.KeyValue) bool {
+		kvs = append(kvs, kv)
+		return true
+	})
+	sort.Slice(kvs, func(i, j int) bool {
+		ri, iok := rank[kvs[i].Key]
+		rj, jok := rank[kvs[j].Key]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		}
+		return kvs[i].Key < kvs[j].Key
+	})
+
+	var (
+		headerValueBuilder strings.Builder
+		members            int
+		dropped            []string
+	)
+	for _, kv := range kvs {
+		member := url.QueryEscape(strings.TrimSpace((string)(kv.Key))) + "=" +
+			url.QueryEscape(strings.TrimSpace(kv.Value.Emit()))
+		size := headerValueBuilder.Len() + len(member)
+		if members > 0 {
+			size++
+		}
+		if (b.MaxMembers > 0 && members >= b.MaxMembers) ||
+			(b.MaxHeaderSize > 0 && size > b.MaxHeaderSize) {
+			dropped = append(dropped, string(kv.Key))
+			continue
+		}
+		if members > 0 {
+			headerValueBuilder.WriteRune(',')
+		}
+		headerValueBuilder.WriteString(member)
+		members++
+	}
+	if headerValueBuilder.Len() > 0 {
+		supplier.Set(baggageHeader, headerValueBuilder.String())
+	}
+	if len(dropped) > 0 && b.ErrorHandler != nil {
+		b.ErrorHandler.Handle(fmt.Errorf("%w: %d of %d members exceeding the limits: %s",
+			ErrMembersDropped, len(dropped), len(kvs), strings.Join(dropped, ",")))
+	}
+}
+
 // Extract implements HTTPExtractor.
 func (b Baggage) Extract(ctx context.Context, supplier propagation.HTTPSupplier) context.Context {
-	baggage := supplier.Get(baggageHeader)
+	baggage := propagation.CombinedValue(supplier, baggageHeader)
 	if baggage == "" {
 		return ctx
 	}
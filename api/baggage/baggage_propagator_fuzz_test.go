@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package baggage_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/api/baggage"
+	"go.opentelemetry.io/otel/label"
+)
+
+func baggageLabels(m baggage.Map) map[label.Key]string {
+	kvs := make(map[label.Key]string, m.Len())
+	m.Foreach(func(kv label.KeyValue) bool {
+		kvs[kv.Key] = kv.Value.Emit()
+		return true
+	})
+	return kvs
+}
+
+func FuzzBaggageExtract(f *testing.F) {
+	f.Add("key1=val1,key2=val2")
+	f.Add("key1 =   val1,  key2 =val2   ")
+	f.Add("key1=val1,key2=val2;prop=1")
+	f.Add("key1=val%2C1,key2=%3Dval2")
+	f.Add("=,;,%zz=1,a==b")
+
+	prop := baggage.Baggage{}
+	roundTrip := func(m baggage.Map) baggage.Map {
+		req := &http.Request{Header: http.Header{}}
+		prop.Inject(baggage.ContextWithMap(context.Background(), m), req.Header)
+		return baggage.MapFromContext(prop.Extract(context.Background(), req.Header))
+	}
+	f.Fuzz(func(t *testing.T, header string) {
+		req := &http.Request{Header: http.Header{}}
+		req.Header.Set("otcorrelations", header)
+		extracted := baggage.MapFromContext(prop.Extract(context.Background(), req.Header))
+
+		// Injection normalizes whitespace, after which the baggage
+		// survives round trips unchanged and no member is lost.
+		normalized := roundTrip(extracted)
+		if normalized.Len() != extracted.Len() {
+			t.Errorf("round trip of %q: got %d members, want %d", header, normalized.Len(), extracted.Len())
+		}
+		got, want := baggageLabels(roundTrip(normalized)), baggageLabels(normalized)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip of %q: got %v, want %v", header, got, want)
+		}
+	})
+}
@@ -16,6 +16,7 @@ package baggage_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -116,6 +117,25 @@ func TestExtractValidBaggageFromHTTPReq(t *testing.T) {
 	}
 }
 
+func TestExtractBaggageFromMultipleHeaders(t *testing.T) {
+	props := propagation.New(propagation.WithExtractors(baggage.Baggage{}))
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	req.Header.Add("otcorrelations", "key1=val1")
+	req.Header.Add("otcorrelations", "key2=val2")
+
+	ctx := propagation.ExtractHTTP(context.Background(), props, req.Header)
+	gotBaggage := baggage.MapFromContext(ctx)
+	if gotBaggage.Len() != 2 {
+		t.Fatalf("Got %d baggage values, want 2", gotBaggage.Len())
+	}
+	for _, kv := range []label.KeyValue{label.String("key1", "val1"), label.String("key2", "val2")} {
+		val, _ := gotBaggage.Value(kv.Key)
+		if diff := cmp.Diff(kv, label.KeyValue{Key: kv.Key, Value: val}, cmp.AllowUnexported(label.Value{})); diff != "" {
+			t.Errorf("Extract baggage: -got +want %s", diff)
+		}
+	}
+}
+
 func TestExtractInvalidDistributedContextFromHTTPReq(t *testing.T) {
 	props := propagation.New(propagation.WithExtractors(baggage.Baggage{}))
 	tests := []struct {
@@ -249,6 +269,88 @@ func TestInjectBaggageToHTTPReq(t *testing.T) {
 	}
 }
 
+type recordingHandler struct {
+	errs []error
+}
+
+func (h *recordingHandler) Handle(err error) {
+	h.errs = append(h.errs, err)
+}
+
+func TestInjectBaggageLimits(t *testing.T) {
+	kvs := []label.KeyValue{
+		label.String("c", "3"),
+		label.String("a", "1"),
+		label.String("tenant", "t"),
+		label.String("b", "2"),
+	}
+	tests := []struct {
+		name        string
+		propagator  baggage.Baggage
+		wantHeader  string
+		wantDropped string
+	}{
+		{
+			name:       "within limits",
+			propagator: baggage.Baggage{MaxMembers: 4, MaxHeaderSize: 100},
+			wantHeader: "a=1,b=2,c=3,tenant=t",
+		},
+		{
+			name:        "max members",
+			propagator:  baggage.Baggage{MaxMembers: 2},
+			wantHeader:  "a=1,b=2",
+			wantDropped: "c,tenant",
+		},
+		{
+			name:        "max members with priority",
+			propagator:  baggage.Baggage{MaxMembers: 2, Priority: []label.Key{"tenant", "missing"}},
+			wantHeader:  "tenant=t,a=1",
+			wantDropped: "b,c",
+		},
+		{
+			name:        "max header size",
+			propagator:  baggage.Baggage{MaxHeaderSize: 12, Priority: []label.Key{"tenant"}},
+			wantHeader:  "tenant=t,a=1",
+			wantDropped: "b,c",
+		},
+		{
+			name:        "smaller members fill the header",
+			propagator:  baggage.Baggage{MaxHeaderSize: 5, Priority: []label.Key{"tenant"}},
+			wantHeader:  "a=1",
+			wantDropped: "tenant,b,c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &recordingHandler{}
+			tt.propagator.ErrorHandler = h
+			props := propagation.New(propagation.WithInjectors(tt.propagator))
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			ctx := baggage.NewContext(context.Background(), kvs...)
+			propagation.InjectHTTP(ctx, props, req.Header)
+
+			if got := req.Header.Get("otcorrelations"); got != tt.wantHeader {
+				t.Errorf("header: got %q, want %q", got, tt.wantHeader)
+			}
+			if tt.wantDropped == "" {
+				if len(h.errs) != 0 {
+					t.Errorf("unexpected errors: %v", h.errs)
+				}
+				return
+			}
+			if len(h.errs) != 1 {
+				t.Fatalf("got %d errors, want 1", len(h.errs))
+			}
+			if !errors.Is(h.errs[0], baggage.ErrMembersDropped) {
+				t.Errorf("error %v does not wrap ErrMembersDropped", h.errs[0])
+			}
+			if !strings.HasSuffix(h.errs[0].Error(), ": "+tt.wantDropped) {
+				t.Errorf("error %q does not list dropped members %q", h.errs[0], tt.wantDropped)
+			}
+		})
+	}
+}
+
 func TestTraceContextPropagator_GetAllKeys(t *testing.T) {
 	var propagator baggage.Baggage
 	want := []string{"otcorrelations"}
@@ -16,11 +16,11 @@ package global
 
 import (
 	"log"
-	"os"
 	"sync"
 	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/internal/diag"
 )
 
 var (
@@ -28,9 +28,9 @@ var (
 	// throughout an OpenTelemetry instrumented project. When a user
 	// specified ErrorHandler is registered (`SetErrorHandler`) all calls to
 	// `Handle` and will be delegated to the registered ErrorHandler.
-	globalErrorHandler = &loggingErrorHandler{
-		l: log.New(os.Stderr, "", log.LstdFlags),
-	}
+	// Errors are filtered according to the diagnostics configuration
+	// (`otel.ConfigureDiagnostics`) first.
+	globalErrorHandler = &loggingErrorHandler{}
 
 	// delegateErrorHandlerOnce ensures that a user provided ErrorHandler is
 	// only ever registered once.
@@ -40,10 +40,12 @@ var (
 	_ otel.ErrorHandler = (*loggingErrorHandler)(nil)
 )
 
-// loggingErrorHandler logs all errors to STDERR.
+// loggingErrorHandler logs all errors to STDERR, or the logger
+// configured with `otel.ConfigureDiagnostics`.
 type loggingErrorHandler struct {
 	delegate atomic.Value
 
+	// l, if not nil, is used instead of the configured logger.
 	l *log.Logger
 }
 
@@ -58,11 +60,21 @@ func (h *loggingErrorHandler) setDelegate(d otel.ErrorHandler) {
 
 // Handle implements otel.ErrorHandler.
 func (h *loggingErrorHandler) Handle(err error) {
+	for _, e := range diag.Filter(err) {
+		h.handle(e)
+	}
+}
+
+func (h *loggingErrorHandler) handle(err error) {
 	if d := h.delegate.Load(); d != nil {
 		d.(otel.ErrorHandler).Handle(err)
 		return
 	}
-	h.l.Print(err)
+	l := h.l
+	if l == nil {
+		l = diag.Logger()
+	}
+	l.Print(err)
 }
 
 // ErrorHandler returns the global ErrorHandler instance. If no ErrorHandler
@@ -161,7 +161,7 @@ func (p *meterProvider) Meter(instrumentationName string, opts ...metric.MeterOp
 		p.meters[key] = entry
 
 	}
-	return metric.WrapMeterImpl(entry.unique, key.Name, metric.WithInstrumentationVersion(key.Version))
+	return metric.WrapMeterImpl(entry.unique, key.Name, opts...)
 }
 
 // Meter interface and delegation
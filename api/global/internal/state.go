@@ -44,8 +44,10 @@ var (
 	globalMeter       = defaultMeterValue()
 	globalPropagators = defaultPropagatorsValue()
 
-	delegateMeterOnce sync.Once
-	delegateTraceOnce sync.Once
+	// delegateMeterOnce and delegateTraceOnce hold a *sync.Once,
+	// swapped by SwapForTest.
+	delegateMeterOnce = defaultOnceValue()
+	delegateTraceOnce = defaultOnceValue()
 )
 
 // TracerProvider is the internal implementation for global.TracerProvider.
@@ -55,7 +57,7 @@ func TracerProvider() trace.Provider {
 
 // SetTracerProvider is the internal implementation for global.SetTracerProvider.
 func SetTracerProvider(tp trace.Provider) {
-	delegateTraceOnce.Do(func() {
+	delegateTraceOnce.Load().(*sync.Once).Do(func() {
 		current := TracerProvider()
 		if current == tp {
 			// Setting the provider to the prior default is nonsense, panic.
@@ -77,7 +79,7 @@ func MeterProvider() metric.Provider {
 
 // SetMeterProvider is the internal implementation for global.SetMeterProvider.
 func SetMeterProvider(mp metric.Provider) {
-	delegateMeterOnce.Do(func() {
+	delegateMeterOnce.Load().(*sync.Once).Do(func() {
 		current := MeterProvider()
 
 		if current == mp {
@@ -120,6 +122,12 @@ func defaultPropagatorsValue() *atomic.Value {
 	return v
 }
 
+func defaultOnceValue() *atomic.Value {
+	v := &atomic.Value{}
+	v.Store(new(sync.Once))
+	return v
+}
+
 // getDefaultPropagators returns a default Propagators, configured
 // with W3C trace and baggage propagation.
 func getDefaultPropagators() propagation.Propagators {
@@ -136,6 +144,32 @@ func ResetForTest() {
 	globalTracer = defaultTracerValue()
 	globalMeter = defaultMeterValue()
 	globalPropagators = defaultPropagatorsValue()
-	delegateMeterOnce = sync.Once{}
-	delegateTraceOnce = sync.Once{}
+	delegateMeterOnce = defaultOnceValue()
+	delegateTraceOnce = defaultOnceValue()
+}
+
+// SwapForTest restores the initial global state and returns a function
+// restoring the state before the call, for testing purposes.  Unlike
+// ResetForTest, it updates the global providers and propagators
+// atomically, so that concurrent readers observe either state.
+func SwapForTest() (restore func()) {
+	tracer := globalTracer.Load()
+	meter := globalMeter.Load()
+	propagators := globalPropagators.Load()
+	meterOnce := delegateMeterOnce.Load()
+	traceOnce := delegateTraceOnce.Load()
+
+	globalTracer.Store(defaultTracerValue().Load())
+	globalMeter.Store(defaultMeterValue().Load())
+	globalPropagators.Store(defaultPropagatorsValue().Load())
+	delegateMeterOnce.Store(new(sync.Once))
+	delegateTraceOnce.Store(new(sync.Once))
+
+	return func() {
+		globalTracer.Store(tracer)
+		globalMeter.Store(meter)
+		globalPropagators.Store(propagators)
+		delegateMeterOnce.Store(meterOnce)
+		delegateTraceOnce.Store(traceOnce)
+	}
 }
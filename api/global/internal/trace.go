@@ -102,7 +102,7 @@ type tracer struct {
 }
 
 // Compile-time guarantee that tracer implements the trace.Tracer interface.
-var _ trace.Tracer = &tracer{}
+var _ trace.EnabledTracer = &tracer{}
 
 // setDelegate configures t to delegate all Tracer functionality to Tracers
 // created by provider.
@@ -123,3 +123,16 @@ func (t *tracer) Start(ctx context.Context, name string, opts ...trace.SpanOptio
 	}
 	return noop.Tracer.Start(ctx, name, opts...)
 }
+
+// Enabled implements trace.EnabledTracer by forwarding the call to
+// t.delegate if set, otherwise it returns false.  It returns true if
+// t.delegate does not implement trace.EnabledTracer.
+func (t *tracer) Enabled(ctx context.Context, opts ...trace.SpanOption) bool {
+	if t.delegate == nil {
+		return false
+	}
+	if et, ok := t.delegate.(trace.EnabledTracer); ok {
+		return et.Enabled(ctx, opts...)
+	}
+	return true
+}
@@ -22,6 +22,8 @@ import (
 
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/global/internal"
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/api/trace/tracetest"
 )
 
@@ -61,3 +63,31 @@ func TestTraceWithSDK(t *testing.T) {
 	assert.ElementsMatch(t, expected, filterNames(sr.Started()))
 	assert.ElementsMatch(t, expected, filterNames(sr.Completed()))
 }
+
+func TestTraceEnabledWithSDK(t *testing.T) {
+	internal.ResetForTest()
+
+	ctx := context.Background()
+	tracer := global.TracerProvider().Tracer("pre").(trace.EnabledTracer)
+	assert.False(t, tracer.Enabled(ctx), "Tracer enabled before an SDK is registered")
+
+	global.SetTracerProvider(tracetest.NewProvider())
+	assert.True(t, tracer.Enabled(ctx), "Tracer not enabled after an SDK is registered")
+}
+
+func TestSwapForTestConcurrentSet(t *testing.T) {
+	internal.ResetForTest()
+	defer internal.ResetForTest()
+
+	// Setting the providers while the global state is swapped must
+	// not race, which `go test -race` verifies.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		global.SetTracerProvider(trace.NoopProvider())
+		global.SetMeterProvider(metric.NoopProvider{})
+	}()
+	restore := internal.SwapForTest()
+	<-done
+	restore()
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/api/global/internal"
+)
+
+// TB is the subset of testing.TB used by ResetForTest.  It is declared
+// here so that this package does not depend on the testing package.
+type TB interface {
+	Helper()
+	Cleanup(func())
+}
+
+// resetLock is held from a call of ResetForTest to the end of the test.
+var resetLock sync.Mutex
+
+// ResetForTest restores the initial global TracerProvider,
+// MeterProvider and Propagators for the duration of the test t, and
+// restores the state before the call when t and its subtests complete.
+// Tracers and Meters obtained from the initial providers during the
+// test delegate to the providers the test sets, as they would at the
+// start of a process.
+//
+// Tests calling ResetForTest do not run concurrently with each other:
+// the call blocks until the previous test calling it has completed, so
+// that parallel tests setting the globals do not interfere.  It must
+// not be called again by t or its subtests, which would deadlock.  It
+// does not isolate tests that set the globals without calling it, nor
+// the global ErrorHandler, which may only be set once.
+//
+// A parallel test must call ResetForTest after t.Parallel.  Called
+// before it, the lock is held while the test is paused waiting for its
+// sequential siblings to complete, and a sibling calling ResetForTest
+// deadlocks:
+//
+//	func TestGlobals(t *testing.T) {
+//		t.Parallel()
+//		global.ResetForTest(t)
+//		// ...
+//	}
+//
+// ResetForTest is meant for tests only.
+func ResetForTest(t TB) {
+	t.Helper()
+	resetLock.Lock()
+	restore := internal.SwapForTest()
+	t.Cleanup(func() {
+		restore()
+		resetLock.Unlock()
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global_test
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+func TestResetForTest(t *testing.T) {
+	before := global.TracerProvider()
+	beforePropagators := global.Propagators()
+
+	t.Run("reset", func(t *testing.T) {
+		global.ResetForTest(t)
+		initial := global.TracerProvider()
+		if initial == before {
+			t.Fatalf("TracerProvider was not reset")
+		}
+
+		p := &testTracerProvider{}
+		global.SetTracerProvider(p)
+		global.SetPropagators(propagation.New())
+		if got := global.TracerProvider(); got != p {
+			t.Fatalf("Provider: got %p, want %p", got, p)
+		}
+	})
+
+	if got := global.TracerProvider(); got != before {
+		t.Fatalf("Provider not restored: got %p, want %p", got, before)
+	}
+	if got := global.Propagators(); got != beforePropagators {
+		t.Fatalf("Propagators not restored")
+	}
+
+	t.Run("parallel", func(t *testing.T) {
+		for _, name := range []string{"a", "b"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				global.ResetForTest(t)
+				p := trace.NoopProvider()
+				global.SetTracerProvider(p)
+				if got := global.TracerProvider(); got != p {
+					t.Fatalf("Provider: got %p, want %p", got, p)
+				}
+			})
+		}
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/metric/metrictest"
@@ -65,6 +66,10 @@ func TestOptions(t *testing.T) {
 		opts []metric.InstrumentOption
 		desc string
 		unit unit.Unit
+		keys []label.Key
+		max  int
+
+		boundaries []float64
 	}
 	testcases := []testcase{
 		{
@@ -107,12 +112,52 @@ func TestOptions(t *testing.T) {
 			desc: "",
 			unit: "h",
 		},
+		{
+			name: "label keys",
+			opts: []metric.InstrumentOption{
+				metric.WithLabelKeys("A", "B"),
+			},
+			desc: "",
+			unit: "",
+			keys: []label.Key{"A", "B"},
+		},
+		{
+			name: "label keys override",
+			opts: []metric.InstrumentOption{
+				metric.WithLabelKeys("A", "B"),
+				metric.WithLabelKeys("C"),
+			},
+			desc: "",
+			unit: "",
+			keys: []label.Key{"C"},
+		},
+		{
+			name: "max label sets",
+			opts: []metric.InstrumentOption{
+				metric.WithMaxLabelSets(10),
+			},
+			desc: "",
+			unit: "",
+			max:  10,
+		},
+		{
+			name: "histogram boundaries",
+			opts: []metric.InstrumentOption{
+				metric.WithHistogramBoundaries(1, 5, 10),
+			},
+			desc:       "",
+			unit:       "",
+			boundaries: []float64{1, 5, 10},
+		},
 	}
 	for idx, tt := range testcases {
 		t.Logf("Testing counter case %s (%d)", tt.name, idx)
 		if diff := cmp.Diff(metric.NewInstrumentConfig(tt.opts...), metric.InstrumentConfig{
-			Description: tt.desc,
-			Unit:        tt.unit,
+			Description:         tt.desc,
+			Unit:                tt.unit,
+			LabelKeys:           tt.keys,
+			MaxLabelSets:        tt.max,
+			HistogramBoundaries: tt.boundaries,
 		}); diff != "" {
 			t.Errorf("Compare options: -got +want %s", diff)
 		}
@@ -206,6 +251,130 @@ func TestValueRecorder(t *testing.T) {
 	})
 }
 
+func TestValueRecorderDuration(t *testing.T) {
+	t.Run("float64 default unit", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		m := Must(meter).NewFloat64ValueRecorder("test.duration.float")
+		ctx := context.Background()
+		labels := []label.KeyValue{}
+		m.RecordDuration(ctx, 1500*time.Microsecond, labels...)
+		m.Bind(labels...).RecordDuration(ctx, 2*time.Millisecond)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Float64NumberKind, metric.ValueRecorderKind, m.SyncImpl(),
+			1.5, 2,
+		)
+	})
+	t.Run("float64 seconds", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		m := Must(meter).NewFloat64ValueRecorder("test.duration.float", metric.WithUnit(unit.Seconds))
+		ctx := context.Background()
+		labels := []label.KeyValue{label.String("A", "B")}
+		m.RecordDuration(ctx, 250*time.Millisecond, labels...)
+		m.Bind(labels...).RecordDuration(ctx, 3*time.Second)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Float64NumberKind, metric.ValueRecorderKind, m.SyncImpl(),
+			0.25, 3,
+		)
+	})
+	t.Run("int64 microseconds", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		m := Must(meter).NewInt64ValueRecorder("test.duration.int", metric.WithUnit(unit.Microseconds))
+		ctx := context.Background()
+		labels := []label.KeyValue{label.Int("I", 1)}
+		m.RecordDuration(ctx, 1500*time.Nanosecond, labels...)
+		m.Bind(labels...).RecordDuration(ctx, time.Millisecond)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Int64NumberKind, metric.ValueRecorderKind, m.SyncImpl(),
+			1, 1000,
+		)
+	})
+}
+
+func TestDurationConversion(t *testing.T) {
+	d := 90*time.Second + 500*time.Nanosecond
+	assert.Equal(t, 90.0000005, metric.DurationFloat64(d, unit.Seconds))
+	assert.Equal(t, 90000.0005, metric.DurationFloat64(d, unit.Milliseconds))
+	assert.Equal(t, 90000000.5, metric.DurationFloat64(d, unit.Microseconds))
+	assert.Equal(t, 90000000500.0, metric.DurationFloat64(d, unit.Nanoseconds))
+	assert.Equal(t, 90000.0005, metric.DurationFloat64(d, unit.Dimensionless))
+
+	assert.Equal(t, int64(90), metric.DurationInt64(d, unit.Seconds))
+	assert.Equal(t, int64(90000), metric.DurationInt64(d, unit.Milliseconds))
+	assert.Equal(t, int64(-90000), metric.DurationInt64(-d, unit.Milliseconds))
+	assert.Equal(t, int64(90000000500), metric.DurationInt64(d, unit.Nanoseconds))
+}
+
+func TestCumulativeCounters(t *testing.T) {
+	t.Run("int64 counter", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		c := Must(meter).NewInt64Counter("test.cumulative.counter.int")
+		cc := metric.NewInt64CumulativeCounter(c)
+		ctx := context.Background()
+		labels := []label.KeyValue{label.String("A", "B")}
+		cc.Set(ctx, 10, labels...)
+		cc.Set(ctx, 15, labels...)
+		cc.Set(ctx, 15, labels...)
+		// A reset is added in full.
+		cc.Set(ctx, 3, labels...)
+		cc.Forget(labels...)
+		cc.Set(ctx, 7, labels...)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Int64NumberKind, metric.CounterKind, c.SyncImpl(),
+			10, 5, 0, 3, 7,
+		)
+	})
+	t.Run("float64 counter", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		c := Must(meter).NewFloat64Counter("test.cumulative.counter.float")
+		cc := metric.NewFloat64CumulativeCounter(c)
+		ctx := context.Background()
+		labels := []label.KeyValue{label.String("A", "B")}
+		cc.Set(ctx, 1.5, labels...)
+		cc.Set(ctx, 4, labels...)
+		cc.Set(ctx, 0.5, labels...)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Float64NumberKind, metric.CounterKind, c.SyncImpl(),
+			1.5, 2.5, 0.5,
+		)
+	})
+	t.Run("int64 updowncounter", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		c := Must(meter).NewInt64UpDownCounter("test.cumulative.updowncounter.int")
+		cc := metric.NewInt64CumulativeUpDownCounter(c)
+		ctx := context.Background()
+		labels := []label.KeyValue{label.String("A", "B")}
+		cc.Set(ctx, 10, labels...)
+		cc.Set(ctx, 4, labels...)
+		cc.Set(ctx, -2, labels...)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Int64NumberKind, metric.UpDownCounterKind, c.SyncImpl(),
+			10, -6, -6,
+		)
+	})
+	t.Run("float64 updowncounter", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		c := Must(meter).NewFloat64UpDownCounter("test.cumulative.updowncounter.float")
+		cc := metric.NewFloat64CumulativeUpDownCounter(c)
+		ctx := context.Background()
+		labels := []label.KeyValue{label.String("A", "B")}
+		cc.Set(ctx, 2.5, labels...)
+		cc.Set(ctx, 1, labels...)
+		checkSyncBatches(ctx, t, labels, mockSDK, metric.Float64NumberKind, metric.UpDownCounterKind, c.SyncImpl(),
+			2.5, -1.5,
+		)
+	})
+	t.Run("label sets", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		c := Must(meter).NewInt64Counter("test.cumulative.counter.labels")
+		cc := metric.NewInt64CumulativeCounter(c)
+		ctx := context.Background()
+		cc.Set(ctx, 10, label.String("A", "B"), label.String("C", "D"))
+		cc.Set(ctx, 20, label.String("A", "C"))
+		// The order of the labels does not matter.
+		cc.Set(ctx, 12, label.String("C", "D"), label.String("A", "B"))
+		cc.Set(ctx, 25, label.String("A", "C"))
+
+		var values []int64
+		for _, b := range mockSDK.MeasurementBatches {
+			values = append(values, b.Measurements[0].Number.AsInt64())
+		}
+		assert.Equal(t, []int64{10, 20, 2, 5}, values)
+	})
+}
 func TestObserverInstruments(t *testing.T) {
 	t.Run("float valueobserver", func(t *testing.T) {
 		labels := []label.KeyValue{label.String("O", "P")}
@@ -383,3 +552,15 @@ func TestNilCallbackObserverNoop(t *testing.T) {
 	_, ok := observer.AsyncImpl().(metric.NoopAsync)
 	require.True(t, ok)
 }
+
+func TestInstrumentationLabels(t *testing.T) {
+	labels := []label.KeyValue{label.String("module", "checkout")}
+	_, provider := metrictest.NewProvider()
+	meter := provider.Meter("test", metric.WithInstrumentationLabels(labels...))
+
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	require.Equal(t, labels, counter.SyncImpl().Descriptor().InstrumentationLabels())
+
+	observer := metric.Must(meter).NewInt64ValueObserver("observer", func(context.Context, metric.Int64ObserverResult) {})
+	require.Equal(t, labels, observer.AsyncImpl().Descriptor().InstrumentationLabels())
+}
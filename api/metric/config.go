@@ -14,7 +14,10 @@
 
 package metric
 
-import "go.opentelemetry.io/otel/unit"
+import (
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/unit"
+)
 
 // InstrumentConfig contains options for instrument descriptors.
 type InstrumentConfig struct {
@@ -28,6 +31,20 @@ type InstrumentConfig struct {
 	// InstrumentationVersion is the version of the library providing
 	// instrumentation.
 	InstrumentationVersion string
+	// LabelKeys is the set of label keys recommended by the
+	// instrumentation author for this instrument.  When non-empty,
+	// the SDK drops labels with other keys before aggregation.
+	LabelKeys []label.Key
+	// InstrumentationLabels are added to every measurement of the
+	// instrument.  They are set from the Meter that created it.
+	InstrumentationLabels []label.KeyValue
+	// MaxLabelSets is the maximum number of distinct label sets an
+	// instrument reports per collection.  Zero means no limit.
+	MaxLabelSets int
+	// HistogramBoundaries are the bucket boundaries recommended for
+	// the instrument when it is aggregated as a histogram.  When
+	// nil, the boundaries of the SDK's aggregator selector are used.
+	HistogramBoundaries []float64
 }
 
 // InstrumentOption is an interface for applying instrument options.
@@ -69,6 +86,51 @@ func (u unitOption) ApplyInstrument(config *InstrumentConfig) {
 	config.Unit = unit.Unit(u)
 }
 
+// WithLabelKeys sets the label keys recommended for an instrument.
+// Labels with any other key are removed by the SDK before
+// aggregation, bounding the cardinality of the instrument at its
+// source.
+func WithLabelKeys(keys ...label.Key) InstrumentOption {
+	return labelKeysOption(keys)
+}
+
+type labelKeysOption []label.Key
+
+func (l labelKeysOption) ApplyInstrument(config *InstrumentConfig) {
+	config.LabelKeys = append(config.LabelKeys[:0:0], l...)
+}
+
+// WithMaxLabelSets limits the number of distinct label sets an
+// instrument reports per collection to n.  The SDK folds measurements
+// of additional label sets into a single overflow label set, so that a
+// faulty callback or high-cardinality labels cannot produce an
+// unbounded number of series.  For synchronous instruments, the limit
+// applies to the label sets the SDK holds in memory, which includes the
+// label sets of bound instruments.  A value of zero means no limit.
+func WithMaxLabelSets(n int) InstrumentOption {
+	return maxLabelSetsOption(n)
+}
+
+type maxLabelSetsOption int
+
+func (n maxLabelSetsOption) ApplyInstrument(config *InstrumentConfig) {
+	config.MaxLabelSets = int(n)
+}
+
+// WithHistogramBoundaries sets the bucket boundaries of an instrument
+// that is aggregated as a histogram, overriding the boundaries
+// configured in the SDK for all instruments.  This has no effect on
+// instruments that the SDK aggregates otherwise.
+func WithHistogramBoundaries(boundaries ...float64) InstrumentOption {
+	return histogramBoundariesOption(boundaries)
+}
+
+type histogramBoundariesOption []float64
+
+func (h histogramBoundariesOption) ApplyInstrument(config *InstrumentConfig) {
+	config.HistogramBoundaries = append(config.HistogramBoundaries[:0:0], h...)
+}
+
 // WithInstrumentationName sets the instrumentation name.
 func WithInstrumentationName(name string) InstrumentOption {
 	return instrumentationNameOption(name)
@@ -85,6 +147,9 @@ type MeterConfig struct {
 	// InstrumentationVersion is the version of the library providing
 	// instrumentation.
 	InstrumentationVersion string
+	// InstrumentationLabels are added to every measurement of the
+	// instruments created by the Meter.
+	InstrumentationLabels []label.KeyValue
 }
 
 // MeterOption is an interface for applying Meter options.
@@ -123,3 +188,18 @@ func (i instrumentationVersionOption) ApplyMeter(config *MeterConfig) {
 func (i instrumentationVersionOption) ApplyInstrument(config *InstrumentConfig) {
 	config.InstrumentationVersion = string(i)
 }
+
+// WithInstrumentationLabels sets labels that are added to every
+// measurement of the instruments created by a Meter, e.g. to identify
+// the module of an application that made it.  Labels passed with a
+// measurement take precedence over instrumentation labels with the
+// same key.
+func WithInstrumentationLabels(labels ...label.KeyValue) MeterOption {
+	return instrumentationLabelsOption(labels)
+}
+
+type instrumentationLabelsOption []label.KeyValue
+
+func (l instrumentationLabelsOption) ApplyMeter(config *MeterConfig) {
+	config.InstrumentationLabels = append(config.InstrumentationLabels[:0:0], l...)
+}
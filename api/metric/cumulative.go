@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/label"
+)
+
+// cumulativeState remembers the last cumulative value of each label
+// set to compute the change to the next one.
+type cumulativeState struct {
+	lock sync.Mutex
+	last map[label.Distinct]Number
+}
+
+// delta returns the change from the last value of labels to value and
+// remembers value.  The first value of a label set is returned in
+// full.  If resets is true, a value lower than the last one is taken
+// as a reset of the sum to zero, and is also returned in full.
+func (s *cumulativeState) delta(kind NumberKind, value Number, resets bool, labels []label.KeyValue) Number {
+	set := label.NewSet(labels...)
+	key := set.Equivalent()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.last == nil {
+		s.last = make(map[label.Distinct]Number)
+	}
+	last, ok := s.last[key]
+	s.last[key] = value
+	if !ok || (resets && value.CompareNumber(kind, last) < 0) {
+		return value
+	}
+	d := value
+	d.AddNumber(kind, NewNumberSignChange(kind, last))
+	return d
+}
+
+// forget drops the last value of labels.
+func (s *cumulativeState) forget(labels []label.KeyValue) {
+	set := label.NewSet(labels...)
+	key := set.Equivalent()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.last, key)
+}
+
+// Int64CumulativeCounter records the cumulative values of a monotonic
+// sum, e.g., a counter read from the operating system, with an
+// Int64Counter, by adding the increase since the previous value of
+// the same label set.  A value lower than the previous one is taken
+// as a reset of the sum, e.g., by a process restart, and is added in
+// full.
+//
+// The last value of each label set is kept until Forget is called.
+// Use an Int64SumObserver instead if the values can be read from a
+// callback.
+type Int64CumulativeCounter struct {
+	counter Int64Counter
+	state   cumulativeState
+}
+
+// NewInt64CumulativeCounter returns an Int64CumulativeCounter adding
+// to c.
+func NewInt64CumulativeCounter(c Int64Counter) *Int64CumulativeCounter {
+	return &Int64CumulativeCounter{counter: c}
+}
+
+// Set adds the increase from the previous value of labels to value.
+func (c *Int64CumulativeCounter) Set(ctx context.Context, value int64, labels ...label.KeyValue) {
+	d := c.state.delta(Int64NumberKind, NewInt64Number(value), true, labels)
+	c.counter.Add(ctx, d.AsInt64(), labels...)
+}
+
+// Forget drops the previous value of labels, so that the next value
+// set for labels is added in full.
+func (c *Int64CumulativeCounter) Forget(labels ...label.KeyValue) {
+	c.state.forget(labels)
+}
+
+// Float64CumulativeCounter records the cumulative values of a
+// monotonic sum with a Float64Counter.  See Int64CumulativeCounter.
+type Float64CumulativeCounter struct {
+	counter Float64Counter
+	state   cumulativeState
+}
+
+// NewFloat64CumulativeCounter returns a Float64CumulativeCounter
+// adding to c.
+func NewFloat64CumulativeCounter(c Float64Counter) *Float64CumulativeCounter {
+	return &Float64CumulativeCounter{counter: c}
+}
+
+// Set adds the increase from the previous value of labels to value.
+func (c *Float64CumulativeCounter) Set(ctx context.Context, value float64, labels ...label.KeyValue) {
+	d := c.state.delta(Float64NumberKind, NewFloat64Number(value), true, labels)
+	c.counter.Add(ctx, d.AsFloat64(), labels...)
+}
+
+// Forget drops the previous value of labels, so that the next value
+// set for labels is added in full.
+func (c *Float64CumulativeCounter) Forget(labels ...label.KeyValue) {
+	c.state.forget(labels)
+}
+
+// Int64CumulativeUpDownCounter records the cumulative values of a
+// non-monotonic sum, e.g., a queue length read from a gauge-like
+// source, with an Int64UpDownCounter, by adding the change since the
+// previous value of the same label set.  Since the sum may decrease,
+// resets cannot be detected.
+//
+// The last value of each label set is kept until Forget is called.
+// Use an Int64UpDownSumObserver instead if the values can be read
+// from a callback.
+type Int64CumulativeUpDownCounter struct {
+	counter Int64UpDownCounter
+	state   cumulativeState
+}
+
+// NewInt64CumulativeUpDownCounter returns an
+// Int64CumulativeUpDownCounter adding to c.
+func NewInt64CumulativeUpDownCounter(c Int64UpDownCounter) *Int64CumulativeUpDownCounter {
+	return &Int64CumulativeUpDownCounter{counter: c}
+}
+
+// Set adds the change from the previous value of labels to value.
+func (c *Int64CumulativeUpDownCounter) Set(ctx context.Context, value int64, labels ...label.KeyValue) {
+	d := c.state.delta(Int64NumberKind, NewInt64Number(value), false, labels)
+	c.counter.Add(ctx, d.AsInt64(), labels...)
+}
+
+// Forget drops the previous value of labels, so that the next value
+// set for labels is added in full.
+func (c *Int64CumulativeUpDownCounter) Forget(labels ...label.KeyValue) {
+	c.state.forget(labels)
+}
+
+// Float64CumulativeUpDownCounter records the cumulative values of a
+// non-monotonic sum with a Float64UpDownCounter.  See
+// Int64CumulativeUpDownCounter.
+type Float64CumulativeUpDownCounter struct {
+	counter Float64UpDownCounter
+	state   cumulativeState
+}
+
+// NewFloat64CumulativeUpDownCounter returns a
+// Float64CumulativeUpDownCounter adding to c.
+func NewFloat64CumulativeUpDownCounter(c Float64UpDownCounter) *Float64CumulativeUpDownCounter {
+	return &Float64CumulativeUpDownCounter{counter: c}
+}
+
+// Set adds the change from the previous value of labels to value.
+func (c *Float64CumulativeUpDownCounter) Set(ctx context.Context, value float64, labels ...label.KeyValue) {
+	d := c.state.delta(Float64NumberKind, NewFloat64Number(value), false, labels)
+	c.counter.Add(ctx, d.AsFloat64(), labels...)
+}
+
+// Forget drops the previous value of labels, so that the next value
+// set for labels is added in full.
+func (c *Float64CumulativeUpDownCounter) Forget(labels ...label.KeyValue) {
+	c.state.forget(labels)
+}
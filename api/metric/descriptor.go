@@ -14,7 +14,10 @@
 
 package metric
 
-import "go.opentelemetry.io/otel/unit"
+import (
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/unit"
+)
 
 // Descriptor contains all the settings that describe an instrument,
 // including its name, metric kind, number kind, and the configurable
@@ -41,6 +44,13 @@ func (d Descriptor) Name() string {
 	return d.name
 }
 
+// Renamed returns a copy of the Descriptor with the given name.  It
+// allows an SDK to apply naming conventions to instruments.
+func (d Descriptor) Renamed(name string) Descriptor {
+	d.name = name
+	return d
+}
+
 // MetricKind returns the specific kind of instrument.
 func (d Descriptor) MetricKind() Kind {
 	return d.kind
@@ -75,3 +85,27 @@ func (d Descriptor) InstrumentationName() string {
 func (d Descriptor) InstrumentationVersion() string {
 	return d.config.InstrumentationVersion
 }
+
+// LabelKeys returns the label keys recommended for this instrument.
+// An empty result means that all labels are kept.
+func (d Descriptor) LabelKeys() []label.Key {
+	return d.config.LabelKeys
+}
+
+// MaxLabelSets returns the maximum number of distinct label sets an
+// instrument reports per collection, or zero if there is no limit.
+func (d Descriptor) MaxLabelSets() int {
+	return d.config.MaxLabelSets
+}
+
+// HistogramBoundaries returns the bucket boundaries recommended for
+// this instrument, or nil if the SDK's boundaries apply.
+func (d Descriptor) HistogramBoundaries() []float64 {
+	return d.config.HistogramBoundaries
+}
+
+// InstrumentationLabels returns the labels added to every measurement of
+// this instrument by the Meter that created it.
+func (d Descriptor) InstrumentationLabels() []label.KeyValue {
+	return d.config.InstrumentationLabels
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// durationScale returns the duration corresponding to one u.  Units
+// that are not a unit of time are treated as milliseconds.
+func durationScale(u unit.Unit) time.Duration {
+	switch u {
+	case unit.Seconds:
+		return time.Second
+	case unit.Microseconds:
+		return time.Microsecond
+	case unit.Nanoseconds:
+		return time.Nanosecond
+	default:
+		return time.Millisecond
+	}
+}
+
+// DurationFloat64 converts d to a float64 number of u.  Units that
+// are not a unit of time are treated as milliseconds.
+func DurationFloat64(d time.Duration, u unit.Unit) float64 {
+	scale := durationScale(u)
+	// Split the conversion so that no precision is lost for
+	// durations that are exact multiples of scale.
+	whole, frac := d/scale, d%scale
+	return float64(whole) + float64(frac)/float64(scale)
+}
+
+// DurationInt64 converts d to an int64 number of u, truncated toward
+// zero.  Units that are not a unit of time are treated as
+// milliseconds.
+func DurationInt64(d time.Duration, u unit.Unit) int64 {
+	return int64(d / durationScale(u))
+}
+
+// RecordDuration adds d to the ValueRecorder's distribution,
+// converted to the unit the instrument was created with (see
+// WithUnit).  The unit should be one of unit.Seconds,
+// unit.Milliseconds, unit.Microseconds or unit.Nanoseconds;
+// instruments without a unit of time record milliseconds.
+func (c Float64ValueRecorder) RecordDuration(ctx context.Context, d time.Duration, labels ...label.KeyValue) {
+	c.Record(ctx, DurationFloat64(d, c.instrument.Descriptor().Unit()), labels...)
+}
+
+// RecordDuration adds d to the ValueRecorder's distribution,
+// converted to the unit the instrument was created with and
+// truncated toward zero (see WithUnit).  The unit should be one of
+// unit.Seconds, unit.Milliseconds, unit.Microseconds or
+// unit.Nanoseconds; instruments without a unit of time record
+// milliseconds.
+func (c Int64ValueRecorder) RecordDuration(ctx context.Context, d time.Duration, labels ...label.KeyValue) {
+	c.Record(ctx, DurationInt64(d, c.instrument.Descriptor().Unit()), labels...)
+}
+
+// RecordDuration adds d to the ValueRecorder's distribution using the
+// labels previously bound via Bind(), converted to the unit the
+// instrument was created with.
+func (b BoundFloat64ValueRecorder) RecordDuration(ctx context.Context, d time.Duration) {
+	b.Record(ctx, DurationFloat64(d, b.unit))
+}
+
+// RecordDuration adds d to the ValueRecorder's distribution using the
+// labels previously bound via Bind(), converted to the unit the
+// instrument was created with and truncated toward zero.
+func (b BoundInt64ValueRecorder) RecordDuration(ctx context.Context, d time.Duration) {
+	b.Record(ctx, DurationInt64(d, b.unit))
+}
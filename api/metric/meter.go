@@ -49,6 +49,7 @@ type Provider interface {
 type Meter struct {
 	impl          MeterImpl
 	name, version string
+	labels        []label.KeyValue
 }
 
 // RecordBatch atomically records a batch of measurements.
@@ -297,6 +298,7 @@ func (m Meter) newAsync(
 	desc := NewDescriptor(name, mkind, nkind, opts...)
 	desc.config.InstrumentationName = m.name
 	desc.config.InstrumentationVersion = m.version
+	desc.config.InstrumentationLabels = m.labels
 	return m.impl.NewAsyncInstrument(desc, runner)
 }
 
@@ -316,5 +318,6 @@ func (m Meter) newSync(
 	desc := NewDescriptor(name, metricKind, numberKind, opts...)
 	desc.config.InstrumentationName = m.name
 	desc.config.InstrumentationVersion = m.version
+	desc.config.InstrumentationLabels = m.labels
 	return m.impl.NewSyncInstrument(desc)
 }
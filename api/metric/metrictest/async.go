@@ -61,6 +61,10 @@ type AsyncInstrumentState struct {
 	// instruments maintains the set of instruments in the order
 	// they were registered.
 	instruments []metric.AsyncImpl
+
+	// panicHandler, if not nil, is called with the value recovered
+	// from a panicking callback.
+	panicHandler func(recovered interface{})
 }
 
 // asyncRunnerPair is a map entry for Observer callback runners.
@@ -120,29 +124,54 @@ func (a *AsyncInstrumentState) Register(inst metric.AsyncImpl, runner metric.Asy
 	}
 }
 
+// SetPanicHandler sets a function that Run calls with the value
+// recovered from a panicking observer callback, after which Run
+// continues with the remaining callbacks.  Panics are not recovered
+// unless a handler is set.
+func (a *AsyncInstrumentState) SetPanicHandler(handler func(recovered interface{})) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.panicHandler = handler
+}
+
 // Run executes the complete set of observer callbacks.
 func (a *AsyncInstrumentState) Run(ctx context.Context, collector AsyncCollector) {
 	a.lock.Lock()
 	runners := a.runners
+	handler := a.panicHandler
 	a.lock.Unlock()
 
 	for _, rp := range runners {
-		// The runner must be a single or batch runner, no
-		// other implementations are possible because the
-		// interface has un-exported methods.
-
-		if singleRunner, ok := rp.runner.(metric.AsyncSingleRunner); ok {
-			singleRunner.Run(ctx, rp.inst, collector.CollectAsync)
-			continue
-		}
-
-		if multiRunner, ok := rp.runner.(metric.AsyncBatchRunner); ok {
-			multiRunner.Run(ctx, collector.CollectAsync)
-			continue
-		}
-
-		a.errorOnce.Do(func() {
-			global.Handle(fmt.Errorf("%w: type %T (reported once)", ErrInvalidAsyncRunner, rp))
-		})
+		a.run(ctx, rp, collector, handler)
+	}
+}
+
+// run executes the observer callback of rp, passing a panic of the
+// callback to handler if it is not nil.
+func (a *AsyncInstrumentState) run(ctx context.Context, rp asyncRunnerPair, collector AsyncCollector, handler func(interface{})) {
+	if handler != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				handler(r)
+			}
+		}()
+	}
+
+	// The runner must be a single or batch runner, no
+	// other implementations are possible because the
+	// interface has un-exported methods.
+
+	if singleRunner, ok := rp.runner.(metric.AsyncSingleRunner); ok {
+		singleRunner.Run(ctx, rp.inst, collector.CollectAsync)
+		return
 	}
+
+	if multiRunner, ok := rp.runner.(metric.AsyncBatchRunner); ok {
+		multiRunner.Run(ctx, collector.CollectAsync)
+		return
+	}
+
+	a.errorOnce.Do(func() {
+		global.Handle(fmt.Errorf("%w: type %T (reported once)", ErrInvalidAsyncRunner, rp))
+	})
 }
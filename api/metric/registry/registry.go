@@ -16,9 +16,11 @@ package registry // import "go.opentelemetry.io/otel/api/metric/registry"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/label"
 )
@@ -34,9 +36,10 @@ var _ metric.Provider = (*Provider)(nil)
 // uniqueness checking for instrument descriptors.  Use NewUniqueInstrumentMeter
 // to wrap an implementation with uniqueness checking.
 type uniqueInstrumentMeterImpl struct {
-	lock  sync.Mutex
-	impl  metric.MeterImpl
-	state map[key]metric.InstrumentImpl
+	lock   sync.Mutex
+	impl   metric.MeterImpl
+	state  map[key]metric.InstrumentImpl
+	config Config
 }
 
 var _ metric.MeterImpl = (*uniqueInstrumentMeterImpl)(nil)
@@ -45,13 +48,75 @@ type key struct {
 	instrumentName         string
 	instrumentationName    string
 	InstrumentationVersion string
+	// instrumentationLabels distinguishes the instruments of
+	// meters with different InstrumentationLabels, which are
+	// added to the measurements of their instruments.
+	instrumentationLabels label.Distinct
+}
+
+// DuplicateInstrumentPolicy determines how an instrument is handled
+// that has the same name, kind and number type as an instrument
+// already registered, but a different unit or description.
+type DuplicateInstrumentPolicy int
+
+const (
+	// LenientDuplicates returns the instrument already registered
+	// and reports an ErrDuplicateInstrument error to the
+	// ErrorHandler, if one is configured.
+	LenientDuplicates DuplicateInstrumentPolicy = iota
+
+	// StrictDuplicates returns an ErrDuplicateInstrument error.
+	StrictDuplicates
+)
+
+// Config contains configuration for uniqueness checking.
+type Config struct {
+	// DuplicateInstrumentPolicy determines how instruments that
+	// differ from the registered instrument of the same name only
+	// by their unit or description are handled.  The default is
+	// LenientDuplicates.
+	DuplicateInstrumentPolicy DuplicateInstrumentPolicy
+
+	// ErrorHandler, if not nil, is reported the conflicts resolved
+	// by LenientDuplicates.
+	ErrorHandler otel.ErrorHandler
+}
+
+// Option is the interface that applies a value to a Config.
+type Option interface {
+	// Apply sets the Option value of a Config.
+	Apply(*Config)
+}
+
+// WithDuplicateInstrumentPolicy sets the DuplicateInstrumentPolicy
+// configuration option of a Config.
+func WithDuplicateInstrumentPolicy(policy DuplicateInstrumentPolicy) Option {
+	return duplicateInstrumentPolicyOption(policy)
+}
+
+type duplicateInstrumentPolicyOption DuplicateInstrumentPolicy
+
+func (o duplicateInstrumentPolicyOption) Apply(config *Config) {
+	config.DuplicateInstrumentPolicy = DuplicateInstrumentPolicy(o)
+}
+
+// WithErrorHandler sets the ErrorHandler configuration option of a
+// Config.
+func WithErrorHandler(h otel.ErrorHandler) Option {
+	return errorHandlerOption{h}
+}
+
+type errorHandlerOption struct{ otel.ErrorHandler }
+
+func (o errorHandlerOption) Apply(config *Config) {
+	config.ErrorHandler = o.ErrorHandler
 }
 
 // NewProvider returns a new provider that implements instrument
 // name-uniqueness checking.
-func NewProvider(impl metric.MeterImpl) *Provider {
+func NewProvider(impl metric.MeterImpl, opts ...Option) *Provider {
 	return &Provider{
-		impl: NewUniqueInstrumentMeterImpl(impl),
+		impl: NewUniqueInstrumentMeterImpl(impl, opts...),
 	}
 }
 
@@ -65,13 +130,22 @@ func (p *Provider) Meter(instrumentationName string, opts ...metric.MeterOption)
 var ErrMetricKindMismatch = fmt.Errorf(
 	"A metric was already registered by this name with another kind or number type")
 
+// ErrDuplicateInstrument is the error for instrument definitions that
+// differ from the registered instrument of the same name only by their
+// unit or description.
+var ErrDuplicateInstrument = errors.New("a metric was already registered by this name with another unit or description")
+
 // NewUniqueInstrumentMeterImpl returns a wrapped metric.MeterImpl with
 // the addition of uniqueness checking.
-func NewUniqueInstrumentMeterImpl(impl metric.MeterImpl) metric.MeterImpl {
-	return &uniqueInstrumentMeterImpl{
+func NewUniqueInstrumentMeterImpl(impl metric.MeterImpl, opts ...Option) metric.MeterImpl {
+	u := &uniqueInstrumentMeterImpl{
 		impl:  impl,
 		state: map[key]metric.InstrumentImpl{},
 	}
+	for _, opt := range opts {
+		opt.Apply(&u.config)
+	}
+	return u
 }
 
 // RecordBatch implements metric.MeterImpl.
@@ -80,10 +154,12 @@ func (u *uniqueInstrumentMeterImpl) RecordBatch(ctx context.Context, labels []la
 }
 
 func keyOf(descriptor metric.Descriptor) key {
+	labels := label.NewSet(descriptor.InstrumentationLabels()...)
 	return key{
 		descriptor.Name(),
 		descriptor.InstrumentationName(),
 		descriptor.InstrumentationVersion(),
+		labels.Equivalent(),
 	}
 }
 
@@ -106,19 +182,47 @@ func Compatible(candidate, existing metric.Descriptor) bool {
 		candidate.NumberKind() == existing.NumberKind()
 }
 
+// NewDuplicateInstrumentError formats an error that describes an
+// instrument definition differing from the registered desc by its unit
+// or description.
+func NewDuplicateInstrumentError(candidate, desc metric.Descriptor) error {
+	return fmt.Errorf("metric %s (%s %s) registered with unit %q and description %q, not %q and %q: %w",
+		desc.Name(),
+		desc.InstrumentationName(),
+		desc.InstrumentationVersion(),
+		desc.Unit(),
+		desc.Description(),
+		candidate.Unit(),
+		candidate.Description(),
+		ErrDuplicateInstrument)
+}
+
 // checkUniqueness returns an ErrMetricKindMismatch error if there is
 // a conflict between a descriptor that was already registered and the
 // `descriptor` argument.  If there is an existing compatible
-// registration, this returns the already-registered instrument.  If
-// there is no conflict and no prior registration, returns (nil, nil).
+// registration, this returns the already-registered instrument, unless
+// its unit or description differ and the policy is StrictDuplicates.
+// If there is no conflict and no prior registration, returns (nil,
+// nil).
 func (u *uniqueInstrumentMeterImpl) checkUniqueness(descriptor metric.Descriptor) (metric.InstrumentImpl, error) {
 	impl, ok := u.state[keyOf(descriptor)]
 	if !ok {
 		return nil, nil
 	}
 
-	if !Compatible(descriptor, impl.Descriptor()) {
-		return nil, NewMetricKindMismatchError(impl.Descriptor())
+	existing := impl.Descriptor()
+	if !Compatible(descriptor, existing) {
+		return nil, NewMetricKindMismatchError(existing)
+	}
+
+	if descriptor.Unit() != existing.Unit() || descriptor.Description() != existing.Description() {
+		err := NewDuplicateInstrumentError(descriptor, existing)
+		if u.config.DuplicateInstrumentPolicy == StrictDuplicates {
+			return nil, err
+		}
+		if u.config.ErrorHandler != nil {
+			u.config.ErrorHandler.Handle(err)
+		}
 	}
 
 	return impl, nil
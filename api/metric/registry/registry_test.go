@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/api/metric"
 	mockTest "go.opentelemetry.io/otel/api/metric/metrictest"
 	"go.opentelemetry.io/otel/api/metric/registry"
+	"go.opentelemetry.io/otel/label"
 )
 
 type (
@@ -99,6 +100,32 @@ func TestRegistryDifferentNamespace(t *testing.T) {
 	}
 }
 
+func TestRegistryDifferentInstrumentationLabels(t *testing.T) {
+	for _, nf := range allNew {
+		_, provider := mockTest.NewProvider()
+
+		meter1 := provider.Meter("meter", metric.WithInstrumentationLabels(label.String("A", "1")))
+		meter2 := provider.Meter("meter", metric.WithInstrumentationLabels(label.String("A", "2")))
+		inst1, err1 := nf(meter1, "this")
+		inst2, err2 := nf(meter2, "this")
+
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NotEqual(t, inst1, inst2)
+		require.Equal(t, []label.KeyValue{label.String("A", "2")}, inst2.Descriptor().InstrumentationLabels())
+
+		// The order of the labels does not matter.
+		meter3 := provider.Meter("meter", metric.WithInstrumentationLabels(label.String("B", "1"), label.String("A", "1")))
+		meter4 := provider.Meter("meter", metric.WithInstrumentationLabels(label.String("A", "1"), label.String("B", "1")))
+		inst3, err3 := nf(meter3, "this")
+		inst4, err4 := nf(meter4, "this")
+
+		require.NoError(t, err3)
+		require.NoError(t, err4)
+		require.Equal(t, inst3, inst4)
+	}
+}
+
 func TestRegistryDiffInstruments(t *testing.T) {
 	for origName, origf := range allNew {
 		_, provider := mockTest.NewProvider()
@@ -130,3 +157,40 @@ func TestProvider(t *testing.T) {
 	require.Equal(t, m1, m1p)
 	require.NotEqual(t, m1, m2)
 }
+
+type recordingHandler struct {
+	errs []error
+}
+
+func (h *recordingHandler) Handle(err error) {
+	h.errs = append(h.errs, err)
+}
+
+func TestRegistryDuplicateInstruments(t *testing.T) {
+	impl, _ := mockTest.NewMeter()
+	h := &recordingHandler{}
+	lenient := registry.NewProvider(impl, registry.WithErrorHandler(h)).Meter("lenient")
+
+	inst1, err := lenient.NewInt64Counter("this", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	inst2, err := lenient.NewInt64Counter("this", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	require.Equal(t, inst1, inst2)
+	require.Empty(t, h.errs)
+
+	inst3, err := lenient.NewInt64Counter("this", metric.WithUnit("s"), metric.WithDescription("seconds"))
+	require.NoError(t, err)
+	require.Equal(t, inst1, inst3)
+	require.Len(t, h.errs, 1)
+	require.True(t, errors.Is(h.errs[0], registry.ErrDuplicateInstrument))
+
+	strict := registry.NewProvider(impl,
+		registry.WithDuplicateInstrumentPolicy(registry.StrictDuplicates),
+	).Meter("strict")
+	_, err = strict.NewInt64Counter("this", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	_, err = strict.NewInt64Counter("this", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	_, err = strict.NewInt64Counter("this", metric.WithUnit("s"))
+	require.True(t, errors.Is(err, registry.ErrDuplicateInstrument))
+}
@@ -86,9 +86,11 @@ type AsyncImpl interface {
 // WrapMeterImpl constructs a `Meter` implementation from a
 // `MeterImpl` implementation.
 func WrapMeterImpl(impl MeterImpl, instrumentationName string, opts ...MeterOption) Meter {
+	config := NewMeterConfig(opts...)
 	return Meter{
 		impl:    impl,
 		name:    instrumentationName,
-		version: NewMeterConfig(opts...).InstrumentationVersion,
+		version: config.InstrumentationVersion,
+		labels:  config.InstrumentationLabels,
 	}
 }
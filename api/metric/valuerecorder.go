@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/unit"
 )
 
 // Float64ValueRecorder is a metric that records float64 values.
@@ -35,6 +36,9 @@ type Int64ValueRecorder struct {
 // It inherits the Unbind function from syncBoundInstrument.
 type BoundFloat64ValueRecorder struct {
 	syncBoundInstrument
+
+	// unit is the unit of the instrument, used by RecordDuration.
+	unit unit.Unit
 }
 
 // BoundInt64ValueRecorder is a bound instrument for Int64ValueRecorder.
@@ -42,12 +46,16 @@ type BoundFloat64ValueRecorder struct {
 // It inherits the Unbind function from syncBoundInstrument.
 type BoundInt64ValueRecorder struct {
 	syncBoundInstrument
+
+	// unit is the unit of the instrument, used by RecordDuration.
+	unit unit.Unit
 }
 
 // Bind creates a bound instrument for this ValueRecorder. The labels are
 // associated with values recorded via subsequent calls to Record.
 func (c Float64ValueRecorder) Bind(labels ...label.KeyValue) (h BoundFloat64ValueRecorder) {
 	h.syncBoundInstrument = c.bind(labels)
+	h.unit = c.instrument.Descriptor().Unit()
 	return
 }
 
@@ -55,6 +63,7 @@ func (c Float64ValueRecorder) Bind(labels ...label.KeyValue) (h BoundFloat64Valu
 // associated with values recorded via subsequent calls to Record.
 func (c Int64ValueRecorder) Bind(labels ...label.KeyValue) (h BoundInt64ValueRecorder) {
 	h.syncBoundInstrument = c.bind(labels)
+	h.unit = c.instrument.Descriptor().Unit()
 	return
 }
 
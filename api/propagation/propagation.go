@@ -101,6 +101,23 @@ func New(options ...Option) Propagators {
 	}
 }
 
+// NewExtractOnly returns Propagators that extract using the extractors
+// of p and have no injectors.
+//
+// Together with NewInjectOnly, this lets a gateway accept several
+// inbound formats while emitting a single format downstream, by
+// passing NewExtractOnly(inbound) to server instrumentation and
+// NewInjectOnly(outbound) to client instrumentation.
+func NewExtractOnly(p Propagators) Propagators {
+	return New(WithExtractors(p.HTTPExtractors()...))
+}
+
+// NewInjectOnly returns Propagators that inject using the injectors of
+// p and have no extractors.
+func NewInjectOnly(p Propagators) Propagators {
+	return New(WithInjectors(p.HTTPInjectors()...))
+}
+
 // WithInjectors appends to the optional injector set.
 func WithInjectors(inj ...HTTPInjector) Option {
 	return func(config *Config) {
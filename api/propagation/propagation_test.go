@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/api/propagation"
+)
+
+var (
+	_ propagation.HTTPSupplier   = http.Header{}
+	_ propagation.ValuesSupplier = http.Header{}
+	_ propagation.HTTPSupplier   = propagation.MetadataSupplier{}
+)
+
+type ctxKey string
+
+type testPropagator string
+
+func (p testPropagator) Extract(ctx context.Context, supplier propagation.HTTPSupplier) context.Context {
+	return context.WithValue(ctx, ctxKey(p), supplier.Get(string(p)))
+}
+
+func (p testPropagator) Inject(ctx context.Context, supplier propagation.HTTPSupplier) {
+	supplier.Set(string(p), "injected")
+}
+
+func (p testPropagator) GetAllKeys() []string { return []string{string(p)} }
+
+func newTestPropagators(names ...string) propagation.Propagators {
+	var opts []propagation.Option
+	for _, n := range names {
+		opts = append(opts,
+			propagation.WithExtractors(testPropagator(n)),
+			propagation.WithInjectors(testPropagator(n)),
+		)
+	}
+	return propagation.New(opts...)
+}
+
+func TestExtractOnlyInjectOnly(t *testing.T) {
+	props := propagation.New(
+		propagation.WithExtractors(propagation.NewExtractOnly(newTestPropagators("a", "b")).HTTPExtractors()...),
+		propagation.WithInjectors(propagation.NewInjectOnly(newTestPropagators("c")).HTTPInjectors()...),
+	)
+
+	in := http.Header{}
+	in.Set("a", "1")
+	in.Set("b", "2")
+	ctx := propagation.ExtractHTTP(context.Background(), props, in)
+	assert.Equal(t, "1", ctx.Value(ctxKey("a")))
+	assert.Equal(t, "2", ctx.Value(ctxKey("b")))
+	assert.Nil(t, ctx.Value(ctxKey("c")))
+
+	out := http.Header{}
+	propagation.InjectHTTP(ctx, props, out)
+	assert.Equal(t, http.Header{"C": []string{"injected"}}, out)
+
+	assert.Empty(t, propagation.NewExtractOnly(newTestPropagators("a")).HTTPInjectors())
+	assert.Empty(t, propagation.NewInjectOnly(newTestPropagators("a")).HTTPExtractors())
+}
+
+func TestCombinedValue(t *testing.T) {
+	h := http.Header{}
+	assert.Equal(t, "", propagation.CombinedValue(h, "tracestate"))
+	h.Add("tracestate", "a=1")
+	assert.Equal(t, "a=1", propagation.CombinedValue(h, "tracestate"))
+	h.Add("tracestate", " ")
+	h.Add("tracestate", "b=2,c=3")
+	assert.Equal(t, "a=1,b=2,c=3", propagation.CombinedValue(h, "tracestate"))
+
+	// Suppliers holding a single value per key are read with Get.
+	assert.Equal(t, "x", propagation.CombinedValue(singleSupplier{"k": "x"}, "k"))
+}
+
+type singleSupplier map[string]string
+
+func (s singleSupplier) Get(key string) string        { return s[key] }
+func (s singleSupplier) Set(key string, value string) { s[key] = value }
+
+func TestMetadataSupplier(t *testing.T) {
+	md := map[string][]string{"baggage": {"a=1", "b=2"}}
+	s := propagation.MetadataSupplier(md)
+
+	assert.Equal(t, "a=1", s.Get("Baggage"))
+	assert.Equal(t, []string{"a=1", "b=2"}, s.Values("BAGGAGE"))
+	assert.Equal(t, "a=1,b=2", propagation.CombinedValue(s, "baggage"))
+
+	s.Set("Traceparent", "value")
+	assert.Equal(t, []string{"value"}, md["traceparent"])
+	assert.Equal(t, "", s.Get("missing"))
+}
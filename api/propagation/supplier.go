@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"strings"
+)
+
+// ValuesSupplier is implemented by HTTPSuppliers whose carrier may hold
+// several values for a key, e.g., http.Header and MetadataSupplier.
+type ValuesSupplier interface {
+	// Values returns all values for a given key.
+	Values(key string) []string
+}
+
+var _ ValuesSupplier = MetadataSupplier{}
+
+// CombinedValue returns the values for key in supplier combined into a
+// single comma-separated list, as RFC 7230 allows for repeated header
+// fields whose value is a list, such as tracestate and baggage.  If
+// supplier does not implement ValuesSupplier, the single value
+// returned by its Get method is returned.
+func CombinedValue(supplier HTTPSupplier, key string) string {
+	vs, ok := supplier.(ValuesSupplier)
+	if !ok {
+		return supplier.Get(key)
+	}
+	values := vs.Values(key)
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return values[0]
+	}
+	nonEmpty := values[:0:0]
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+	return strings.Join(nonEmpty, ",")
+}
+
+// MetadataSupplier is an HTTPSupplier for gRPC metadata.  Keys are
+// normalized to lowercase, as gRPC requires.  gRPC metadata, whose
+// type is also a map of strings to string slices, is converted without
+// copying:
+//
+//	propagation.ExtractHTTP(ctx, props, propagation.MetadataSupplier(md))
+type MetadataSupplier map[string][]string
+
+// Get returns the first value for key, or an empty string if there is
+// none.
+func (s MetadataSupplier) Get(key string) string {
+	values := s[strings.ToLower(key)]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set replaces the values for key with value.
+func (s MetadataSupplier) Set(key string, value string) {
+	s[strings.ToLower(key)] = []string{value}
+}
+
+// Values returns all values for key.
+func (s MetadataSupplier) Values(key string) []string {
+	return s[strings.ToLower(key)]
+}
@@ -20,6 +20,7 @@ import (
 
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
 )
 
 type Provider interface {
@@ -73,6 +74,26 @@ type Tracer interface {
 	Start(ctx context.Context, spanName string, opts ...SpanOption) (context.Context, Span)
 }
 
+// EnabledTracer is implemented by the Tracers that can report whether a
+// span would be recording before it is started.  Instrumentation can
+// detect it with a type assertion to avoid the cost of computing span
+// attributes when they would be dropped:
+//
+//	if et, ok := tracer.(trace.EnabledTracer); ok && !et.Enabled(ctx) {
+//		return
+//	}
+type EnabledTracer interface {
+	Tracer
+
+	// Enabled reports whether a span started with ctx and opts could be
+	// recording.
+	//
+	// A false return means a span started with the same arguments will
+	// not be recording. A true return does not guarantee the opposite,
+	// as the span name is not known to the sampler yet.
+	Enabled(ctx context.Context, opts ...SpanOption) bool
+}
+
 // ErrorConfig provides options to set properties of an error
 // event at the time it is recorded.
 //
@@ -195,6 +216,15 @@ func WithAttributes(attributes ...label.KeyValue) SpanOption {
 	return attributeSpanOption(attributes)
 }
 
+// WithCaller adds attributes of the code namespace, e.g.,
+// "code.function" and "code.lineno", describing the function calling
+// WithCaller, usually the function starting a span, so that backends
+// can link the span to its source.  The attributes of a call site are
+// computed once and cached.
+func WithCaller() SpanOption {
+	return attributeSpanOption(semconv.CodeAttributesFromCaller(0))
+}
+
 type timestampSpanOption time.Time
 
 func (o timestampSpanOption) Apply(c *SpanConfig) { c.Timestamp = time.Time(o) }
@@ -232,7 +262,9 @@ func (o newRootSpanOption) Apply(c *SpanConfig) { c.NewRoot = bool(o) }
 
 // WithNewRoot specifies that the Span should be treated as a root Span. Any
 // existing parent span context will be ignored when defining the Span's trace
-// identifiers.
+// identifiers.  The SDK links the Span to the ignored local and remote span
+// contexts, identified by an "ignored-on-demand" attribute with a value of
+// "current" or "remote".
 func WithNewRoot() SpanOption {
 	return newRootSpanOption(true)
 }
@@ -23,6 +23,7 @@ type traceContextKeyType int
 const (
 	currentSpanKey traceContextKeyType = iota
 	remoteContextKey
+	spanKindKey
 )
 
 // ContextWithSpan creates a new context with a current span set to
@@ -53,3 +54,21 @@ func RemoteSpanContextFromContext(ctx context.Context) SpanContext {
 	}
 	return EmptySpanContext()
 }
+
+// ContextWithSpanKind creates a new context that marks the operation it
+// is passed to as having the passed span kind.  Instrumentation handling
+// an inbound request, for example, can mark the request context with
+// SpanKindServer.  An SDK may use this kind for a span started from the
+// context without an explicit SpanKind if the span has no local parent.
+func ContextWithSpanKind(ctx context.Context, kind SpanKind) context.Context {
+	return context.WithValue(ctx, spanKindKey, kind)
+}
+
+// SpanKindFromContext returns the span kind stored in the context, or
+// SpanKindUnspecified if there is none.
+func SpanKindFromContext(ctx context.Context) SpanKind {
+	if kind, ok := ctx.Value(spanKindKey).(SpanKind); ok {
+		return kind
+	}
+	return SpanKindUnspecified
+}
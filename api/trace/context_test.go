@@ -65,6 +65,17 @@ func TestCurrentSpan(t *testing.T) {
 	}
 }
 
+func TestSpanKindFromContext(t *testing.T) {
+	if have := trace.SpanKindFromContext(context.Background()); have != trace.SpanKindUnspecified {
+		t.Errorf("Want: %v, but have: %v", trace.SpanKindUnspecified, have)
+	}
+
+	ctx := trace.ContextWithSpanKind(context.Background(), trace.SpanKindConsumer)
+	if have := trace.SpanKindFromContext(ctx); have != trace.SpanKindConsumer {
+		t.Errorf("Want: %v, but have: %v", trace.SpanKindConsumer, have)
+	}
+}
+
 // a duplicate of trace.NoopSpan for testing
 type mockSpan struct{}
 
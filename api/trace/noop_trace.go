@@ -20,10 +20,15 @@ import (
 
 type noopTracer struct{}
 
-var _ Tracer = noopTracer{}
+var _ EnabledTracer = noopTracer{}
 
 // Start starts a noop span.
 func (noopTracer) Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, Span) {
 	span := noopSpan{}
 	return ContextWithSpan(ctx, span), span
 }
+
+// Enabled returns false, noop spans are never recording.
+func (noopTracer) Enabled(context.Context, ...SpanOption) bool {
+	return false
+}
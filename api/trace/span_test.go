@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
 )
 
 func TestNewSpanConfig(t *testing.T) {
@@ -193,3 +194,16 @@ func TestNewSpanConfig(t *testing.T) {
 		assert.Equal(t, test.expected, NewSpanConfig(test.options...))
 	}
 }
+
+func TestWithCaller(t *testing.T) {
+	c := NewSpanConfig(WithAttributes(label.String("key", "value")), WithCaller())
+
+	attrs := label.NewSet(c.Attributes...)
+	function, ok := attrs.Value(semconv.CodeFunctionKey)
+	assert.True(t, ok)
+	assert.Equal(t, "TestWithCaller", function.AsString())
+	namespace, ok := attrs.Value(semconv.CodeNamespaceKey)
+	assert.True(t, ok)
+	assert.Equal(t, "go.opentelemetry.io/otel/api/trace", namespace.AsString())
+	assert.True(t, attrs.HasValue("key"))
+}
@@ -42,7 +42,7 @@ type MockTracer struct {
 	OnSpanStarted func(span *MockSpan)
 }
 
-var _ apitrace.Tracer = (*MockTracer)(nil)
+var _ apitrace.EnabledTracer = (*MockTracer)(nil)
 
 // Start starts a MockSpan. It creates a new Span based on Parent SpanContext option.
 // TraceID is used from Parent Span Context and SpanID is assigned.
@@ -78,3 +78,8 @@ func (mt *MockTracer) Start(ctx context.Context, name string, o ...apitrace.Span
 
 	return apitrace.ContextWithSpan(ctx, span), span
 }
+
+// Enabled returns false, MockSpans are never recording.
+func (mt *MockTracer) Enabled(context.Context, ...apitrace.SpanOption) bool {
+	return false
+}
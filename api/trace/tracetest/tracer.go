@@ -23,7 +23,7 @@ import (
 	"go.opentelemetry.io/otel/label"
 )
 
-var _ trace.Tracer = (*Tracer)(nil)
+var _ trace.EnabledTracer = (*Tracer)(nil)
 
 // Tracer is an OpenTelemetry Tracer implementation used for testing.
 type Tracer struct {
@@ -83,3 +83,8 @@ func (t *Tracer) Start(ctx context.Context, name string, opts ...trace.SpanOptio
 	}
 	return trace.ContextWithSpan(ctx, span), span
 }
+
+// Enabled returns true, all spans started by t are recording.
+func (t *Tracer) Enabled(context.Context, ...trace.SpanOption) bool {
+	return true
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expvar implements a bridge that reports variables published
+// with the standard library expvar package as OpenTelemetry metrics.
+//
+// Register creates an asynchronous instrument for each numeric variable
+// published at the time of the call.  An *expvar.Int is reported by an
+// Int64ValueObserver, an *expvar.Float by a Float64ValueObserver, and
+// an *expvar.Map by a Float64ValueObserver with one observation per
+// numeric entry, labeled with the entry's key.  Variables named with
+// WithCounters are reported by sum observers instead.  Variables of
+// other types, like the "cmdline" and "memstats" functions published by
+// the expvar package itself, are ignored.
+package expvar // import "go.opentelemetry.io/otel/bridge/expvar"
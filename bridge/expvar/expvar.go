@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar
+
+import (
+	"context"
+	goexpvar "expvar"
+	"sync"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/label"
+)
+
+// DefaultPrefix is prepended to the name of each expvar variable to
+// form the name of its instrument.
+const DefaultPrefix = "expvar."
+
+// MapKey is the label key of the entry of an *expvar.Map an
+// observation is made for.
+const MapKey = label.Key("key")
+
+type config struct {
+	prefix   string
+	counters map[string]bool
+}
+
+// Option configures Register.
+type Option func(*config)
+
+// WithPrefix sets the prefix prepended to the name of each variable to
+// form the name of its instrument.  The default is DefaultPrefix.
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// WithCounters reports the named variables as monotonic sums rather
+// than as gauges.  Their values must never decrease.
+func WithCounters(names ...string) Option {
+	return func(c *config) {
+		for _, name := range names {
+			c.counters[name] = true
+		}
+	}
+}
+
+type observeFunc func(metric.BatchObserverResult)
+
+// Register creates instruments on meter for the numeric expvar
+// variables published at the time of the call.  Their current values
+// are observed on each collection.
+//
+// If an instrument cannot be created, Register returns the error and
+// no variable is observed.  The instruments created before the error
+// remain registered with meter, as instruments cannot be unregistered.
+func Register(meter metric.Meter, opts ...Option) error {
+	c := config{
+		prefix:   DefaultPrefix,
+		counters: map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	// observers is set only once every instrument was created, so
+	// the batch observer observes nothing if Register fails.
+	var (
+		lock      sync.Mutex
+		observers []observeFunc
+	)
+	batch := meter.NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		for _, observe := range observers {
+			observe(result)
+		}
+	})
+
+	var (
+		pending []observeFunc
+		err     error
+	)
+	goexpvar.Do(func(kv goexpvar.KeyValue) {
+		if err != nil {
+			return
+		}
+		var observe observeFunc
+		if observe, err = newObserver(batch, c.prefix+kv.Key, c.counters[kv.Key], kv.Value); err != nil {
+			return
+		}
+		if observe != nil {
+			pending = append(pending, observe)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	observers = pending
+	lock.Unlock()
+	return nil
+}
+
+// newObserver returns a function observing v using a new instrument
+// named name, or nil if v is not numeric.  It returns a nil function
+// if the instrument cannot be created.
+func newObserver(batch metric.BatchObserver, name string, counter bool, v goexpvar.Var) (observeFunc, error) {
+	switch v := v.(type) {
+	case *goexpvar.Int:
+		if counter {
+			inst, err := batch.NewInt64SumObserver(name)
+			if err != nil {
+				return nil, err
+			}
+			return func(r metric.BatchObserverResult) {
+				r.Observe(nil, inst.Observation(v.Value()))
+			}, nil
+		}
+		inst, err := batch.NewInt64ValueObserver(name)
+		if err != nil {
+			return nil, err
+		}
+		return func(r metric.BatchObserverResult) {
+			r.Observe(nil, inst.Observation(v.Value()))
+		}, nil
+	case *goexpvar.Float:
+		if counter {
+			inst, err := batch.NewFloat64SumObserver(name)
+			if err != nil {
+				return nil, err
+			}
+			return func(r metric.BatchObserverResult) {
+				r.Observe(nil, inst.Observation(v.Value()))
+			}, nil
+		}
+		inst, err := batch.NewFloat64ValueObserver(name)
+		if err != nil {
+			return nil, err
+		}
+		return func(r metric.BatchObserverResult) {
+			r.Observe(nil, inst.Observation(v.Value()))
+		}, nil
+	case *goexpvar.Map:
+		var observation func(float64) metric.Observation
+		if counter {
+			inst, err := batch.NewFloat64SumObserver(name)
+			if err != nil {
+				return nil, err
+			}
+			observation = inst.Observation
+		} else {
+			inst, err := batch.NewFloat64ValueObserver(name)
+			if err != nil {
+				return nil, err
+			}
+			observation = inst.Observation
+		}
+		return func(r metric.BatchObserverResult) {
+			v.Do(func(kv goexpvar.KeyValue) {
+				if f, ok := numericValue(kv.Value); ok {
+					r.Observe([]label.KeyValue{MapKey.String(kv.Key)}, observation(f))
+				}
+			})
+		}, nil
+	}
+	return nil, nil
+}
+
+// numericValue returns the value of an *expvar.Int or *expvar.Float.
+func numericValue(v goexpvar.Var) (float64, bool) {
+	switch v := v.(type) {
+	case *goexpvar.Int:
+		return float64(v.Value()), true
+	case *goexpvar.Float:
+		return v.Value(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar_test
+
+import (
+	"errors"
+	goexpvar "expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/metric/metrictest"
+	"go.opentelemetry.io/otel/api/metric/registry"
+	"go.opentelemetry.io/otel/bridge/expvar"
+	"go.opentelemetry.io/otel/label"
+)
+
+func TestRegister(t *testing.T) {
+	requests := goexpvar.NewInt("test.requests")
+	requests.Add(3)
+	load := goexpvar.NewFloat("test.load")
+	load.Set(0.5)
+	errs := goexpvar.NewMap("test.errors")
+	errs.Add("timeout", 2)
+	errs.AddFloat("ratio", 0.25)
+	errs.Set("name", new(goexpvar.String))
+	goexpvar.NewString("test.version").Set("1.0")
+
+	mock, meter := metrictest.NewMeter()
+	require.NoError(t, expvar.Register(meter, expvar.WithPrefix("x."), expvar.WithCounters("test.requests")))
+
+	mock.RunAsyncInstruments()
+
+	type obs struct {
+		kind   metric.Kind
+		value  float64
+		labels string
+	}
+	got := map[string][]obs{}
+	for _, batch := range mock.MeasurementBatches {
+		set := label.NewSet(batch.Labels...)
+		for _, m := range batch.Measurements {
+			desc := m.Instrument.Descriptor()
+			got[desc.Name()] = append(got[desc.Name()], obs{
+				kind:   desc.MetricKind(),
+				value:  m.Number.CoerceToFloat64(desc.NumberKind()),
+				labels: set.Encoded(label.DefaultEncoder()),
+			})
+		}
+	}
+
+	assert.Equal(t, []obs{{metric.SumObserverKind, 3, ""}}, got["x.test.requests"])
+	assert.Equal(t, []obs{{metric.ValueObserverKind, 0.5, ""}}, got["x.test.load"])
+	assert.ElementsMatch(t, []obs{
+		{metric.ValueObserverKind, 0.25, "key=ratio"},
+		{metric.ValueObserverKind, 2, "key=timeout"},
+	}, got["x.test.errors"])
+	assert.NotContains(t, got, "x.test.version")
+	assert.NotContains(t, got, "x.cmdline")
+}
+
+func TestRegisterError(t *testing.T) {
+	goexpvar.NewInt("test.a").Add(1)
+	goexpvar.NewInt("test.failures").Add(1)
+
+	mock, meter := metrictest.NewMeter()
+	require.NoError(t, expvar.Register(meter))
+
+	// Registering the same variable as a counter conflicts with the
+	// instrument created above.
+	err := expvar.Register(meter, expvar.WithCounters("test.failures"))
+	require.True(t, errors.Is(err, registry.ErrMetricKindMismatch))
+
+	mock.RunAsyncInstruments()
+
+	// Only the variables of the first Register are observed,
+	// including those registered again before the error.
+	kinds := map[string][]metric.Kind{}
+	for _, batch := range mock.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			desc := m.Instrument.Descriptor()
+			kinds[desc.Name()] = append(kinds[desc.Name()], desc.MetricKind())
+		}
+	}
+	assert.Equal(t, []metric.Kind{metric.ValueObserverKind}, kinds["expvar.test.a"])
+	assert.Equal(t, []metric.Kind{metric.ValueObserverKind}, kinds["expvar.test.failures"])
+}
@@ -40,6 +40,17 @@ import (
 type bridgeSpanContext struct {
 	baggageItems    otelbaggage.Map
 	otelSpanContext oteltrace.SpanContext
+
+	// remote is true if the span context was extracted from a
+	// carrier rather than created for a local span.
+	remote bool
+	// propagated is the context returned by the propagators when
+	// the span context, or the span context of the closest
+	// extracted ancestor, was extracted.  It holds propagator state
+	// that is not part of the span context, such as the W3C
+	// tracestate, so that it is injected again.  It is nil if no
+	// ancestor was extracted.
+	propagated context.Context
 }
 
 var _ ot.SpanContext = &bridgeSpanContext{}
@@ -54,6 +65,9 @@ func newBridgeSpanContext(otelSpanContext oteltrace.SpanContext, parentOtSpanCon
 			bCtx.setBaggageItem(key, value)
 			return true
 		})
+		if parentBridgeSC, ok := parentOtSpanContext.(*bridgeSpanContext); ok {
+			bCtx.propagated = parentBridgeSC.propagated
+		}
 	}
 	return bCtx
 }
@@ -388,7 +402,17 @@ func (t *BridgeTracer) StartSpan(operationName string, opts ...ot.StartSpanOptio
 	attributes, kind, hadTrueErrorTag := otTagsToOTelAttributesKindAndError(sso.Tags)
 	checkCtx := migration.WithDeferredSetup(context.Background())
 	if parentBridgeSC != nil {
-		checkCtx = oteltrace.ContextWithRemoteSpanContext(checkCtx, parentBridgeSC.otelSpanContext)
+		// Only extracted parents are remote, so that
+		// parent-based sampling treats local OpenTracing
+		// parents as local.
+		if parentBridgeSC.remote {
+			checkCtx = oteltrace.ContextWithRemoteSpanContext(checkCtx, parentBridgeSC.otelSpanContext)
+		} else {
+			checkCtx = oteltrace.ContextWithSpan(checkCtx, fakeSpan{
+				Span: noop.Span,
+				sc:   parentBridgeSC.otelSpanContext,
+			})
+		}
 	}
 	checkCtx2, otelSpan := t.setTracer.tracer().Start(
 		checkCtx,
@@ -612,7 +636,11 @@ func (t *BridgeTracer) Inject(sm ot.SpanContext, format interface{}, carrier int
 		Span: noop.Span,
 		sc:   bridgeSC.otelSpanContext,
 	}
-	ctx := oteltrace.ContextWithSpan(context.Background(), fs)
+	ctx := bridgeSC.propagated
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = oteltrace.ContextWithSpan(ctx, fs)
 	ctx = otelbaggage.ContextWithMap(ctx, bridgeSC.baggageItems)
 	otelpropagation.InjectHTTP(ctx, t.getPropagators(), header)
 	return nil
@@ -637,6 +665,8 @@ func (t *BridgeTracer) Extract(format interface{}, carrier interface{}) (ot.Span
 	bridgeSC := &bridgeSpanContext{
 		baggageItems:    baggage,
 		otelSpanContext: otelSC,
+		remote:          true,
+		propagated:      ctx,
 	}
 	if !bridgeSC.otelSpanContext.IsValid() {
 		return nil, ot.ErrSpanContextNotFound
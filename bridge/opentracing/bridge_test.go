@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing
+
+import (
+	"net/http"
+	"testing"
+
+	ot "github.com/opentracing/opentracing-go"
+
+	otelpropagation "go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/propagators"
+
+	"go.opentelemetry.io/otel/bridge/opentracing/internal"
+)
+
+func TestBridgeRemoteParentAndTraceState(t *testing.T) {
+	mockOtelTracer := internal.NewMockTracer()
+	bridgeTracer, _ := NewTracerPair(mockOtelTracer)
+	bridgeTracer.SetPropagators(otelpropagation.New(
+		otelpropagation.WithExtractors(propagators.TraceContext{}),
+		otelpropagation.WithInjectors(propagators.TraceContext{}),
+	))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	in := http.Header{}
+	in.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	in.Set("tracestate", "vendor=opaque")
+	remoteSC, err := bridgeTracer.Extract(ot.HTTPHeaders, ot.HTTPHeadersCarrier(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parent := bridgeTracer.StartSpan("parent", ot.ChildOf(remoteSC))
+	child := bridgeTracer.StartSpan("child", ot.ChildOf(parent.Context()))
+
+	out := http.Header{}
+	if err := bridgeTracer.Inject(child.Context(), ot.HTTPHeaders, ot.HTTPHeadersCarrier(out)); err != nil {
+		t.Fatal(err)
+	}
+	child.Finish()
+	parent.Finish()
+
+	spans := mockOtelTracer.FinishedSpans
+	if len(spans) != 2 {
+		t.Fatalf("got %d finished spans, want 2", len(spans))
+	}
+	if spans[0].HasRemoteParent {
+		t.Error("child of a local OpenTracing span has a remote parent")
+	}
+	if !spans[1].HasRemoteParent {
+		t.Error("child of an extracted span context does not have a remote parent")
+	}
+
+	if got := out.Get("tracestate"); got != "vendor=opaque" {
+		t.Errorf("injected tracestate %q, want %q", got, "vendor=opaque")
+	}
+	if got, want := out.Get("traceparent")[3:35], traceID; got != want {
+		t.Errorf("injected trace ID %q, want %q", got, want)
+	}
+}
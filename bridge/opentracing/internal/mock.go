@@ -55,7 +55,7 @@ type MockTracer struct {
 	rand     *rand.Rand
 }
 
-var _ oteltrace.Tracer = &MockTracer{}
+var _ oteltrace.EnabledTracer = &MockTracer{}
 var _ migration.DeferredContextSetupTracerExtension = &MockTracer{}
 
 func NewMockTracer() *MockTracer {
@@ -95,6 +95,7 @@ func (t *MockTracer) Start(ctx context.Context, name string, opts ...oteltrace.S
 		Events:       nil,
 		SpanKind:     oteltrace.ValidateSpanKind(config.SpanKind),
 	}
+	_, span.HasRemoteParent, _ = otelparent.GetSpanContextAndLinks(ctx, config.NewRoot)
 	if !migration.SkipContextSetup(ctx) {
 		ctx = oteltrace.ContextWithSpan(ctx, span)
 		ctx = t.addSpareContextValue(ctx)
@@ -102,6 +103,10 @@ func (t *MockTracer) Start(ctx context.Context, name string, opts ...oteltrace.S
 	return ctx, span
 }
 
+func (t *MockTracer) Enabled(ctx context.Context, opts ...oteltrace.SpanOption) bool {
+	return oteltrace.NewSpanConfig(opts...).Record
+}
+
 func (t *MockTracer) addSpareContextValue(ctx context.Context) context.Context {
 	if len(t.SpareContextKeyValues) > 0 {
 		pair := t.SpareContextKeyValues[0]
@@ -192,11 +197,12 @@ type MockSpan struct {
 	SpanKind       oteltrace.SpanKind
 	recording      bool
 
-	Attributes   otelbaggage.Map
-	StartTime    time.Time
-	EndTime      time.Time
-	ParentSpanID oteltrace.SpanID
-	Events       []MockEvent
+	Attributes      otelbaggage.Map
+	StartTime       time.Time
+	EndTime         time.Time
+	ParentSpanID    oteltrace.SpanID
+	HasRemoteParent bool
+	Events          []MockEvent
 }
 
 var _ oteltrace.Span = &MockSpan{}
@@ -54,7 +54,7 @@ type WrapperTracer struct {
 	tracer oteltrace.Tracer
 }
 
-var _ oteltrace.Tracer = &WrapperTracer{}
+var _ oteltrace.EnabledTracer = &WrapperTracer{}
 var _ migration.DeferredContextSetupTracerExtension = &WrapperTracer{}
 
 // NewWrapperTracer wraps the passed tracer and also talks to the
@@ -85,6 +85,15 @@ func (t *WrapperTracer) Start(ctx context.Context, name string, opts ...oteltrac
 	return ctx, span
 }
 
+// Enabled forwards the call to the wrapped tracer if it implements
+// the EnabledTracer interface, otherwise it returns true.
+func (t *WrapperTracer) Enabled(ctx context.Context, opts ...oteltrace.SpanOption) bool {
+	if et, ok := t.otelTracer().(oteltrace.EnabledTracer); ok {
+		return et.Enabled(ctx, opts...)
+	}
+	return true
+}
+
 // DeferredContextSetupHook is a part of the implementation of the
 // DeferredContextSetupTracerExtension interface. It will try to
 // forward the call to the wrapped tracer if it implements the
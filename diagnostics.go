@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"log"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/diag"
+)
+
+// Severity is the severity of a diagnostic reported to the global
+// ErrorHandler.
+type Severity = diag.Severity
+
+const (
+	// SeverityDebug diagnostics help to troubleshoot the
+	// configuration of OpenTelemetry.
+	SeverityDebug = diag.Debug
+	// SeverityWarn diagnostics report a likely misconfiguration that
+	// does not lose data.
+	SeverityWarn = diag.Warn
+	// SeverityError diagnostics report lost data or a component that
+	// does not work as configured.  Errors without an explicit
+	// severity are of this severity.
+	SeverityError = diag.Error
+)
+
+// WithSeverity returns err annotated with severity s, for reporting
+// with global.Handle.  It returns nil if err is nil.
+func WithSeverity(err error, s Severity) error {
+	return diag.WithSeverity(err, s)
+}
+
+// SeverityOf returns the Severity err was annotated with using
+// WithSeverity, or SeverityError if it was not.
+func SeverityOf(err error) Severity {
+	return diag.SeverityOf(err)
+}
+
+// DiagnosticsOption configures the diagnostics of OpenTelemetry.
+type DiagnosticsOption func(*diag.Config)
+
+// ConfigureDiagnostics configures how the errors reported by
+// OpenTelemetry components with global.Handle are handled before they
+// reach the global ErrorHandler, which is set with
+// global.SetErrorHandler.  Each call replaces the configuration of the
+// previous one; options that are not passed take their default value.
+//
+// By default, all diagnostics are handled, without rate limiting, and
+// logged to os.Stderr if no ErrorHandler is set.
+func ConfigureDiagnostics(opts ...DiagnosticsOption) {
+	var c diag.Config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	diag.Configure(c)
+}
+
+// WithMinSeverity drops diagnostics less severe than s.
+func WithMinSeverity(s Severity) DiagnosticsOption {
+	return func(c *diag.Config) {
+		c.MinSeverity = s
+	}
+}
+
+// WithRateLimit handles at most burst distinct diagnostics per period,
+// see NewRateLimitedHandler.
+func WithRateLimit(per time.Duration, burst int) DiagnosticsOption {
+	return func(c *diag.Config) {
+		c.RateLimitPeriod = per
+		c.RateLimitBurst = burst
+	}
+}
+
+// WithLogger logs diagnostics with l when no ErrorHandler is set.
+func WithLogger(l *log.Logger) DiagnosticsOption {
+	return func(c *diag.Config) {
+		c.Logger = l
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/api/global"
+)
+
+func TestConfigureDiagnostics(t *testing.T) {
+	defer otel.ConfigureDiagnostics()
+
+	var buf bytes.Buffer
+	otel.ConfigureDiagnostics(
+		otel.WithLogger(log.New(&buf, "", 0)),
+		otel.WithMinSeverity(otel.SeverityWarn),
+		otel.WithRateLimit(time.Hour, 2),
+	)
+
+	global.Handle(otel.WithSeverity(errors.New("debug"), otel.SeverityDebug))
+	global.Handle(otel.WithSeverity(errors.New("warn"), otel.SeverityWarn))
+	global.Handle(errors.New("error"))
+	global.Handle(errors.New("rate limited"))
+
+	assert.Equal(t, "warn\nerror\n", buf.String())
+	assert.Equal(t, otel.SeverityWarn, otel.SeverityOf(otel.WithSeverity(errors.New("warn"), otel.SeverityWarn)))
+	assert.Equal(t, otel.SeverityError, otel.SeverityOf(errors.New("error")))
+}
@@ -206,12 +206,10 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	c.exp.lock.RLock()
 	defer c.exp.lock.RUnlock()
 
+	// Concurrent scrapes are serialized so that each observes the
+	// records of its own collection.
 	ctrl := c.exp.Controller()
-	if err := ctrl.Collect(context.Background()); err != nil {
-		global.Handle(err)
-	}
-
-	err := ctrl.ForEach(c.exp, func(record export.Record) error {
+	err := ctrl.CollectAndForEach(context.Background(), c.exp, func(record export.Record) error {
 		agg := record.Aggregation()
 		numberKind := record.Descriptor().NumberKind()
 
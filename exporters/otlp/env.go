@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/metric"
+	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// Environment variable names
+const (
+	// The preferred temporality of exported metrics, one of
+	// "cumulative", "delta" or "lowmemory" (case insensitive).
+	envMetricsTemporalityPreference = "OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE"
+	// The default aggregation of ValueRecorder instruments.
+	envMetricsDefaultHistogramAggregation = "OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION"
+)
+
+// temporalitySelector is an ExportKindSelector that selects the
+// ExportKind of each instrument based on its metric.Kind.
+type temporalitySelector func(metric.Kind) metricsdk.ExportKind
+
+var _ metricsdk.ExportKindSelector = temporalitySelector(nil)
+
+// ExportKindFor implements metricsdk.ExportKindSelector.
+func (s temporalitySelector) ExportKindFor(desc *metric.Descriptor, _ aggregation.Kind) metricsdk.ExportKind {
+	return s(desc.MetricKind())
+}
+
+// deltaPreference exports the instruments whose sums are monotonic,
+// and the ValueRecorder, using deltas.  UpDownCounters and
+// UpDownSumObservers remain cumulative.
+func deltaPreference(kind metric.Kind) metricsdk.ExportKind {
+	switch kind {
+	case metric.CounterKind, metric.ValueRecorderKind, metric.SumObserverKind:
+		return metricsdk.DeltaExporter
+	}
+	return metricsdk.CumulativeExporter
+}
+
+// lowMemoryPreference exports synchronous Counters and ValueRecorders
+// using deltas, and all other instruments cumulatively.  Neither
+// requires the Processor to remember state between collections.
+func lowMemoryPreference(kind metric.Kind) metricsdk.ExportKind {
+	switch kind {
+	case metric.CounterKind, metric.ValueRecorderKind:
+		return metricsdk.DeltaExporter
+	}
+	return metricsdk.CumulativeExporter
+}
+
+// exportKindSelectorFromEnv returns the ExportKindSelector configured
+// by the OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE environment
+// variable, or metricsdk.PassThroughExporter if it is not set.  An
+// invalid value is reported to the global error handler and ignored.
+func exportKindSelectorFromEnv() metricsdk.ExportKindSelector {
+	e := strings.TrimSpace(os.Getenv(envMetricsTemporalityPreference))
+	switch strings.ToLower(e) {
+	case "":
+		return metricsdk.PassThroughExporter
+	case "cumulative":
+		return metricsdk.CumulativeExporter
+	case "delta":
+		return temporalitySelector(deltaPreference)
+	case "lowmemory":
+		return temporalitySelector(lowMemoryPreference)
+	}
+	global.Handle(fmt.Errorf("invalid %s value %q, using the default temporality", envMetricsTemporalityPreference, e))
+	return metricsdk.PassThroughExporter
+}
+
+// checkHistogramAggregationEnv reports a configured
+// OTEL_EXPORTER_OTLP_METRICS_DEFAULT_HISTOGRAM_AGGREGATION to the global
+// error handler.  The exporter does not encode histograms yet, so the
+// distribution of ValueRecorders is only exported when aggregated as a
+// MinMaxSumCount.
+func checkHistogramAggregationEnv() {
+	e := strings.TrimSpace(os.Getenv(envMetricsDefaultHistogramAggregation))
+	switch strings.ToLower(e) {
+	case "":
+	case "explicit_bucket_histogram", "base2_exponential_bucket_histogram":
+		global.Handle(otel.WithSeverity(fmt.Errorf("%s=%s is not supported: histograms are not exported, use a MinMaxSumCount aggregator", envMetricsDefaultHistogramAggregation, e), otel.SeverityWarn))
+	default:
+		global.Handle(fmt.Errorf("invalid %s value %q", envMetricsDefaultHistogramAggregation, e))
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/metric"
+	ottest "go.opentelemetry.io/otel/internal/testing"
+	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+func TestTemporalityPreferenceFromEnv(t *testing.T) {
+	kinds := []metric.Kind{
+		metric.CounterKind,
+		metric.UpDownCounterKind,
+		metric.ValueRecorderKind,
+		metric.SumObserverKind,
+		metric.UpDownSumObserverKind,
+		metric.ValueObserverKind,
+	}
+	cum, delta, pass := metricsdk.CumulativeExporter, metricsdk.DeltaExporter, metricsdk.PassThroughExporter
+
+	testCases := []struct {
+		name     string
+		env      string
+		expected []metricsdk.ExportKind
+	}{
+		{name: "unset", env: "", expected: []metricsdk.ExportKind{pass, pass, pass, pass, pass, pass}},
+		{name: "cumulative", env: "cumulative", expected: []metricsdk.ExportKind{cum, cum, cum, cum, cum, cum}},
+		{name: "delta", env: "Delta", expected: []metricsdk.ExportKind{delta, cum, delta, delta, cum, cum}},
+		{name: "lowmemory", env: " LowMemory ", expected: []metricsdk.ExportKind{delta, cum, delta, cum, cum, cum}},
+		{name: "invalid", env: "sometimes", expected: []metricsdk.ExportKind{pass, pass, pass, pass, pass, pass}},
+	}
+
+	envStore := ottest.NewEnvStore()
+	envStore.Record(envMetricsTemporalityPreference)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, os.Setenv(envMetricsTemporalityPreference, tc.env))
+
+			exp := NewUnstartedExporter()
+			for i, kind := range kinds {
+				desc := metric.NewDescriptor("instrument", kind, metric.Int64NumberKind)
+				assert.Equal(t, tc.expected[i], exp.ExportKindFor(&desc, aggregation.SumKind), kind.String())
+			}
+		})
+	}
+}
+
+func TestTemporalityPreferenceOptionOverridesEnv(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		envMetricsTemporalityPreference: "delta",
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, envStore.Restore())
+	}()
+
+	desc := metric.NewDescriptor("instrument", metric.CounterKind, metric.Int64NumberKind)
+	exp := NewUnstartedExporter(WithMetricExportKindSelector(metricsdk.CumulativeExporter))
+	assert.Equal(t, metricsdk.CumulativeExporter, exp.ExportKindFor(&desc, aggregation.SumKind))
+}
@@ -34,6 +34,31 @@ func Attributes(attrs []label.KeyValue) []*commonpb.KeyValue {
 	return out
 }
 
+// SetAttributes transforms a label Set into a slice of OTLP attribute
+// key-values, sorted by key.
+func SetAttributes(set *label.Set) []*commonpb.KeyValue {
+	return Attributes(set.ToSlice())
+}
+
+// Labels transforms a slice of OTLP attribute key-values into a slice of
+// KeyValues.  It is the inverse of Attributes, except that integers are
+// returned as INT64 labels and floating point numbers as FLOAT64 labels.
+// Attributes whose value cannot be represented as a label, such as
+// arrays with mixed or nested element types, are dropped.
+func Labels(attrs []*commonpb.KeyValue) []label.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	out := make([]label.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if kv, ok := fromAttribute(attr); ok {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
 // ResourceAttributes transforms a Resource into a slice of OTLP attribute key-values.
 func ResourceAttributes(resource *resource.Resource) []*commonpb.KeyValue {
 	if resource.Len() == 0 {
@@ -84,6 +109,76 @@ func toAttribute(v label.KeyValue) *commonpb.KeyValue {
 	return result
 }
 
+func fromAttribute(attr *commonpb.KeyValue) (label.KeyValue, bool) {
+	key := label.Key(attr.GetKey())
+	switch v := attr.GetValue().GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		return key.Bool(v.BoolValue), true
+	case *commonpb.AnyValue_IntValue:
+		return key.Int64(v.IntValue), true
+	case *commonpb.AnyValue_DoubleValue:
+		return key.Float64(v.DoubleValue), true
+	case *commonpb.AnyValue_StringValue:
+		return key.String(v.StringValue), true
+	case *commonpb.AnyValue_ArrayValue:
+		if array := fromArrayValue(v.ArrayValue.GetValues()); array != nil {
+			return key.Array(array), true
+		}
+	}
+	return label.KeyValue{}, false
+}
+
+// fromArrayValue returns a typed slice holding values, or nil if the
+// values do not share a single scalar type.
+func fromArrayValue(values []*commonpb.AnyValue) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	switch values[0].GetValue().(type) {
+	case *commonpb.AnyValue_BoolValue:
+		out := make([]bool, len(values))
+		for i, v := range values {
+			b, ok := v.GetValue().(*commonpb.AnyValue_BoolValue)
+			if !ok {
+				return nil
+			}
+			out[i] = b.BoolValue
+		}
+		return out
+	case *commonpb.AnyValue_IntValue:
+		out := make([]int64, len(values))
+		for i, v := range values {
+			n, ok := v.GetValue().(*commonpb.AnyValue_IntValue)
+			if !ok {
+				return nil
+			}
+			out[i] = n.IntValue
+		}
+		return out
+	case *commonpb.AnyValue_DoubleValue:
+		out := make([]float64, len(values))
+		for i, v := range values {
+			f, ok := v.GetValue().(*commonpb.AnyValue_DoubleValue)
+			if !ok {
+				return nil
+			}
+			out[i] = f.DoubleValue
+		}
+		return out
+	case *commonpb.AnyValue_StringValue:
+		out := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.GetValue().(*commonpb.AnyValue_StringValue)
+			if !ok {
+				return nil
+			}
+			out[i] = s.StringValue
+		}
+		return out
+	}
+	return nil
+}
+
 func toArrayAttribute(v label.KeyValue) *commonpb.AnyValue_ArrayValue {
 	array := v.Value.AsArray()
 	var resultValues []*commonpb.AnyValue
@@ -134,7 +134,7 @@ func status(status codes.Code, message string) *tracepb.Status {
 }
 
 // links transforms span Links to OTLP span links.
-func links(links []apitrace.Link) []*tracepb.Span_Link {
+func links(links []export.Link) []*tracepb.Span_Link {
 	if len(links) == 0 {
 		return nil
 	}
@@ -146,9 +146,10 @@ func links(links []apitrace.Link) []*tracepb.Span_Link {
 		otLink := otLink
 
 		sl = append(sl, &tracepb.Span_Link{
-			TraceId:    otLink.TraceID[:],
-			SpanId:     otLink.SpanID[:],
-			Attributes: Attributes(otLink.Attributes),
+			TraceId:                otLink.TraceID[:],
+			SpanId:                 otLink.SpanID[:],
+			Attributes:             Attributes(otLink.Attributes),
+			DroppedAttributesCount: uint32(otLink.DroppedAttributeCount),
 		})
 	}
 	return sl
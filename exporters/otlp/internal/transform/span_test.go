@@ -117,16 +117,19 @@ func TestNilLinks(t *testing.T) {
 }
 
 func TestEmptyLinks(t *testing.T) {
-	assert.Nil(t, links([]apitrace.Link{}))
+	assert.Nil(t, links([]export.Link{}))
 }
 
 func TestLinks(t *testing.T) {
 	attrs := []label.KeyValue{label.Int("one", 1), label.Int("two", 2)}
-	l := []apitrace.Link{
+	l := []export.Link{
 		{},
 		{
-			SpanContext: apitrace.EmptySpanContext(),
-			Attributes:  attrs,
+			Link: apitrace.Link{
+				SpanContext: apitrace.EmptySpanContext(),
+				Attributes:  attrs,
+			},
+			DroppedAttributeCount: 3,
 		},
 	}
 	got := links(l)
@@ -145,6 +148,7 @@ func TestLinks(t *testing.T) {
 
 	// Do not test Attributes directly, just that the return value goes to the correct field.
 	expected.Attributes = Attributes(attrs)
+	expected.DroppedAttributesCount = 3
 	assert.Equal(t, expected, got[1])
 
 	// Changes to our links should not change the produced links.
@@ -293,25 +297,29 @@ func TestSpanData(t *testing.T) {
 				},
 			},
 		},
-		Links: []apitrace.Link{
+		Links: []export.Link{
 			{
-				SpanContext: apitrace.SpanContext{
-					TraceID:    apitrace.ID{0xC0, 0xC1, 0xC2, 0xC3, 0xC4, 0xC5, 0xC6, 0xC7, 0xC8, 0xC9, 0xCA, 0xCB, 0xCC, 0xCD, 0xCE, 0xCF},
-					SpanID:     apitrace.SpanID{0xB0, 0xB1, 0xB2, 0xB3, 0xB4, 0xB5, 0xB6, 0xB7},
-					TraceFlags: 0,
-				},
-				Attributes: []label.KeyValue{
-					label.String("LinkType", "Parent"),
+				Link: apitrace.Link{
+					SpanContext: apitrace.SpanContext{
+						TraceID:    apitrace.ID{0xC0, 0xC1, 0xC2, 0xC3, 0xC4, 0xC5, 0xC6, 0xC7, 0xC8, 0xC9, 0xCA, 0xCB, 0xCC, 0xCD, 0xCE, 0xCF},
+						SpanID:     apitrace.SpanID{0xB0, 0xB1, 0xB2, 0xB3, 0xB4, 0xB5, 0xB6, 0xB7},
+						TraceFlags: 0,
+					},
+					Attributes: []label.KeyValue{
+						label.String("LinkType", "Parent"),
+					},
 				},
 			},
 			{
-				SpanContext: apitrace.SpanContext{
-					TraceID:    apitrace.ID{0xE0, 0xE1, 0xE2, 0xE3, 0xE4, 0xE5, 0xE6, 0xE7, 0xE8, 0xE9, 0xEA, 0xEB, 0xEC, 0xED, 0xEE, 0xEF},
-					SpanID:     apitrace.SpanID{0xD0, 0xD1, 0xD2, 0xD3, 0xD4, 0xD5, 0xD6, 0xD7},
-					TraceFlags: 0,
-				},
-				Attributes: []label.KeyValue{
-					label.String("LinkType", "Child"),
+				Link: apitrace.Link{
+					SpanContext: apitrace.SpanContext{
+						TraceID:    apitrace.ID{0xE0, 0xE1, 0xE2, 0xE3, 0xE4, 0xE5, 0xE6, 0xE7, 0xE8, 0xE9, 0xEA, 0xEB, 0xEC, 0xED, 0xEE, 0xEF},
+						SpanID:     apitrace.SpanID{0xD0, 0xD1, 0xD2, 0xD3, 0xD4, 0xD5, 0xD6, 0xD7},
+						TraceFlags: 0,
+					},
+					Attributes: []label.KeyValue{
+						label.String("LinkType", "Child"),
+					},
 				},
 			},
 		},
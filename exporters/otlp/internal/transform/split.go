@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	tracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/trace/v1"
+)
+
+// fieldOverhead is an upper bound of the bytes needed to encode the tag
+// and length of an embedded message field: all fields of the OTLP
+// messages have a single byte tag and a length is encoded in at most 5
+// bytes.
+const fieldOverhead = 6
+
+type sizer interface {
+	Size() int
+}
+
+// fieldSize returns an upper bound of the encoded size of m as a field
+// of its parent message.
+func fieldSize(m sizer) int {
+	return m.Size() + fieldOverhead
+}
+
+// SplitResourceSpans splits rss into batches whose encoded size as an
+// ExportTraceServiceRequest does not exceed limit bytes.
+//
+// ResourceSpans are kept whole when they fit into a batch.  Otherwise
+// they are split along InstrumentationLibrarySpans boundaries, and then
+// between spans, repeating the Resource and InstrumentationLibrary in
+// each batch.  A single span larger than limit is put into a batch of
+// its own.  If limit is not positive, rss is returned as a single
+// batch.
+func SplitResourceSpans(rss []*tracepb.ResourceSpans, limit int) [][]*tracepb.ResourceSpans {
+	if limit <= 0 || len(rss) == 0 {
+		return [][]*tracepb.ResourceSpans{rss}
+	}
+
+	var (
+		batches [][]*tracepb.ResourceSpans
+		batch   []*tracepb.ResourceSpans
+		size    int
+	)
+	flush := func() {
+		if len(batch) > 0 {
+			batches = append(batches, batch)
+		}
+		batch, size = nil, 0
+	}
+
+	for _, rs := range rss {
+		n := fieldSize(rs)
+		if size+n > limit {
+			flush()
+		}
+		if n <= limit {
+			batch = append(batch, rs)
+			size += n
+			continue
+		}
+
+		// rs is too large: split it, starting from an empty batch.
+		var cur *tracepb.ResourceSpans
+		hdr := fieldSize(&tracepb.ResourceSpans{Resource: rs.Resource})
+		next := func() {
+			flush()
+			cur = &tracepb.ResourceSpans{Resource: rs.Resource}
+			batch, size = append(batch, cur), hdr
+		}
+		next()
+
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			n := fieldSize(ils)
+			if size+n > limit && size > hdr {
+				next()
+			}
+			if size+n <= limit {
+				cur.InstrumentationLibrarySpans = append(cur.InstrumentationLibrarySpans, ils)
+				size += n
+				continue
+			}
+
+			var curILS *tracepb.InstrumentationLibrarySpans
+			ilsHdr := fieldSize(&tracepb.InstrumentationLibrarySpans{InstrumentationLibrary: ils.InstrumentationLibrary})
+			for _, s := range ils.Spans {
+				n := fieldSize(s)
+				if curILS == nil {
+					n += ilsHdr
+				}
+				if size+n > limit && size > hdr {
+					next()
+					curILS = nil
+					n = fieldSize(s) + ilsHdr
+				}
+				if curILS == nil {
+					curILS = &tracepb.InstrumentationLibrarySpans{InstrumentationLibrary: ils.InstrumentationLibrary}
+					cur.InstrumentationLibrarySpans = append(cur.InstrumentationLibrarySpans, curILS)
+				}
+				curILS.Spans = append(curILS.Spans, s)
+				size += n
+			}
+		}
+	}
+	flush()
+	return batches
+}
+
+// SplitResourceMetrics splits rms into batches whose encoded size as an
+// ExportMetricsServiceRequest does not exceed limit bytes.
+//
+// ResourceMetrics are kept whole when they fit into a batch.  Otherwise
+// they are split along InstrumentationLibraryMetrics boundaries, and
+// then between metrics, repeating the Resource and
+// InstrumentationLibrary in each batch.  A single metric larger than
+// limit is put into a batch of its own.  If limit is not positive, rms
+// is returned as a single batch.
+func SplitResourceMetrics(rms []*metricpb.ResourceMetrics, limit int) [][]*metricpb.ResourceMetrics {
+	if limit <= 0 || len(rms) == 0 {
+		return [][]*metricpb.ResourceMetrics{rms}
+	}
+
+	var (
+		batches [][]*metricpb.ResourceMetrics
+		batch   []*metricpb.ResourceMetrics
+		size    int
+	)
+	flush := func() {
+		if len(batch) > 0 {
+			batches = append(batches, batch)
+		}
+		batch, size = nil, 0
+	}
+
+	for _, rm := range rms {
+		n := fieldSize(rm)
+		if size+n > limit {
+			flush()
+		}
+		if n <= limit {
+			batch = append(batch, rm)
+			size += n
+			continue
+		}
+
+		// rm is too large: split it, starting from an empty batch.
+		var cur *metricpb.ResourceMetrics
+		hdr := fieldSize(&metricpb.ResourceMetrics{Resource: rm.Resource})
+		next := func() {
+			flush()
+			cur = &metricpb.ResourceMetrics{Resource: rm.Resource}
+			batch, size = append(batch, cur), hdr
+		}
+		next()
+
+		for _, ilm := range rm.InstrumentationLibraryMetrics {
+			n := fieldSize(ilm)
+			if size+n > limit && size > hdr {
+				next()
+			}
+			if size+n <= limit {
+				cur.InstrumentationLibraryMetrics = append(cur.InstrumentationLibraryMetrics, ilm)
+				size += n
+				continue
+			}
+
+			var curILM *metricpb.InstrumentationLibraryMetrics
+			ilmHdr := fieldSize(&metricpb.InstrumentationLibraryMetrics{InstrumentationLibrary: ilm.InstrumentationLibrary})
+			for _, m := range ilm.Metrics {
+				n := fieldSize(m)
+				if curILM == nil {
+					n += ilmHdr
+				}
+				if size+n > limit && size > hdr {
+					next()
+					curILM = nil
+					n = fieldSize(m) + ilmHdr
+				}
+				if curILM == nil {
+					curILM = &metricpb.InstrumentationLibraryMetrics{InstrumentationLibrary: ilm.InstrumentationLibrary}
+					cur.InstrumentationLibraryMetrics = append(cur.InstrumentationLibraryMetrics, curILM)
+				}
+				curILM.Metrics = append(curILM.Metrics, m)
+				size += n
+			}
+		}
+	}
+	flush()
+	return batches
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colmetricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/trace/v1"
+	commonpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/common/v1"
+	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	resourcepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/resource/v1"
+	tracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/trace/v1"
+)
+
+func testResource(name string) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{{
+			Key:   "service.name",
+			Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: name}},
+		}},
+	}
+}
+
+// testResourceSpans returns ResourceSpans for each of resources with
+// libs libraries of n spans each, with names padded to pad bytes.
+func testResourceSpans(resources []string, libs, n, pad int) []*tracepb.ResourceSpans {
+	var rss []*tracepb.ResourceSpans
+	for _, r := range resources {
+		rs := &tracepb.ResourceSpans{Resource: testResource(r)}
+		for l := 0; l < libs; l++ {
+			ils := &tracepb.InstrumentationLibrarySpans{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: fmt.Sprint("lib", l)},
+			}
+			for i := 0; i < n; i++ {
+				name := fmt.Sprintf("%s/%d/%d", r, l, i)
+				ils.Spans = append(ils.Spans, &tracepb.Span{Name: name + strings.Repeat("x", pad)})
+			}
+			rs.InstrumentationLibrarySpans = append(rs.InstrumentationLibrarySpans, ils)
+		}
+		rss = append(rss, rs)
+	}
+	return rss
+}
+
+// spanNames returns the resource, library and span name of each span
+// in batches.
+func spanNames(batches [][]*tracepb.ResourceSpans) []string {
+	var names []string
+	for _, batch := range batches {
+		for _, rs := range batch {
+			for _, ils := range rs.InstrumentationLibrarySpans {
+				for _, s := range ils.Spans {
+					r := rs.Resource.Attributes[0].Value.GetStringValue()
+					names = append(names, r+" "+ils.InstrumentationLibrary.Name+" "+s.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func TestSplitResourceSpans(t *testing.T) {
+	rss := testResourceSpans([]string{"a", "b", "c"}, 3, 10, 100)
+	want := spanNames([][]*tracepb.ResourceSpans{rss})
+	total := (&coltracepb.ExportTraceServiceRequest{ResourceSpans: rss}).Size()
+
+	for _, limit := range []int{0, 2 * total, total / 2, 2000, 1000, 300, 10} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			batches := SplitResourceSpans(rss, limit)
+			assert.Equal(t, want, spanNames(batches))
+			if limit <= 0 || limit > total {
+				require.Len(t, batches, 1)
+				return
+			}
+			assert.Greater(t, len(batches), 1)
+			for _, batch := range batches {
+				require.NotEmpty(t, batch)
+				size := (&coltracepb.ExportTraceServiceRequest{ResourceSpans: batch}).Size()
+				spans := len(spanNames([][]*tracepb.ResourceSpans{batch}))
+				if spans > 1 {
+					assert.LessOrEqual(t, size, limit)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitResourceSpansKeepsBoundaries(t *testing.T) {
+	rss := testResourceSpans([]string{"a", "b"}, 2, 2, 0)
+	rsSize := fieldSize(rss[0])
+
+	// Each ResourceSpans fits whole into a batch of its own.
+	batches := SplitResourceSpans(rss, rsSize+fieldOverhead)
+	require.Len(t, batches, 2)
+	assert.Equal(t, rss[0], batches[0][0])
+	assert.Equal(t, rss[1], batches[1][0])
+
+	// Each InstrumentationLibrarySpans fits whole into a batch of
+	// its own, with a copy of the resource.
+	batches = SplitResourceSpans(rss, rsSize-1)
+	require.Len(t, batches, 4)
+	for i, batch := range batches {
+		require.Len(t, batch, 1)
+		assert.Equal(t, rss[i/2].Resource, batch[0].Resource)
+		assert.Equal(t, []*tracepb.InstrumentationLibrarySpans{rss[i/2].InstrumentationLibrarySpans[i%2]}, batch[0].InstrumentationLibrarySpans)
+	}
+}
+
+func TestSplitResourceMetrics(t *testing.T) {
+	var rms []*metricpb.ResourceMetrics
+	var want []string
+	for _, r := range []string{"a", "b"} {
+		rm := &metricpb.ResourceMetrics{Resource: testResource(r)}
+		for l := 0; l < 3; l++ {
+			ilm := &metricpb.InstrumentationLibraryMetrics{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{Name: fmt.Sprint("lib", l)},
+			}
+			for i := 0; i < 10; i++ {
+				name := fmt.Sprintf("%s/%d/%d", r, l, i)
+				ilm.Metrics = append(ilm.Metrics, &metricpb.Metric{MetricDescriptor: &metricpb.MetricDescriptor{Name: name, Description: strings.Repeat("x", 100)}})
+				want = append(want, name)
+			}
+			rm.InstrumentationLibraryMetrics = append(rm.InstrumentationLibraryMetrics, ilm)
+		}
+		rms = append(rms, rm)
+	}
+
+	limit := 1000
+	batches := SplitResourceMetrics(rms, limit)
+	assert.Greater(t, len(batches), 1)
+
+	var got []string
+	for _, batch := range batches {
+		size := (&colmetricpb.ExportMetricsServiceRequest{ResourceMetrics: batch}).Size()
+		assert.LessOrEqual(t, size, limit)
+		for _, rm := range batch {
+			for _, ilm := range rm.InstrumentationLibraryMetrics {
+				for _, m := range ilm.Metrics {
+					assert.True(t, strings.HasPrefix(m.MetricDescriptor.Name, rm.Resource.Attributes[0].Value.GetStringValue()+"/"))
+					got = append(got, m.MetricDescriptor.Name)
+				}
+			}
+		}
+	}
+	assert.Equal(t, want, got)
+
+	assert.Equal(t, [][]*metricpb.ResourceMetrics{rms}, SplitResourceMetrics(rms, 0))
+}
@@ -15,10 +15,13 @@
 package otlp
 
 import (
+	"context"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+
+	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
 )
 
 const (
@@ -26,6 +29,11 @@ const (
 	DefaultCollectorHost string = "localhost"
 	DefaultNumWorkers    uint   = 1
 
+	// DefaultMaxRequestSize is the default maximum size in bytes of
+	// export requests, the default maximum size of messages received
+	// by gRPC servers, including the OpenTelemetry Collector.
+	DefaultMaxRequestSize = 4 * 1024 * 1024
+
 	// For more info on gRPC service configs:
 	// https://github.com/grpc/proposal/blob/master/A6-client-retries.md
 	//
@@ -71,8 +79,13 @@ type config struct {
 	grpcServiceConfig  string
 	grpcDialOptions    []grpc.DialOption
 	headers            map[string]string
+	headersProvider    func(ctx context.Context) map[string]string
 	clientCredentials  credentials.TransportCredentials
 	numWorkers         uint
+	exportKindSelector metricsdk.ExportKindSelector
+	lazyConnection     bool
+	startupBufferSize  int
+	maxRequestSize     int
 }
 
 // WorkerCount sets the number of Goroutines to use when processing telemetry.
@@ -129,6 +142,26 @@ func WithHeaders(headers map[string]string) ExporterOption {
 	}
 }
 
+// WithHeadersProvider will send the headers returned by provider with
+// each gRPC request, in addition to those of WithHeaders.  The provider
+// is called with the context of each export, so that short-lived
+// credentials, e.g., OAuth bearer tokens, can be refreshed without
+// recreating the exporter:
+//
+//	otlp.WithHeadersProvider(func(ctx context.Context) map[string]string {
+//		return map[string]string{"authorization": "Bearer " + tokens.Current()}
+//	})
+//
+// Its headers take precedence over those of WithHeaders.  The provider
+// is called concurrently by the export workers and should cache the
+// credentials it returns.  Alternatively, gRPC per-RPC credentials can
+// be set with WithGRPCDialOption and grpc.WithPerRPCCredentials.
+func WithHeadersProvider(provider func(ctx context.Context) map[string]string) ExporterOption {
+	return func(cfg *config) {
+		cfg.headersProvider = provider
+	}
+}
+
 // WithTLSCredentials allows the connection to use TLS credentials
 // when talking to the server. It takes in grpc.TransportCredentials instead
 // of say a Certificate file or a tls.Certificate, because the retrieving
@@ -147,6 +180,33 @@ func WithGRPCServiceConfig(serviceConfig string) ExporterOption {
 	}
 }
 
+// WithLazyConnection makes Start return without attempting to connect
+// to the collector.  The first connection attempt is instead made in
+// the background, so that applications do not block at startup when
+// the collector is unreachable, e.g., when grpc.WithBlock is passed
+// using WithGRPCDialOption.
+func WithLazyConnection() ExporterOption {
+	return func(cfg *config) {
+		cfg.lazyConnection = true
+	}
+}
+
+// WithStartupBuffer sets the number of spans, and separately the number
+// of metric exports per resource, that are buffered until the exporter
+// first succeeds in exporting to the collector.  Buffered data is sent
+// along with the next export once the collector is reachable.  When
+// the buffer is full the oldest data is dropped.  After the first
+// successful export, data that cannot be exported is no longer
+// buffered.
+//
+// By default, no data is buffered and data exported before the
+// collector is reachable is dropped.
+func WithStartupBuffer(size int) ExporterOption {
+	return func(cfg *config) {
+		cfg.startupBufferSize = size
+	}
+}
+
 // WithGRPCDialOption opens support to any grpc.DialOption to be used. If it conflicts
 // with some other configuration the GRPC specified via the collector the ones here will
 // take preference since they are set last.
@@ -155,3 +215,34 @@ func WithGRPCDialOption(opts ...grpc.DialOption) ExporterOption {
 		cfg.grpcDialOptions = opts
 	}
 }
+
+// WithMetricExportKindSelector sets the ExportKindSelector used to
+// determine the ExportKind of each metric instrument.  By default, the
+// exporter uses metricsdk.PassThroughExporter for all instruments,
+// unless the OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE
+// environment variable is set to "cumulative", "delta" or "lowmemory".
+// This option takes precedence over the environment variable.  Use
+// metricsdk.NewInstrumentExportKindSelector to export specific
+// instruments using a different ExportKind than the rest.
+func WithMetricExportKindSelector(selector metricsdk.ExportKindSelector) ExporterOption {
+	return func(cfg *config) {
+		cfg.exportKindSelector = selector
+	}
+}
+
+// WithMaxRequestSize sets the maximum size in bytes of the requests
+// sent to the collector.  The spans or metrics of an export that do not
+// fit into a single request are split along resource and
+// instrumentation library boundaries, and then between individual
+// spans or metrics, into several requests that are sent one after the
+// other.  A failing request does not prevent the others from being
+// sent.  A single span or metric larger than the limit is sent in a
+// request of its own.
+//
+// By default, DefaultMaxRequestSize is used.  A size of zero or less
+// disables splitting.
+func WithMaxRequestSize(size int) ExporterOption {
+	return func(cfg *config) {
+		cfg.maxRequestSize = size
+	}
+}
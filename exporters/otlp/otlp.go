@@ -54,17 +54,23 @@ type Exporter struct {
 
 	c        config
 	metadata metadata.MD
+
+	startup startupBuffer
 }
 
 var _ tracesdk.SpanExporter = (*Exporter)(nil)
 var _ metricsdk.Exporter = (*Exporter)(nil)
 
-// newConfig initializes a config struct with default values and applies
-// any ExporterOptions provided.
+// newConfig initializes a config struct with default values, including
+// those configured by environment variables, and applies any
+// ExporterOptions provided.
 func newConfig(opts ...ExporterOption) config {
+	checkHistogramAggregationEnv()
 	cfg := config{
-		numWorkers:        DefaultNumWorkers,
-		grpcServiceConfig: DefaultGRPCServiceConfig,
+		numWorkers:         DefaultNumWorkers,
+		grpcServiceConfig:  DefaultGRPCServiceConfig,
+		exportKindSelector: exportKindSelectorFromEnv(),
+		maxRequestSize:     DefaultMaxRequestSize,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
@@ -98,6 +104,7 @@ var (
 	errAlreadyStarted  = errors.New("already started")
 	errNotStarted      = errors.New("not started")
 	errDisconnected    = errors.New("exporter disconnected")
+	errNotConnected    = errors.New("exporter not yet connected")
 	errStopped         = errors.New("exporter stopped")
 	errContextCanceled = errors.New("context canceled")
 )
@@ -117,10 +124,14 @@ func (e *Exporter) Start() error {
 		e.backgroundConnectionDoneCh = make(chan bool)
 		e.mu.Unlock()
 
-		// An optimistic first connection attempt to ensure that
-		// applications under heavy load can immediately process
-		// data. See https://github.com/census-ecosystem/opencensus-go-exporter-ocagent/pull/63
-		if err := e.connect(); err == nil {
+		if e.c.lazyConnection {
+			// Have the background connector make the first
+			// connection attempt.
+			e.setStateDisconnected(errNotConnected)
+		} else if err := e.connect(); err == nil {
+			// An optimistic first connection attempt to ensure that
+			// applications under heavy load can immediately process
+			// data. See https://github.com/census-ecosystem/opencensus-go-exporter-ocagent/pull/63
 			e.setStateConnected()
 		} else {
 			e.setStateDisconnected(err)
@@ -170,8 +181,17 @@ func (e *Exporter) enableConnections(cc *grpc.ClientConn) error {
 }
 
 func (e *Exporter) contextWithMetadata(ctx context.Context) context.Context {
-	if e.metadata.Len() > 0 {
-		return metadata.NewOutgoingContext(ctx, e.metadata)
+	md := e.metadata
+	if e.c.headersProvider != nil {
+		if headers := e.c.headersProvider(ctx); len(headers) > 0 {
+			md = md.Copy()
+			for k, v := range headers {
+				md.Set(k, v)
+			}
+		}
+	}
+	if md.Len() > 0 {
+		return metadata.NewOutgoingContext(ctx, md)
 	}
 	return ctx
 }
@@ -259,8 +279,12 @@ func (e *Exporter) Export(parent context.Context, cps metricsdk.CheckpointSet) e
 	}
 
 	if !e.connected() {
+		if e.startup.addMetrics(e.c.startupBufferSize, rms) {
+			return nil
+		}
 		return errDisconnected
 	}
+	rms = e.startup.takeMetrics(rms)
 
 	select {
 	case <-e.stopCh:
@@ -268,20 +292,35 @@ func (e *Exporter) Export(parent context.Context, cps metricsdk.CheckpointSet) e
 	case <-ctx.Done():
 		return errContextCanceled
 	default:
-		e.senderMu.Lock()
-		_, err := e.metricExporter.Export(e.contextWithMetadata(ctx), &colmetricpb.ExportMetricsServiceRequest{
-			ResourceMetrics: rms,
-		})
-		e.senderMu.Unlock()
-		if err != nil {
-			return err
+		var firstErr error
+		sent := false
+		for _, batch := range transform.SplitResourceMetrics(rms, e.c.maxRequestSize) {
+			e.senderMu.Lock()
+			_, err := e.metricExporter.Export(e.contextWithMetadata(ctx), &colmetricpb.ExportMetricsServiceRequest{
+				ResourceMetrics: batch,
+			})
+			e.senderMu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			sent = true
+		}
+		if !sent {
+			// Retain the metrics if the collector has not
+			// yet been reached.
+			_ = e.startup.addMetrics(e.c.startupBufferSize, rms)
+			return firstErr
 		}
+		e.startup.setConnected()
+		return firstErr
 	}
-	return nil
 }
 
-func (e *Exporter) ExportKindFor(*metric.Descriptor, aggregation.Kind) metricsdk.ExportKind {
-	return metricsdk.PassThroughExporter
+func (e *Exporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) metricsdk.ExportKind {
+	return e.c.exportKindSelector.ExportKindFor(desc, kind)
 }
 
 func (e *Exporter) ExportSpans(ctx context.Context, sds []*tracesdk.SpanData) error {
@@ -294,23 +333,42 @@ func (e *Exporter) uploadTraces(ctx context.Context, sdl []*tracesdk.SpanData) e
 		return nil
 	default:
 		if !e.connected() {
+			e.startup.addSpans(e.c.startupBufferSize, sdl)
 			return nil
 		}
+		sdl = e.startup.takeSpans(sdl)
 
 		protoSpans := transform.SpanData(sdl)
 		if len(protoSpans) == 0 {
 			return nil
 		}
 
-		e.senderMu.Lock()
-		_, err := e.traceExporter.Export(e.contextWithMetadata(ctx), &coltracepb.ExportTraceServiceRequest{
-			ResourceSpans: protoSpans,
-		})
-		e.senderMu.Unlock()
-		if err != nil {
-			e.setStateDisconnected(err)
-			return err
+		var firstErr error
+		sent := false
+		for _, batch := range transform.SplitResourceSpans(protoSpans, e.c.maxRequestSize) {
+			e.senderMu.Lock()
+			_, err := e.traceExporter.Export(e.contextWithMetadata(ctx), &coltracepb.ExportTraceServiceRequest{
+				ResourceSpans: batch,
+			})
+			e.senderMu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			sent = true
+		}
+		if firstErr != nil {
+			e.setStateDisconnected(firstErr)
 		}
+		if !sent {
+			// Retain the spans if the collector has not yet
+			// been reached.
+			_ = e.startup.addSpans(e.c.startupBufferSize, sdl)
+			return firstErr
+		}
+		e.startup.setConnected()
+		return firstErr
 	}
-	return nil
 }
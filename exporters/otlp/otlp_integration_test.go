@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,11 +29,14 @@ import (
 
 	commonpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/common/v1"
 	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	resourcepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/resource/v1"
+	tracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/trace/v1"
 	"go.opentelemetry.io/otel/label"
 
 	"go.opentelemetry.io/otel/api/metric"
 	metricapi "go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
 	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/metric/controller/push"
@@ -41,6 +46,34 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
+// startCollector starts an otlptest.Collector listening on a random
+// local port.
+func startCollector(t *testing.T) *otlptest.Collector {
+	return startCollectorAt(t, "localhost:0")
+}
+
+// startCollectorAt starts an otlptest.Collector listening on addr.
+func startCollectorAt(t *testing.T, addr string) *otlptest.Collector {
+	mc, err := otlptest.StartAt(addr)
+	if err != nil {
+		t.Fatalf("Failed to start the collector: %v", err)
+	}
+	return mc
+}
+
+// resourceString returns a key identifying res by its attributes.
+func resourceString(res *resourcepb.Resource) string {
+	attrs := res.GetAttributes()
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+	rstr := ""
+	for _, attr := range attrs {
+		rstr = rstr + attr.String()
+	}
+	return rstr
+}
+
 func TestNewExporter_endToEnd(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -59,17 +92,17 @@ func TestNewExporter_endToEnd(t *testing.T) {
 }
 
 func newExporterEndToEndTest(t *testing.T, additionalOpts []otlp.ExporterOption) {
-	mc := runMockColAtAddr(t, "localhost:56561")
+	mc := startCollectorAt(t, "localhost:56561")
 
 	defer func() {
-		_ = mc.stop()
+		mc.Stop()
 	}()
 
 	<-time.After(5 * time.Millisecond)
 
 	opts := []otlp.ExporterOption{
 		otlp.WithInsecure(),
-		otlp.WithAddress(mc.address),
+		otlp.WithAddress(mc.Address()),
 		otlp.WithReconnectionPeriod(50 * time.Millisecond),
 	}
 
@@ -198,24 +231,27 @@ func newExporterEndToEndTest(t *testing.T, additionalOpts []otlp.ExporterOption)
 
 	// Shutdown the collector too so that we can begin
 	// verification checks of expected data back.
-	_ = mc.stop()
+	mc.Stop()
 
 	// Now verify that we only got two resources
-	rss := mc.getResourceSpans()
-	if got, want := len(rss), 2; got != want {
+	spansByResource := map[string][]*tracepb.Span{}
+	for _, rs := range mc.ResourceSpans() {
+		key := resourceString(rs.Resource)
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			spansByResource[key] = append(spansByResource[key], ils.Spans...)
+		}
+	}
+	if got, want := len(spansByResource), 2; got != want {
 		t.Fatalf("resource span count: got %d, want %d\n", got, want)
 	}
 
-	// Now verify spans and attributes for each resource span.
-	for _, rs := range rss {
-		if len(rs.InstrumentationLibrarySpans) == 0 {
-			t.Fatalf("zero Instrumentation Library Spans")
-		}
-		if got, want := len(rs.InstrumentationLibrarySpans[0].Spans), m; got != want {
+	// Now verify spans and attributes for each resource.
+	for _, spans := range spansByResource {
+		if got, want := len(spans), m; got != want {
 			t.Fatalf("span counts: got %d, want %d", got, want)
 		}
 		attrMap := map[int64]bool{}
-		for _, s := range rs.InstrumentationLibrarySpans[0].Spans {
+		for _, s := range spans {
 			if gotName, want := s.Name, "AlwaysSample"; gotName != want {
 				t.Fatalf("span name: got %s, want %s", gotName, want)
 			}
@@ -232,7 +268,7 @@ func newExporterEndToEndTest(t *testing.T, additionalOpts []otlp.ExporterOption)
 		}
 	}
 
-	metrics := mc.getMetrics()
+	metrics := mc.Metrics()
 	assert.Len(t, metrics, len(instruments), "not enough metrics exported")
 	seen := make(map[string]struct{}, len(instruments))
 	for _, m := range metrics {
@@ -281,14 +317,14 @@ func newExporterEndToEndTest(t *testing.T, additionalOpts []otlp.ExporterOption)
 }
 
 func TestNewExporter_invokeStartThenStopManyTimes(t *testing.T) {
-	mc := runMockCol(t)
+	mc := startCollector(t)
 	defer func() {
-		_ = mc.stop()
+		mc.Stop()
 	}()
 
 	exp, err := otlp.NewExporter(otlp.WithInsecure(),
 		otlp.WithReconnectionPeriod(50*time.Millisecond),
-		otlp.WithAddress(mc.address))
+		otlp.WithAddress(mc.Address()))
 	if err != nil {
 		t.Fatalf("error creating exporter: %v", err)
 	}
@@ -317,11 +353,11 @@ func TestNewExporter_invokeStartThenStopManyTimes(t *testing.T) {
 }
 
 func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
-	mc := runMockCol(t)
+	mc := startCollector(t)
 
 	reconnectionPeriod := 20 * time.Millisecond
 	exp, err := otlp.NewExporter(otlp.WithInsecure(),
-		otlp.WithAddress(mc.address),
+		otlp.WithAddress(mc.Address()),
 		otlp.WithReconnectionPeriod(reconnectionPeriod))
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -332,7 +368,7 @@ func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
 
 	// We'll now stop the collector right away to simulate a connection
 	// dying in the midst of communication or even not existing before.
-	_ = mc.stop()
+	mc.Stop()
 
 	// In the test below, we'll stop the collector many times,
 	// while exporting traces and test to ensure that we can
@@ -344,12 +380,12 @@ func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
 			t,
 			exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "in the midst"}}),
 			"transport: Error while dialing dial tcp %s: connect: connection refused",
-			mc.address,
+			mc.Address(),
 		)
 
 		// Now resurrect the collector by making a new one but reusing the
 		// old address, and the collector should reconnect automatically.
-		nmc := runMockColAtAddr(t, mc.address)
+		nmc := startCollectorAt(t, mc.Address())
 
 		// Give the exporter sometime to reconnect
 		<-time.After(reconnectionPeriod * 4)
@@ -359,18 +395,18 @@ func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
 			require.NoError(t, exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "Resurrected"}}))
 		}
 
-		nmaSpans := nmc.getSpans()
+		nmaSpans := nmc.Spans()
 		// Expecting 10 spanData that were sampled, given that
 		if g, w := len(nmaSpans), n; g != w {
 			t.Fatalf("Round #%d: Connected collector: spans: got %d want %d", j, g, w)
 		}
 
-		dSpans := mc.getSpans()
+		dSpans := mc.Spans()
 		// Expecting 0 spans to have been received by the original but now dead collector
 		if g, w := len(dSpans), 0; g != w {
 			t.Fatalf("Round #%d: Disconnected collector: spans: got %d want %d", j, g, w)
 		}
-		_ = nmc.stop()
+		nmc.Stop()
 	}
 }
 
@@ -400,16 +436,59 @@ func TestNewExporter_collectorOnBadConnection(t *testing.T) {
 	_ = exp.Shutdown(context.Background())
 }
 
+func TestNewExporter_lazyConnectionWithStartupBuffer(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Failed to grab an available port: %v", err)
+	}
+	address := ln.Addr().String()
+	_ = ln.Close()
+
+	reconnectionPeriod := 20 * time.Millisecond
+	exp, err := otlp.NewExporter(otlp.WithInsecure(),
+		otlp.WithAddress(address),
+		otlp.WithReconnectionPeriod(reconnectionPeriod),
+		otlp.WithLazyConnection(),
+		otlp.WithStartupBuffer(10))
+	require.NoError(t, err)
+	defer func() {
+		_ = exp.Shutdown(context.Background())
+	}()
+
+	// The collector is not up yet, these spans are buffered.
+	for i := 0; i < 3; i++ {
+		_ = exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "early"}})
+	}
+
+	mc := startCollectorAt(t, address)
+	defer func() {
+		mc.Stop()
+	}()
+
+	require.Eventually(t, func() bool {
+		_ = exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "late"}})
+		return len(mc.Spans()) > 0
+	}, 10*time.Second, reconnectionPeriod*4)
+
+	var early int
+	for _, span := range mc.Spans() {
+		if span.Name == "early" {
+			early++
+		}
+	}
+	assert.Equal(t, 3, early)
+}
+
 func TestNewExporter_withAddress(t *testing.T) {
-	mc := runMockCol(t)
+	mc := startCollector(t)
 	defer func() {
-		_ = mc.stop()
+		mc.Stop()
 	}()
 
 	exp := otlp.NewUnstartedExporter(
 		otlp.WithInsecure(),
 		otlp.WithReconnectionPeriod(50*time.Millisecond),
-		otlp.WithAddress(mc.address))
+		otlp.WithAddress(mc.Address()))
 
 	defer func() {
 		_ = exp.Shutdown(context.Background())
@@ -421,15 +500,15 @@ func TestNewExporter_withAddress(t *testing.T) {
 }
 
 func TestNewExporter_withHeaders(t *testing.T) {
-	mc := runMockCol(t)
+	mc := startCollector(t)
 	defer func() {
-		_ = mc.stop()
+		mc.Stop()
 	}()
 
 	exp, _ := otlp.NewExporter(
 		otlp.WithInsecure(),
 		otlp.WithReconnectionPeriod(50*time.Millisecond),
-		otlp.WithAddress(mc.address),
+		otlp.WithAddress(mc.Address()),
 		otlp.WithHeaders(map[string]string{"header1": "value1"}),
 	)
 	require.NoError(t, exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "in the midst"}}))
@@ -438,16 +517,74 @@ func TestNewExporter_withHeaders(t *testing.T) {
 		_ = exp.Shutdown(context.Background())
 	}()
 
-	headers := mc.getHeaders()
+	headers := mc.Headers()
+	require.Len(t, headers.Get("header1"), 1)
+	assert.Equal(t, "value1", headers.Get("header1")[0])
+}
+
+func TestNewExporter_withHeadersProvider(t *testing.T) {
+	mc := startCollector(t)
+	defer func() {
+		mc.Stop()
+	}()
+
+	var calls int32
+	exp, _ := otlp.NewExporter(
+		otlp.WithInsecure(),
+		otlp.WithReconnectionPeriod(50*time.Millisecond),
+		otlp.WithAddress(mc.Address()),
+		otlp.WithHeaders(map[string]string{"header1": "value1", "authorization": "static"}),
+		otlp.WithHeadersProvider(func(context.Context) map[string]string {
+			n := atomic.AddInt32(&calls, 1)
+			return map[string]string{"authorization": fmt.Sprintf("Bearer token%d", n)}
+		}),
+	)
+	defer func() {
+		_ = exp.Shutdown(context.Background())
+	}()
+
+	require.NoError(t, exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "first"}}))
+	first := atomic.LoadInt32(&calls)
+	require.NoError(t, exp.ExportSpans(context.Background(), []*exporttrace.SpanData{{Name: "second"}}))
+
+	headers := mc.Headers()
 	require.Len(t, headers.Get("header1"), 1)
 	assert.Equal(t, "value1", headers.Get("header1")[0])
+	assert.Equal(t, []string{fmt.Sprintf("Bearer token%d", first+1)}, headers.Get("authorization"))
+}
+
+func TestNewExporter_withMaxRequestSize(t *testing.T) {
+	mc := startCollector(t)
+	defer func() {
+		mc.Stop()
+	}()
+
+	exp, err := otlp.NewExporter(
+		otlp.WithInsecure(),
+		otlp.WithReconnectionPeriod(50*time.Millisecond),
+		otlp.WithAddress(mc.Address()),
+		otlp.WithMaxRequestSize(1024),
+	)
+	require.NoError(t, err)
+	defer func() {
+		_ = exp.Shutdown(context.Background())
+	}()
+
+	spans := make([]*exporttrace.SpanData, 100)
+	for i := range spans {
+		spans[i] = &exporttrace.SpanData{Name: fmt.Sprintf("span-%03d-%s", i, strings.Repeat("x", 50))}
+	}
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+
+	assert.Len(t, mc.Spans(), 100)
+	assert.Greater(t, len(mc.TraceRequests()), 1)
 }
 
 func TestNewExporter_withMultipleAttributeTypes(t *testing.T) {
-	mc := runMockCol(t)
+	mc := startCollector(t)
 
 	defer func() {
-		_ = mc.stop()
+		mc.Stop()
 	}()
 
 	<-time.After(5 * time.Millisecond)
@@ -455,7 +592,7 @@ func TestNewExporter_withMultipleAttributeTypes(t *testing.T) {
 	exp, _ := otlp.NewExporter(
 		otlp.WithInsecure(),
 		otlp.WithReconnectionPeriod(50*time.Millisecond),
-		otlp.WithAddress(mc.address),
+		otlp.WithAddress(mc.Address()),
 	)
 
 	defer func() {
@@ -506,10 +643,10 @@ func TestNewExporter_withMultipleAttributeTypes(t *testing.T) {
 
 	// Shutdown the collector too so that we can begin
 	// verification checks of expected data back.
-	_ = mc.stop()
+	mc.Stop()
 
 	// Now verify that we only got one span
-	rss := mc.getSpans()
+	rss := mc.Spans()
 	if got, want := len(rss), 1; got != want {
 		t.Fatalf("resource span count: got %d, want %d\n", got, want)
 	}
@@ -791,3 +791,20 @@ func TestEmptyMetricExport(t *testing.T) {
 		assert.Equal(t, test.want, msc.ResourceMetrics())
 	}
 }
+
+func TestMetricExportKindSelector(t *testing.T) {
+	desc := metric.NewDescriptor("high.churn", metric.CounterKind, metric.Int64NumberKind)
+	other := metric.NewDescriptor("other", metric.CounterKind, metric.Int64NumberKind)
+
+	exp := NewUnstartedExporter()
+	assert.Equal(t, metricsdk.PassThroughExporter, exp.ExportKindFor(&desc, aggregation.SumKind))
+
+	exp = NewUnstartedExporter(WithMetricExportKindSelector(
+		metricsdk.NewInstrumentExportKindSelector(
+			metricsdk.CumulativeExporter,
+			map[string]metricsdk.ExportKind{"high.churn": metricsdk.DeltaExporter},
+		),
+	))
+	assert.Equal(t, metricsdk.DeltaExporter, exp.ExportKindFor(&desc, aggregation.SumKind))
+	assert.Equal(t, metricsdk.CumulativeExporter, exp.ExportKindFor(&other, aggregation.SumKind))
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpattribute converts labels to and from the OTLP
+// attributes sent by the OTLP exporter, such as those recorded by the
+// collector of the otlptest package.
+//
+// The OTLP protocol types are generated in an internal package of the
+// OTLP exporter; this package re-exports those of attributes.
+package otlpattribute // import "go.opentelemetry.io/otel/exporters/otlp/otlpattribute"
+
+import (
+	commonpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/common/v1"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+	"go.opentelemetry.io/otel/label"
+)
+
+// The OTLP attribute types.
+type (
+	KeyValue             = commonpb.KeyValue
+	AnyValue             = commonpb.AnyValue
+	AnyValue_StringValue = commonpb.AnyValue_StringValue //nolint:golint // named as generated
+	AnyValue_BoolValue   = commonpb.AnyValue_BoolValue   //nolint:golint // named as generated
+	AnyValue_IntValue    = commonpb.AnyValue_IntValue    //nolint:golint // named as generated
+	AnyValue_DoubleValue = commonpb.AnyValue_DoubleValue //nolint:golint // named as generated
+	AnyValue_ArrayValue  = commonpb.AnyValue_ArrayValue  //nolint:golint // named as generated
+	AnyValue_KvlistValue = commonpb.AnyValue_KvlistValue //nolint:golint // named as generated
+	ArrayValue           = commonpb.ArrayValue
+	KeyValueList         = commonpb.KeyValueList
+)
+
+// Attributes converts labels into OTLP attribute key-values, in the
+// same order.
+func Attributes(labels []label.KeyValue) []*KeyValue {
+	return transform.Attributes(labels)
+}
+
+// SetAttributes converts a label Set into OTLP attribute key-values,
+// sorted by key.
+func SetAttributes(set *label.Set) []*KeyValue {
+	return transform.SetAttributes(set)
+}
+
+// Labels converts OTLP attribute key-values into labels.  It is the
+// inverse of Attributes, except that integers are returned as INT64
+// labels and floating point numbers as FLOAT64 labels.  Attributes
+// whose value cannot be represented as a label, such as arrays with
+// mixed or nested element types, are dropped.
+func Labels(attrs []*KeyValue) []label.KeyValue {
+	return transform.Labels(attrs)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpattribute_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpattribute"
+	"go.opentelemetry.io/otel/label"
+)
+
+func TestLabelsRoundTrip(t *testing.T) {
+	labels := []label.KeyValue{
+		label.Bool("bool", true),
+		label.Int64("int64", -1),
+		label.Float64("float64", 1.5),
+		label.String("string", "s"),
+		label.Array("bool array", []bool{true, false}),
+		label.Array("int64 array", []int64{1, 2}),
+		label.Array("float64 array", []float64{1.5}),
+		label.Array("string array", []string{"a", "b"}),
+	}
+	assert.Equal(t, labels, otlpattribute.Labels(otlpattribute.Attributes(labels)))
+
+	set := label.NewSet(labels...)
+	assert.Equal(t, set.ToSlice(), otlpattribute.Labels(otlpattribute.SetAttributes(&set)))
+	assert.Nil(t, otlpattribute.Labels(nil))
+}
+
+func TestLabelsDropsUnrepresentable(t *testing.T) {
+	mixed := &otlpattribute.KeyValue{
+		Key: "mixed",
+		Value: &otlpattribute.AnyValue{Value: &otlpattribute.AnyValue_ArrayValue{
+			ArrayValue: &otlpattribute.ArrayValue{Values: []*otlpattribute.AnyValue{
+				{Value: &otlpattribute.AnyValue_IntValue{IntValue: 1}},
+				{Value: &otlpattribute.AnyValue_StringValue{StringValue: "a"}},
+			}},
+		}},
+	}
+	empty := &otlpattribute.KeyValue{Key: "empty", Value: &otlpattribute.AnyValue{}}
+	valid := &otlpattribute.KeyValue{
+		Key:   "valid",
+		Value: &otlpattribute.AnyValue{Value: &otlpattribute.AnyValue_IntValue{IntValue: 1}},
+	}
+	assert.Equal(t, []label.KeyValue{label.Int64("valid", 1)}, otlpattribute.Labels([]*otlpattribute.KeyValue{mixed, empty, valid}))
+}
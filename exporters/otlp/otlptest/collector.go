@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlptest provides an in-process OTLP collector that records
+// the requests it receives, for testing export pipelines end-to-end.
+//
+// The collector serves the gRPC protocol, the only one the OTLP
+// exporter implements.  The version of the OTLP protocol it implements
+// has no partial success responses, so a request either fails with an
+// injected error or is recorded as a whole.
+package otlptest // import "go.opentelemetry.io/otel/exporters/otlp/otlptest"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	colmetricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/trace/v1"
+)
+
+// Fault is injected into the handling of a single request.
+type Fault struct {
+	// Delay delays the response to the request.  The delay ends
+	// early if the request is canceled.
+	Delay time.Duration
+
+	// Err, if not nil, is returned instead of a response.  The
+	// request is then not recorded.  Use status.Error to return a
+	// specific gRPC status code.
+	Err error
+}
+
+// Collector is an in-process gRPC server implementing the OTLP trace
+// and metrics services.  It records the requests it receives and
+// succeeds unless faults are injected with InjectFaults.
+type Collector struct {
+	mu             sync.Mutex
+	traceRequests  []*ExportTraceServiceRequest
+	metricRequests []*ExportMetricsServiceRequest
+	headers        metadata.MD
+	faults         []Fault
+
+	address  string
+	srv      *grpc.Server
+	stopOnce sync.Once
+}
+
+var (
+	_ coltracepb.TraceServiceServer    = (*traceService)(nil)
+	_ colmetricpb.MetricsServiceServer = (*metricService)(nil)
+)
+
+// Start starts a Collector listening on a random local port.
+func Start() (*Collector, error) {
+	return StartAt("localhost:0")
+}
+
+// StartAt starts a Collector listening on addr.
+func StartAt(addr string) (*Collector, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collector{
+		address: ln.Addr().String(),
+		srv:     grpc.NewServer(),
+	}
+	coltracepb.RegisterTraceServiceServer(c.srv, &traceService{c})
+	colmetricpb.RegisterMetricsServiceServer(c.srv, &metricService{c})
+	go func() {
+		_ = c.srv.Serve(ln)
+	}()
+	return c, nil
+}
+
+// Address returns the address the Collector listens on, to be passed
+// to otlp.WithAddress.
+func (c *Collector) Address() string {
+	return c.address
+}
+
+// Stop stops the Collector, closing all connections.  It waits for
+// pending requests to be handled.
+func (c *Collector) Stop() {
+	c.stopOnce.Do(c.srv.GracefulStop)
+}
+
+// InjectFaults queues faults to be injected into the following
+// requests, one fault per request in order, regardless of the service
+// they are sent to.  Requests succeed once the queue is empty.
+func (c *Collector) InjectFaults(faults ...Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults = append(c.faults, faults...)
+}
+
+// TraceRequests returns the trace export requests received so far.
+func (c *Collector) TraceRequests() []*ExportTraceServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*ExportTraceServiceRequest(nil), c.traceRequests...)
+}
+
+// MetricRequests returns the metric export requests received so far.
+func (c *Collector) MetricRequests() []*ExportMetricsServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*ExportMetricsServiceRequest(nil), c.metricRequests...)
+}
+
+// ResourceSpans returns the ResourceSpans of all trace export requests
+// received so far, in the order they were received.
+func (c *Collector) ResourceSpans() []*ResourceSpans {
+	var rss []*ResourceSpans
+	for _, req := range c.TraceRequests() {
+		rss = append(rss, req.ResourceSpans...)
+	}
+	return rss
+}
+
+// Spans returns all spans received so far, in the order they were
+// received.
+func (c *Collector) Spans() []*Span {
+	var spans []*Span
+	for _, rs := range c.ResourceSpans() {
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			spans = append(spans, ils.Spans...)
+		}
+	}
+	return spans
+}
+
+// ResourceMetrics returns the ResourceMetrics of all metric export
+// requests received so far, in the order they were received.
+func (c *Collector) ResourceMetrics() []*ResourceMetrics {
+	var rms []*ResourceMetrics
+	for _, req := range c.MetricRequests() {
+		rms = append(rms, req.ResourceMetrics...)
+	}
+	return rms
+}
+
+// Metrics returns all metrics received so far, in the order they were
+// received.
+func (c *Collector) Metrics() []*Metric {
+	var metrics []*Metric
+	for _, rm := range c.ResourceMetrics() {
+		for _, ilm := range rm.InstrumentationLibraryMetrics {
+			metrics = append(metrics, ilm.Metrics...)
+		}
+	}
+	return metrics
+}
+
+// Headers returns the metadata of the last request received.
+func (c *Collector) Headers() metadata.MD {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.headers
+}
+
+// Reset forgets the requests received so far and the faults not yet
+// injected.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traceRequests = nil
+	c.metricRequests = nil
+	c.headers = nil
+	c.faults = nil
+}
+
+// handle injects the next fault into a request and records its
+// metadata.  It returns the error to respond with, if any.
+func (c *Collector) handle(ctx context.Context) error {
+	c.mu.Lock()
+	c.headers, _ = metadata.FromIncomingContext(ctx)
+	var fault Fault
+	if len(c.faults) > 0 {
+		fault = c.faults[0]
+		c.faults = c.faults[1:]
+	}
+	c.mu.Unlock()
+
+	if fault.Delay > 0 {
+		t := time.NewTimer(fault.Delay)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fault.Err
+}
+
+type traceService struct {
+	c *Collector
+}
+
+func (s *traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	if err := s.c.handle(ctx); err != nil {
+		return nil, err
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.traceRequests = append(s.c.traceRequests, req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type metricService struct {
+	c *Collector
+}
+
+func (s *metricService) Export(ctx context.Context, req *colmetricpb.ExportMetricsServiceRequest) (*colmetricpb.ExportMetricsServiceResponse, error) {
+	if err := s.c.handle(ctx); err != nil {
+		return nil, err
+	}
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	s.c.metricRequests = append(s.c.metricRequests, req)
+	return &colmetricpb.ExportMetricsServiceResponse{}, nil
+}
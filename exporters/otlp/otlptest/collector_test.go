@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptest"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+func newExporter(t *testing.T, c *otlptest.Collector) *otlp.Exporter {
+	exp, err := otlp.NewExporter(
+		otlp.WithInsecure(),
+		otlp.WithAddress(c.Address()),
+		otlp.WithReconnectionPeriod(10*time.Millisecond),
+		otlp.WithHeaders(map[string]string{"header": "value"}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = exp.Shutdown(context.Background())
+	})
+	return exp
+}
+
+func TestCollectorRecordsRequests(t *testing.T) {
+	c, err := otlptest.Start()
+	require.NoError(t, err)
+	defer c.Stop()
+	exp := newExporter(t, c)
+
+	ctx := context.Background()
+	require.NoError(t, exp.ExportSpans(ctx, []*exporttrace.SpanData{{Name: "a"}, {Name: "b"}}))
+	require.NoError(t, exp.ExportSpans(ctx, []*exporttrace.SpanData{{Name: "c"}}))
+
+	assert.Len(t, c.TraceRequests(), 2)
+	var names []string
+	for _, s := range c.Spans() {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+	assert.Equal(t, []string{"value"}, c.Headers().Get("header"))
+	assert.Empty(t, c.MetricRequests())
+
+	c.Reset()
+	assert.Empty(t, c.Spans())
+}
+
+func TestCollectorInjectFaults(t *testing.T) {
+	c, err := otlptest.Start()
+	require.NoError(t, err)
+	defer c.Stop()
+	exp := newExporter(t, c)
+
+	c.InjectFaults(
+		otlptest.Fault{Err: status.Error(codes.InvalidArgument, "rejected")},
+		otlptest.Fault{Delay: time.Second},
+	)
+
+	ctx := context.Background()
+	err = exp.ExportSpans(ctx, []*exporttrace.SpanData{{Name: "rejected"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The delay applies to the first export after reconnecting.
+	require.Eventually(t, func() bool {
+		shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err = exp.ExportSpans(shortCtx, []*exporttrace.SpanData{{Name: "delayed"}})
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// The exporter drops spans until it has reconnected after a
+	// failed export.
+	require.Eventually(t, func() bool {
+		_ = exp.ExportSpans(ctx, []*exporttrace.SpanData{{Name: "accepted"}})
+		return len(c.Spans()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "accepted", c.Spans()[0].Name)
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptest
+
+import (
+	colmetricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/collector/trace/v1"
+	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	resourcepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/resource/v1"
+	tracepb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/trace/v1"
+)
+
+// The OTLP protocol types recorded by the Collector.  They are
+// generated in an internal package of the OTLP exporter and
+// re-exported here so that tests can name them.  The attribute types
+// are re-exported by the otlpattribute package.
+type (
+	ExportTraceServiceRequest   = coltracepb.ExportTraceServiceRequest
+	ExportMetricsServiceRequest = colmetricpb.ExportMetricsServiceRequest
+
+	Resource = resourcepb.Resource
+
+	ResourceSpans               = tracepb.ResourceSpans
+	InstrumentationLibrarySpans = tracepb.InstrumentationLibrarySpans
+	Span                        = tracepb.Span
+
+	ResourceMetrics               = metricpb.ResourceMetrics
+	InstrumentationLibraryMetrics = metricpb.InstrumentationLibraryMetrics
+	Metric                        = metricpb.Metric
+	MetricDescriptor              = metricpb.MetricDescriptor
+	Int64DataPoint                = metricpb.Int64DataPoint
+	DoubleDataPoint               = metricpb.DoubleDataPoint
+)
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"sync"
+
+	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+// startupBuffer holds the data exported before the exporter first
+// connects to the collector.
+type startupBuffer struct {
+	mu sync.Mutex
+	// connected is set once data has been successfully exported,
+	// after which no more data is buffered.
+	connected bool
+	spans     []*tracesdk.SpanData
+	metrics   []*metricpb.ResourceMetrics
+}
+
+// setConnected stops buffering.  It is called after the first
+// successful export.
+func (b *startupBuffer) setConnected() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.connected = true
+	b.spans = nil
+	b.metrics = nil
+}
+
+// addSpans buffers spans, retaining at most size spans.  It returns
+// whether the spans were buffered.
+func (b *startupBuffer) addSpans(size int, spans []*tracesdk.SpanData) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.connected || size <= 0 {
+		return false
+	}
+	b.spans = append(b.spans, spans...)
+	if over := len(b.spans) - size; over > 0 {
+		b.spans = append(b.spans[:0:0], b.spans[over:]...)
+	}
+	return true
+}
+
+// takeSpans removes the buffered spans and returns them followed by
+// spans.
+func (b *startupBuffer) takeSpans(spans []*tracesdk.SpanData) []*tracesdk.SpanData {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.spans) == 0 {
+		return spans
+	}
+	spans = append(b.spans, spans...)
+	b.spans = nil
+	return spans
+}
+
+// addMetrics buffers the metrics of one export, retaining at most size
+// ResourceMetrics, i.e., one per resource per export.  It returns
+// whether the metrics were buffered.
+func (b *startupBuffer) addMetrics(size int, rms []*metricpb.ResourceMetrics) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.connected || size <= 0 {
+		return false
+	}
+	b.metrics = append(b.metrics, rms...)
+	if over := len(b.metrics) - size; over > 0 {
+		b.metrics = append(b.metrics[:0:0], b.metrics[over:]...)
+	}
+	return true
+}
+
+// takeMetrics removes the buffered metrics and returns them followed
+// by rms.
+func (b *startupBuffer) takeMetrics(rms []*metricpb.ResourceMetrics) []*metricpb.ResourceMetrics {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.metrics) == 0 {
+		return rms
+	}
+	rms = append(b.metrics, rms...)
+	b.metrics = nil
+	return rms
+}
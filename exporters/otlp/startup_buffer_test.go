@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metricpb "go.opentelemetry.io/otel/exporters/otlp/internal/opentelemetry-proto-gen/metrics/v1"
+	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+func TestStartupBufferSpans(t *testing.T) {
+	var b startupBuffer
+	a, c, d := &tracesdk.SpanData{Name: "a"}, &tracesdk.SpanData{Name: "c"}, &tracesdk.SpanData{Name: "d"}
+
+	assert.False(t, b.addSpans(0, []*tracesdk.SpanData{a}))
+	assert.True(t, b.addSpans(2, []*tracesdk.SpanData{a, c}))
+	assert.True(t, b.addSpans(2, []*tracesdk.SpanData{d}))
+
+	// The oldest span was dropped.
+	assert.Equal(t, []*tracesdk.SpanData{c, d, a}, b.takeSpans([]*tracesdk.SpanData{a}))
+	assert.Empty(t, b.takeSpans(nil))
+
+	b.setConnected()
+	assert.False(t, b.addSpans(2, []*tracesdk.SpanData{a}))
+}
+
+func TestStartupBufferMetrics(t *testing.T) {
+	var b startupBuffer
+	r1, r2, r3 := &metricpb.ResourceMetrics{}, &metricpb.ResourceMetrics{}, &metricpb.ResourceMetrics{}
+
+	assert.True(t, b.addMetrics(2, []*metricpb.ResourceMetrics{r1}))
+	assert.True(t, b.addMetrics(2, []*metricpb.ResourceMetrics{r2, r3}))
+	assert.Equal(t, []*metricpb.ResourceMetrics{r2, r3, r1}, b.takeMetrics([]*metricpb.ResourceMetrics{r1}))
+
+	b.setConnected()
+	assert.False(t, b.addMetrics(2, []*metricpb.ResourceMetrics{r1}))
+}
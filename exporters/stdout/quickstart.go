@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/api/baggage"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/propagators"
+	"go.opentelemetry.io/otel/sdk/metric/controller/push"
+	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// QuickStartPushPeriod is the interval at which the metrics of the
+// pipeline installed by QuickStart are printed.
+const QuickStartPushPeriod = 2 * time.Second
+
+// QuickStart installs a pipeline printing all telemetry to stdout, for
+// local development and tutorials.  It is NOT meant for production
+// use: every span is sampled and printed synchronously when it ends,
+// and all output is pretty-printed JSON.
+//
+// It registers globally a TracerProvider sampling every span, a
+// MeterProvider printing metrics every QuickStartPushPeriod, and W3C
+// trace context and baggage propagation.  The returned shutdown
+// function prints the metrics one last time and stops the pipeline:
+//
+//	shutdown, err := stdout.QuickStart()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer shutdown()
+func QuickStart() (shutdown func(), err error) {
+	exporter, err := NewExporter(WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSyncer(exporter),
+	)
+	pusher := push.New(
+		basic.New(
+			simple.NewWithExactDistribution(),
+			exporter,
+		),
+		exporter,
+		push.WithPeriod(QuickStartPushPeriod),
+	)
+	pusher.Start()
+
+	tc := propagators.TraceContext{}
+	bag := baggage.Baggage{}
+	global.SetTracerProvider(tp)
+	global.SetMeterProvider(pusher.Provider())
+	global.SetPropagators(propagation.New(
+		propagation.WithExtractors(tc, bag),
+		propagation.WithInjectors(tc, bag),
+	))
+	return pusher.Stop, nil
+}
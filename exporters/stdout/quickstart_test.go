@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/baggage"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/label"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestQuickStart(t *testing.T) {
+	global.ResetForTest(t)
+
+	shutdown, err := stdout.QuickStart()
+	require.NoError(t, err)
+	defer shutdown()
+
+	require.IsType(t, &sdktrace.Provider{}, global.TracerProvider())
+	ctx := baggage.NewContext(context.Background(), label.String("k", "v"))
+	ctx, span := global.Tracer("quickstart").Start(ctx, "span")
+	defer span.End()
+	assert.True(t, span.SpanContext().IsSampled())
+
+	header := http.Header{}
+	propagation.InjectHTTP(ctx, global.Propagators(), header)
+	assert.NotEmpty(t, header.Get("traceparent"))
+	assert.Equal(t, "k=v", header.Get("otcorrelations"))
+}
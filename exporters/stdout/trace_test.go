@@ -121,11 +121,12 @@ func TestExporter_ExportSpan(t *testing.T) {
 		`"DroppedAttributeCount":0,` +
 		`"DroppedMessageEventCount":0,` +
 		`"DroppedLinkCount":0,` +
+		`"ParentLinkCount":0,` +
 		`"ChildSpanCount":0,` +
 		`"Resource":[` +
 		`{` +
-		`"Key":"rk1",` +
-		`"Value":{"Type":"STRING","Value":"rv11"}` +
+		`"key":"rk1",` +
+		`"value":{"stringValue":"rv11"}` +
 		`}],` +
 		`"InstrumentationLibrary":{` +
 		`"Name":"",` +
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsreload provides TLS client certificates that are reloaded
+// from files when they are rotated, for exporters authenticating with
+// short-lived mTLS certificates.
+//
+// The configuration it returns can be used with the OTLP exporter:
+//
+//	r, err := tlsreload.New("client.crt", "client.key")
+//	...
+//	otlp.WithTLSCredentials(credentials.NewTLS(r.ClientConfig(&tls.Config{RootCAs: pool})))
+//
+// and with exporters sending over HTTP, e.g., Zipkin:
+//
+//	zipkin.WithClient(&http.Client{
+//		Transport: &http.Transport{TLSClientConfig: r.ClientConfig(nil)},
+//	})
+package tlsreload // import "go.opentelemetry.io/otel/exporters/tlsreload"
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+)
+
+// DefaultCheckInterval is the default interval at which the files of a
+// Reloader are checked for changes.
+const DefaultCheckInterval = time.Minute
+
+// Option configures a Reloader.
+type Option func(*config)
+
+type config struct {
+	checkInterval time.Duration
+}
+
+// WithCheckInterval sets the interval at which the certificate and key
+// files are checked for changes.  The default is DefaultCheckInterval.
+func WithCheckInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.checkInterval = d
+	}
+}
+
+// Reloader holds a client certificate loaded from a PEM encoded
+// certificate file and key file, and reloads it when either file is
+// modified.
+//
+// The files are checked when a TLS handshake requests the certificate,
+// at most once per check interval, so that a rotated certificate is
+// used by the next connection without a background goroutine.  If the
+// rotated files cannot be loaded, e.g., because only one of them was
+// replaced yet, the error is reported to the global error handler, the
+// previous certificate is used and loading is retried at the next
+// check.
+type Reloader struct {
+	certFile, keyFile string
+	checkInterval     time.Duration
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+
+	// now returns the current time.  It is replaced in tests.
+	now func() time.Time
+}
+
+// New returns a Reloader for the certificate in certFile and the key
+// in keyFile.  It returns an error if they cannot be loaded.
+func New(certFile, keyFile string, opts ...Option) (*Reloader, error) {
+	c := config{checkInterval: DefaultCheckInterval}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	r := &Reloader{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		checkInterval: c.checkInterval,
+		now:           time.Now,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.lastCheck = r.now()
+	return r, nil
+}
+
+// GetClientCertificate returns the current certificate, reloading it
+// if the files were modified.  It can be used as the
+// GetClientCertificate function of a tls.Config.
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := r.now(); now.Sub(r.lastCheck) >= r.checkInterval {
+		r.lastCheck = now
+		if err := r.reloadIfModified(); err != nil {
+			global.Handle(err)
+		}
+	}
+	return r.cert, nil
+}
+
+// ClientConfig returns a copy of base, or a new tls.Config if base is
+// nil, presenting the certificate of r to servers.
+func (r *Reloader) ClientConfig(base *tls.Config) *tls.Config {
+	var c *tls.Config
+	if base != nil {
+		c = base.Clone()
+	} else {
+		c = &tls.Config{}
+	}
+	c.Certificates = nil
+	c.GetClientCertificate = r.GetClientCertificate
+	return c
+}
+
+// reloadIfModified reloads the certificate if the modification time
+// of either file changed.
+func (r *Reloader) reloadIfModified() error {
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return nil
+	}
+	return r.load()
+}
+
+// load loads the certificate and records the modification times of
+// its files.
+func (r *Reloader) load() error {
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("tlsreload: loading %s and %s: %w", r.certFile, r.keyFile, err)
+	}
+	r.cert = &cert
+	r.certMod, r.keyMod = certMod, keyMod
+	return nil
+}
+
+func (r *Reloader) modTimes() (certMod, keyMod time.Time, err error) {
+	fi, err := os.Stat(r.certFile)
+	if err != nil {
+		return certMod, keyMod, fmt.Errorf("tlsreload: %w", err)
+	}
+	certMod = fi.ModTime()
+	fi, err = os.Stat(r.keyFile)
+	if err != nil {
+		return certMod, keyMod, fmt.Errorf("tlsreload: %w", err)
+	}
+	return certMod, fi.ModTime(), nil
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyPair writes a self-signed certificate with the serial number
+// serial and its key to certFile and keyFile, modified at mod.
+func writeKeyPair(t *testing.T, certFile, keyFile string, serial int64, mod time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certFile, mod, mod))
+	require.NoError(t, os.Chtimes(keyFile, mod, mod))
+}
+
+func serialOf(t *testing.T, r *Reloader) int64 {
+	cert, err := r.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return parsed.SerialNumber.Int64()
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsreload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+
+	mod := time.Now().Add(-time.Hour).Truncate(time.Second)
+	writeKeyPair(t, certFile, keyFile, 1, mod)
+
+	r, err := New(certFile, keyFile, WithCheckInterval(time.Minute))
+	require.NoError(t, err)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	assert.Equal(t, int64(1), serialOf(t, r))
+
+	// The files are not checked again within the interval.
+	writeKeyPair(t, certFile, keyFile, 2, mod.Add(time.Second))
+	assert.Equal(t, int64(1), serialOf(t, r))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, int64(2), serialOf(t, r))
+
+	// A partially rotated pair keeps the previous certificate until
+	// the rotation completes.
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("invalid"), 0600))
+	now = now.Add(time.Minute)
+	assert.Equal(t, int64(2), serialOf(t, r))
+
+	writeKeyPair(t, certFile, keyFile, 3, mod.Add(2*time.Second))
+	now = now.Add(time.Minute)
+	assert.Equal(t, int64(3), serialOf(t, r))
+}
+
+func TestNewError(t *testing.T) {
+	_, err := New("missing.crt", "missing.key")
+	assert.Error(t, err)
+}
+
+func TestClientConfig(t *testing.T) {
+	r := &Reloader{}
+	base := &tls.Config{ServerName: "collector", Certificates: []tls.Certificate{{}}}
+
+	c := r.ClientConfig(base)
+	assert.Equal(t, "collector", c.ServerName)
+	assert.Empty(t, c.Certificates)
+	assert.NotNil(t, c.GetClientCertificate)
+	assert.Len(t, base.Certificates, 1, "base must not be modified")
+
+	assert.NotNil(t, r.ClientConfig(nil).GetClientCertificate)
+}
@@ -390,11 +390,13 @@ func Test_spanDataToThrift(t *testing.T) {
 				Name:      "/foo",
 				StartTime: now,
 				EndTime:   now,
-				Links: []apitrace.Link{
+				Links: []export.Link{
 					{
-						SpanContext: apitrace.SpanContext{
-							TraceID: linkTraceID,
-							SpanID:  linkSpanID,
+						Link: apitrace.Link{
+							SpanContext: apitrace.SpanContext{
+								TraceID: linkTraceID,
+								SpanID:  linkSpanID,
+							},
 						},
 					},
 				},
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/internal/diag"
+)
+
+// rateLimitedHandler forwards a bounded number of distinct errors per
+// period to a delegate ErrorHandler.
+type rateLimitedHandler struct {
+	delegate ErrorHandler
+	limiter  *diag.RateLimiter
+
+	// now returns the current time.  It is replaced in tests.
+	now func() time.Time
+}
+
+var _ ErrorHandler = (*rateLimitedHandler)(nil)
+
+// NewRateLimitedHandler returns an ErrorHandler that forwards at most
+// burst errors per period to h.  Within a period, an error with the
+// same message as one already forwarded is not forwarded again.
+//
+// Errors that are not forwarded are counted.  The first error handled
+// after a period with suppressed errors is preceded by a single
+// summary error reporting how many errors were suppressed.  The
+// summary is not flushed on a timer: the handler starts no goroutine,
+// so the errors suppressed in the last period before the errors stop
+// are never summarized.
+//
+// This is useful to prevent repeated errors, e.g. an exporter failing
+// to connect on every collection interval, from flooding logs:
+//
+//	global.SetErrorHandler(otel.NewRateLimitedHandler(h, time.Minute, 10))
+//
+// ConfigureDiagnostics applies the same rate limiting to all errors
+// reported with global.Handle.
+func NewRateLimitedHandler(h ErrorHandler, per time.Duration, burst int) ErrorHandler {
+	return &rateLimitedHandler{
+		delegate: h,
+		limiter:  diag.NewRateLimiter(per, burst),
+		now:      time.Now,
+	}
+}
+
+// Handle implements ErrorHandler.
+func (h *rateLimitedHandler) Handle(err error) {
+	if err == nil {
+		return
+	}
+
+	// The delegate is called after Check has released its lock so
+	// that it may itself block or call back into this handler.
+	summary, forward := h.limiter.Check(err, h.now())
+	if summary != nil {
+		h.delegate.Handle(summary)
+	}
+	if forward {
+		h.delegate.Handle(err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	errs []error
+}
+
+func (h *recordingHandler) Handle(err error) {
+	h.errs = append(h.errs, err)
+}
+
+func newTestRateLimitedHandler(per time.Duration, burst int) (*rateLimitedHandler, *recordingHandler, *time.Time) {
+	rec := new(recordingHandler)
+	h := NewRateLimitedHandler(rec, per, burst).(*rateLimitedHandler)
+	now := time.Unix(1000, 0)
+	h.now = func() time.Time { return now }
+	return h, rec, &now
+}
+
+func TestRateLimitedHandlerDeduplicates(t *testing.T) {
+	h, rec, _ := newTestRateLimitedHandler(time.Minute, 10)
+
+	errRefused := errors.New("connection refused")
+	for i := 0; i < 5; i++ {
+		h.Handle(errRefused)
+	}
+	h.Handle(errors.New("connection refused"))
+
+	assert.Equal(t, []error{errRefused}, rec.errs)
+}
+
+func TestRateLimitedHandlerBurst(t *testing.T) {
+	h, rec, _ := newTestRateLimitedHandler(time.Minute, 2)
+
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+	h.Handle(errA)
+	h.Handle(errB)
+	h.Handle(errC)
+
+	assert.Equal(t, []error{errA, errB}, rec.errs)
+}
+
+func TestRateLimitedHandlerSummary(t *testing.T) {
+	h, rec, now := newTestRateLimitedHandler(time.Minute, 1)
+
+	errA, errB := errors.New("a"), errors.New("b")
+	h.Handle(errA)
+	h.Handle(errA)
+	h.Handle(errB)
+	require.Len(t, rec.errs, 1)
+
+	// Still within the period.
+	*now = now.Add(30 * time.Second)
+	h.Handle(errA)
+	require.Len(t, rec.errs, 1)
+
+	*now = now.Add(time.Minute)
+	h.Handle(errA)
+	require.Len(t, rec.errs, 3)
+	assert.Contains(t, rec.errs[1].Error(), "3 errors suppressed")
+	assert.True(t, errors.Is(rec.errs[1], errA))
+	assert.Equal(t, errA, rec.errs[2])
+
+	// No summary is emitted for a period without suppressed errors.
+	*now = now.Add(time.Minute)
+	h.Handle(errB)
+	assert.Equal(t, []error{errA, rec.errs[1], errA, errB}, rec.errs)
+}
+
+func TestRateLimitedHandlerNilError(t *testing.T) {
+	h, rec, _ := newTestRateLimitedHandler(time.Minute, 1)
+	h.Handle(nil)
+	assert.Empty(t, rec.errs)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diag holds the diagnostics settings shared by all
+// OpenTelemetry packages.  Errors reported with global.Handle are
+// filtered according to these settings before they reach the global
+// ErrorHandler.  The public configuration surface is
+// otel.ConfigureDiagnostics.
+package diag // import "go.opentelemetry.io/otel/internal/diag"
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity int8
+
+const (
+	// Debug diagnostics are useful to troubleshoot the configuration
+	// of OpenTelemetry.
+	Debug Severity = iota
+	// Warn diagnostics report a likely misconfiguration that does
+	// not lose data, e.g. an invalid instrument unit.
+	Warn
+	// Error diagnostics report data that was lost or a component that
+	// does not work as configured.  It is the severity of errors
+	// without an explicit one.
+	Error
+)
+
+// String returns the name of the Severity.
+func (s Severity) String() string {
+	switch s {
+	case Debug:
+		return "debug"
+	case Warn:
+		return "warn"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("Severity(%d)", int8(s))
+}
+
+// severityError is an error with an explicit Severity.
+type severityError struct {
+	err      error
+	severity Severity
+}
+
+func (e *severityError) Error() string { return e.err.Error() }
+
+func (e *severityError) Unwrap() error { return e.err }
+
+// WithSeverity returns err annotated with severity s.  It returns nil
+// if err is nil.
+func WithSeverity(err error, s Severity) error {
+	if err == nil {
+		return nil
+	}
+	return &severityError{err: err, severity: s}
+}
+
+// SeverityOf returns the Severity err was annotated with, or Error if
+// it was not.
+func SeverityOf(err error) Severity {
+	var se *severityError
+	if errors.As(err, &se) {
+		return se.severity
+	}
+	return Error
+}
+
+// Config contains the diagnostics settings.
+type Config struct {
+	// MinSeverity is the least severe diagnostic that is handled.
+	// The zero value, Debug, handles all diagnostics.
+	MinSeverity Severity
+
+	// RateLimitPeriod and RateLimitBurst, if both are positive,
+	// limit the number of distinct diagnostics handled per period,
+	// see RateLimiter.
+	RateLimitPeriod time.Duration
+	RateLimitBurst  int
+
+	// Logger is used to log diagnostics when no ErrorHandler is
+	// registered.  If nil, diagnostics are logged to os.Stderr.
+	Logger *log.Logger
+}
+
+// settings are the current diagnostics settings.
+type settings struct {
+	config  Config
+	limiter *RateLimiter
+}
+
+var (
+	current atomic.Value // *settings
+
+	defaultLogger = log.New(os.Stderr, "", log.LstdFlags)
+)
+
+func init() {
+	Configure(Config{})
+}
+
+// Configure replaces the diagnostics settings with c.
+func Configure(c Config) {
+	s := &settings{config: c}
+	if c.RateLimitPeriod > 0 && c.RateLimitBurst > 0 {
+		s.limiter = NewRateLimiter(c.RateLimitPeriod, c.RateLimitBurst)
+	}
+	current.Store(s)
+}
+
+// Logger returns the Logger diagnostics are logged with when no
+// ErrorHandler is registered.
+func Logger() *log.Logger {
+	if l := current.Load().(*settings).config.Logger; l != nil {
+		return l
+	}
+	return defaultLogger
+}
+
+// Filter returns the diagnostics to handle for err: none if it is
+// less severe than the configured minimum or suppressed by rate
+// limiting, and otherwise err, possibly preceded by a summary of the
+// diagnostics suppressed in the previous rate limiting period.
+func Filter(err error) []error {
+	if err == nil {
+		return nil
+	}
+	s := current.Load().(*settings)
+	if SeverityOf(err) < s.config.MinSeverity {
+		return nil
+	}
+	if s.limiter == nil {
+		return []error{err}
+	}
+	summary, forward := s.limiter.Check(err, time.Now())
+	switch {
+	case summary != nil && forward:
+		return []error{summary, err}
+	case summary != nil:
+		return []error{summary}
+	case forward:
+		return []error{err}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diag
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity(t *testing.T) {
+	err := errors.New("unit")
+	assert.Equal(t, Error, SeverityOf(err))
+	assert.Nil(t, WithSeverity(nil, Warn))
+
+	warn := WithSeverity(err, Warn)
+	assert.Equal(t, "unit", warn.Error())
+	assert.Equal(t, Warn, SeverityOf(warn))
+	assert.True(t, errors.Is(warn, err))
+	assert.Equal(t, Warn, SeverityOf(fmt.Errorf("wrapped: %w", warn)))
+
+	assert.Equal(t, "debug", Debug.String())
+	assert.Equal(t, "warn", Warn.String())
+	assert.Equal(t, "error", Error.String())
+}
+
+func TestFilter(t *testing.T) {
+	defer Configure(Config{})
+
+	debug := WithSeverity(errors.New("debug"), Debug)
+	warn := WithSeverity(errors.New("warn"), Warn)
+	err := errors.New("error")
+
+	assert.Nil(t, Filter(nil))
+	assert.Equal(t, []error{debug}, Filter(debug))
+
+	Configure(Config{MinSeverity: Warn})
+	assert.Nil(t, Filter(debug))
+	assert.Equal(t, []error{warn}, Filter(warn))
+	assert.Equal(t, []error{err}, Filter(err))
+
+	Configure(Config{RateLimitPeriod: time.Hour, RateLimitBurst: 1})
+	assert.Equal(t, []error{err}, Filter(err))
+	assert.Nil(t, Filter(warn))
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(time.Minute, 2)
+	now := time.Unix(1000, 0)
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	check := func(err error) (error, bool) { return l.Check(err, now) }
+
+	summary, forward := check(errA)
+	assert.Nil(t, summary)
+	assert.True(t, forward)
+	_, forward = check(errA)
+	assert.False(t, forward, "duplicate")
+	_, forward = check(errB)
+	assert.True(t, forward)
+	_, forward = check(errC)
+	assert.False(t, forward, "over burst")
+
+	now = now.Add(time.Minute)
+	summary, forward = check(errC)
+	assert.True(t, forward)
+	assert.Contains(t, summary.Error(), "2 errors suppressed")
+	assert.True(t, errors.Is(summary, errA))
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diag
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter bounds the number of distinct errors handled per
+// period.
+type RateLimiter struct {
+	per   time.Duration
+	burst int
+
+	mu          sync.Mutex
+	windowStart time.Time
+	forwarded   int
+	seen        map[string]struct{}
+	suppressed  int
+	example     error
+}
+
+// NewRateLimiter returns a RateLimiter that lets at most burst errors
+// through per period.  Within a period, an error with the same message
+// as one already let through is not let through again.
+func NewRateLimiter(per time.Duration, burst int) *RateLimiter {
+	return &RateLimiter{
+		per:   per,
+		burst: burst,
+		seen:  make(map[string]struct{}),
+	}
+}
+
+// Check reports whether err, handled at time now, should be forwarded.
+// Errors that are not forwarded are counted; the first error checked
+// after a period with suppressed errors is accompanied by a summary
+// error reporting how many errors were suppressed, which should be
+// forwarded before err.
+func (l *RateLimiter) Check(err error, now time.Time) (summary error, forward bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.windowStart.IsZero() || now.Sub(l.windowStart) >= l.per {
+		summary = l.resetLocked(now)
+	}
+
+	msg := err.Error()
+	_, duplicate := l.seen[msg]
+	forward = !duplicate && l.forwarded < l.burst
+	if forward {
+		l.seen[msg] = struct{}{}
+		l.forwarded++
+	} else {
+		if l.example == nil {
+			l.example = err
+		}
+		l.suppressed++
+	}
+	return summary, forward
+}
+
+// resetLocked starts a new period at now and returns a summary of the
+// errors suppressed during the previous period, if any.  It must be
+// called with l.mu held.
+func (l *RateLimiter) resetLocked(now time.Time) error {
+	var summary error
+	if l.suppressed > 0 {
+		summary = fmt.Errorf("%d errors suppressed since %s, e.g.: %w", l.suppressed, l.windowStart.Format(time.RFC3339), l.example)
+	}
+	l.windowStart = now
+	l.forwarded = 0
+	l.suppressed = 0
+	l.example = nil
+	l.seen = make(map[string]struct{})
+	return summary
+}
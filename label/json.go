@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package label
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// otlpKeyValue is the JSON encoding of an OTLP KeyValue.
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// otlpArrayValue is the JSON encoding of an OTLP ArrayValue.
+type otlpArrayValue struct {
+	Values []map[string]interface{} `json:"values"`
+}
+
+// otlpKeyValues returns the OTLP JSON encoding of kvs.
+func otlpKeyValues(kvs []KeyValue) []otlpKeyValue {
+	out := make([]otlpKeyValue, len(kvs))
+	for i, kv := range kvs {
+		out[i] = otlpKeyValue{
+			Key:   string(kv.Key),
+			Value: otlpAnyValue(reflect.ValueOf(kv.Value.AsInterface())),
+		}
+	}
+	return out
+}
+
+// otlpAnyValue returns the OTLP JSON encoding of an AnyValue holding v,
+// following the protobuf JSON mapping: 64-bit integers are encoded as
+// strings, as are non-finite floating point numbers.
+func otlpAnyValue(v reflect.Value) map[string]interface{} {
+	switch v.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"boolValue": v.Bool()}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v.Int(), 10)}
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		// OTLP integers are signed, uint64 values wrap as they
+		// do when exported.
+		return map[string]interface{}{"intValue": strconv.FormatInt(int64(v.Uint()), 10)}
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return map[string]interface{}{"doubleValue": "NaN"}
+		case math.IsInf(f, +1):
+			return map[string]interface{}{"doubleValue": "Infinity"}
+		case math.IsInf(f, -1):
+			return map[string]interface{}{"doubleValue": "-Infinity"}
+		}
+		return map[string]interface{}{"doubleValue": f}
+	case reflect.String:
+		return map[string]interface{}{"stringValue": v.String()}
+	case reflect.Array, reflect.Slice:
+		array := otlpArrayValue{Values: make([]map[string]interface{}, v.Len())}
+		for i := range array.Values {
+			array.Values[i] = otlpAnyValue(v.Index(i))
+		}
+		return map[string]interface{}{"arrayValue": array}
+	}
+	// An invalid value is encoded as an empty AnyValue.
+	return map[string]interface{}{}
+}
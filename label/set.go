@@ -146,6 +146,26 @@ func (l *Set) HasValue(k Key) bool {
 	return ok
 }
 
+// HasOnlyKeys tests whether every key defined in this set is one of
+// `keys`.  This is cheaper than filtering the set, and can be used to
+// skip a filter that would keep every label.
+func (l *Set) HasOnlyKeys(keys ...Key) bool {
+	for iter := l.Iter(); iter.Next(); {
+		k := iter.Label().Key
+		found := false
+		for _, allowed := range keys {
+			if k == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // Iter returns an iterator for visiting the labels in this set.
 func (l *Set) Iter() Iterator {
 	return Iterator{
@@ -447,9 +467,12 @@ func computeDistinctReflect(kvs []KeyValue) interface{} {
 	return at.Interface()
 }
 
-// MarshalJSON returns the JSON encoding of the `*Set`.
+// MarshalJSON returns the JSON encoding of the `*Set`, a list of
+// labels sorted by key using the structure of OTLP KeyValues, e.g.:
+//
+//	[{"key":"A","value":{"intValue":"1"}},{"key":"B","value":{"arrayValue":{"values":[{"stringValue":"C"}]}}}]
 func (l *Set) MarshalJSON() ([]byte, error) {
-	return json.Marshal(l.equivalent.iface)
+	return json.Marshal(otlpKeyValues(l.ToSlice()))
 }
 
 // Len implements `sort.Interface`.
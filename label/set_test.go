@@ -15,6 +15,8 @@
 package label_test
 
 import (
+	"encoding/json"
+	"math"
 	"regexp"
 	"testing"
 
@@ -188,3 +190,49 @@ func TestLookup(t *testing.T) {
 	value, has = set.Value("D")
 	require.False(t, has)
 }
+
+func TestHasOnlyKeys(t *testing.T) {
+	set := label.NewSet(label.Int("C", 3), label.Int("A", 1))
+
+	require.True(t, set.HasOnlyKeys("A", "C"))
+	require.True(t, set.HasOnlyKeys("D", "C", "B", "A"))
+	require.False(t, set.HasOnlyKeys("A"))
+	require.False(t, set.HasOnlyKeys())
+
+	empty := label.EmptySet()
+	require.True(t, empty.HasOnlyKeys())
+	require.True(t, empty.HasOnlyKeys("A"))
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	set := label.NewSet(
+		label.String("string", "s"),
+		label.Bool("bool", true),
+		label.Int64("int64", -1),
+		label.Uint64("uint64", 2),
+		label.Float64("float64", 1.5),
+		label.Float64("inf", math.Inf(+1)),
+		label.Array("array", []string{"a", "b"}),
+		label.Array("nested", [][]int{{1}, {2, 3}}),
+	)
+	data, err := json.Marshal(&set)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"key":"array","value":{"arrayValue":{"values":[{"stringValue":"a"},{"stringValue":"b"}]}}},
+		{"key":"bool","value":{"boolValue":true}},
+		{"key":"float64","value":{"doubleValue":1.5}},
+		{"key":"inf","value":{"doubleValue":"Infinity"}},
+		{"key":"int64","value":{"intValue":"-1"}},
+		{"key":"nested","value":{"arrayValue":{"values":[
+			{"arrayValue":{"values":[{"intValue":"1"}]}},
+			{"arrayValue":{"values":[{"intValue":"2"},{"intValue":"3"}]}}
+		]}}},
+		{"key":"string","value":{"stringValue":"s"}},
+		{"key":"uint64","value":{"intValue":"2"}}
+	]`, string(data))
+
+	empty := label.EmptySet()
+	data, err = json.Marshal(empty)
+	require.NoError(t, err)
+	require.Equal(t, `[]`, string(data))
+}
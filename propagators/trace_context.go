@@ -73,7 +73,7 @@ func (tc TraceContext) Inject(ctx context.Context, supplier propagation.HTTPSupp
 // Extract extracts a context from the supplier if it contains W3C Trace
 // Context headers.
 func (tc TraceContext) Extract(ctx context.Context, supplier propagation.HTTPSupplier) context.Context {
-	state := supplier.Get(tracestateHeader)
+	state := propagation.CombinedValue(supplier, tracestateHeader)
 	if state != "" {
 		ctx = context.WithValue(ctx, tracestateKey, state)
 	}
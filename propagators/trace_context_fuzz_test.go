@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package propagators_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/propagators"
+)
+
+// spanContextSpan is a non-recording Span with a fixed SpanContext.
+type spanContextSpan struct {
+	trace.Span
+	sc trace.SpanContext
+}
+
+func (s spanContextSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+func FuzzTraceContextExtract(f *testing.F) {
+	f.Add("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "foo=bar")
+	f.Add("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "")
+	f.Add("02-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09-XYZxsf09", "a=1,b=2")
+	f.Add("ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "")
+	f.Add("00-00000000000000000000000000000000-0000000000000000-01", "")
+
+	prop := propagators.TraceContext{}
+	f.Fuzz(func(t *testing.T, traceparent, tracestate string) {
+		req := &http.Request{Header: http.Header{}}
+		req.Header.Set("traceparent", traceparent)
+		req.Header.Set("tracestate", tracestate)
+
+		ctx := prop.Extract(context.Background(), req.Header)
+		sc := trace.RemoteSpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return
+		}
+
+		// A valid extracted SpanContext survives a round trip.
+		span := spanContextSpan{Span: trace.SpanFromContext(ctx), sc: sc}
+		out := &http.Request{Header: http.Header{}}
+		prop.Inject(trace.ContextWithSpan(ctx, span), out.Header)
+		got := trace.RemoteSpanContextFromContext(prop.Extract(context.Background(), out.Header))
+		if got != sc {
+			t.Errorf("round trip of %q: got %v, want %v", traceparent, got, sc)
+		}
+	})
+}
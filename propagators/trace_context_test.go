@@ -296,3 +296,19 @@ func TestTraceStatePropagation(t *testing.T) {
 		t.Errorf("Propagate tracestate: -got +want %s", diff)
 	}
 }
+
+func TestTraceStateMultipleHeaders(t *testing.T) {
+	props := propagation.New(propagation.WithInjectors(propagators.TraceContext{}), propagation.WithExtractors(propagators.TraceContext{}))
+
+	inReq, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	inReq.Header.Add("tracestate", "a=1")
+	inReq.Header.Add("tracestate", "b=2")
+	ctx := propagation.ExtractHTTP(context.Background(), props, inReq.Header)
+
+	outReq, _ := http.NewRequest(http.MethodGet, "http://www.example.com", nil)
+	propagation.InjectHTTP(ctx, props, outReq.Header)
+
+	if diff := cmp.Diff(outReq.Header.Values("tracestate"), []string{"a=1,b=2"}); diff != "" {
+		t.Errorf("Propagate tracestate: -got +want %s", diff)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
 )
 
 // These interfaces describe the various ways to access state from an
@@ -108,6 +109,24 @@ type (
 		Count() (int64, error)
 	}
 
+	// Exemplar is a measurement sampled by an Aggregator together
+	// with the span that was active in the context of the
+	// measurement, linking metric data to traces.
+	Exemplar struct {
+		Value   metric.Number
+		Time    time.Time
+		TraceID trace.ID
+		SpanID  trace.SpanID
+	}
+
+	// Exemplars returns the Exemplars sampled by an Aggregator.
+	// Only measurements made in the context of a sampled span are
+	// sampled.
+	Exemplars interface {
+		Aggregation
+		Exemplars() ([]Exemplar, error)
+	}
+
 	// Distribution supports the Min, Max, Sum, Count, and Quantile
 	// interfaces.
 	Distribution interface {
@@ -150,6 +169,7 @@ var (
 	ErrInvalidQuantile  = fmt.Errorf("the requested quantile is out of range")
 	ErrNegativeInput    = fmt.Errorf("negative value is out of range for this instrument")
 	ErrNaNInput         = fmt.Errorf("NaN value is an invalid input")
+	ErrInfInput         = fmt.Errorf("Inf value is an invalid input")
 	ErrInconsistentType = fmt.Errorf("inconsistent aggregator types")
 	ErrNoSubtraction    = fmt.Errorf("aggregator does not subtract")
 
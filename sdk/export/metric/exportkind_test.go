@@ -62,3 +62,18 @@ func TestExportKindMemoryRequired(t *testing.T) {
 		require.False(t, PassThroughExporter.MemoryRequired(kind))
 	}
 }
+
+func TestInstrumentExportKindSelector(t *testing.T) {
+	akind := aggregation.SumKind
+	kinds := map[string]ExportKind{"delta": DeltaExporter}
+	selector := NewInstrumentExportKindSelector(CumulativeExporter, kinds)
+
+	// Modifying the map after construction has no effect.
+	kinds["other"] = DeltaExporter
+
+	delta := metric.NewDescriptor("delta", metric.CounterKind, metric.Int64NumberKind)
+	other := metric.NewDescriptor("other", metric.CounterKind, metric.Int64NumberKind)
+
+	require.Equal(t, DeltaExporter, selector.ExportKindFor(&delta, akind))
+	require.Equal(t, CumulativeExporter, selector.ExportKindFor(&other, akind))
+}
@@ -18,6 +18,7 @@ package metric // import "go.opentelemetry.io/otel/sdk/export/metric"
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -194,6 +195,11 @@ type Subtractor interface {
 	Subtract(operand, result Aggregator, descriptor *metric.Descriptor) error
 }
 
+// ErrExportTimeout is returned, possibly wrapped, when an export does
+// not complete before the deadline of its context.  Retry logic can use
+// errors.Is to distinguish timeouts from permanent failures.
+var ErrExportTimeout = errors.New("metric export timed out")
+
 // Exporter handles presentation of the checkpoint of aggregate
 // metrics.  This is the final stage of a metrics export pipeline,
 // where metric data are formatted for a specific system.
@@ -202,7 +208,8 @@ type Exporter interface {
 	// pass in the SDK.
 	//
 	// The Context comes from the controller that initiated
-	// collection.
+	// collection.  If its deadline is exceeded, the controller
+	// reports the returned error wrapped with ErrExportTimeout.
 	//
 	// The CheckpointSet interface refers to the Processor that just
 	// completed collection.
@@ -383,6 +390,37 @@ func (kind ExportKind) ExportKindFor(_ *metric.Descriptor, _ aggregation.Kind) E
 	return kind
 }
 
+// NewInstrumentExportKindSelector returns an ExportKindSelector that
+// returns the ExportKind configured in kinds for instruments with a
+// matching name, and otherwise defers to defaultSelector.  This allows
+// an exporter to export most instruments using one ExportKind, e.g.,
+// cumulative, while exporting specific high-cardinality instruments
+// using another, e.g., delta, to reduce the memory required to export
+// them.
+func NewInstrumentExportKindSelector(defaultSelector ExportKindSelector, kinds map[string]ExportKind) ExportKindSelector {
+	copied := make(map[string]ExportKind, len(kinds))
+	for name, kind := range kinds {
+		copied[name] = kind
+	}
+	return instrumentExportKindSelector{
+		defaultSelector: defaultSelector,
+		kinds:           copied,
+	}
+}
+
+type instrumentExportKindSelector struct {
+	defaultSelector ExportKindSelector
+	kinds           map[string]ExportKind
+}
+
+// ExportKindFor implements ExportKindSelector.
+func (s instrumentExportKindSelector) ExportKindFor(descriptor *metric.Descriptor, aggregatorKind aggregation.Kind) ExportKind {
+	if kind, ok := s.kinds[descriptor.Name()]; ok {
+		return kind
+	}
+	return s.defaultSelector.ExportKindFor(descriptor, aggregatorKind)
+}
+
 // MemoryRequired returns whether an exporter of this kind requires
 // memory to export correctly.
 func (kind ExportKind) MemoryRequired(mkind metric.Kind) bool {
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/export/metric"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// ErrInvalidRecord is returned, wrapped with a description of the
+// problem, by ValidateCheckpointSet for a Record that violates the
+// metric data model.
+var ErrInvalidRecord = errors.New("invalid metric record")
+
+type validateKey struct {
+	name     string
+	labels   label.Distinct
+	resource label.Distinct
+}
+
+// ValidateCheckpointSet checks that each Record of the CheckpointSet,
+// computed using the passed ExportKindSelector, satisfies the
+// invariants of the metric data model:
+//
+//   - a Histogram has one more bucket count than boundaries,
+//   - the sum of a monotonic instrument is not negative,
+//   - a Record's start time is not after its end time, and
+//   - no two Records have the same instrument name, labels and
+//     resource.
+//
+// The first violation found is returned wrapped with ErrInvalidRecord.
+// This is intended for use by Exporter authors and in tests; it should
+// not be called on the export path of a production pipeline.
+//
+// ValidateCheckpointSet does not lock cs: the caller must hold its
+// lock, as Exporter.Export does when it is called by a controller.
+func ValidateCheckpointSet(cs CheckpointSet, kinds ExportKindSelector) error {
+	seen := map[validateKey]struct{}{}
+	return cs.ForEach(kinds, func(r Record) error {
+		desc := r.Descriptor()
+		key := validateKey{
+			name:     desc.Name(),
+			labels:   r.Labels().Equivalent(),
+			resource: r.Resource().Equivalent(),
+		}
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: %s: duplicate label set %s", ErrInvalidRecord, desc.Name(), r.Labels().Encoded(label.DefaultEncoder()))
+		}
+		seen[key] = struct{}{}
+
+		if !r.EndTime().IsZero() && r.StartTime().After(r.EndTime()) {
+			return fmt.Errorf("%w: %s: start time %v is after end time %v", ErrInvalidRecord, desc.Name(), r.StartTime(), r.EndTime())
+		}
+		return validateAggregation(r)
+	})
+}
+
+func validateAggregation(r Record) error {
+	desc := r.Descriptor()
+	agg := r.Aggregation()
+	if h, ok := agg.(aggregation.Histogram); ok {
+		buckets, err := h.Histogram()
+		if err != nil {
+			return err
+		}
+		if len(buckets.Counts) != len(buckets.Boundaries)+1 {
+			return fmt.Errorf("%w: %s: %d bucket counts for %d boundaries", ErrInvalidRecord, desc.Name(), len(buckets.Counts), len(buckets.Boundaries))
+		}
+	}
+	if s, ok := agg.(aggregation.Sum); ok && desc.MetricKind().Monotonic() {
+		sum, err := s.Sum()
+		if err != nil {
+			return err
+		}
+		if sum.IsNegative(desc.NumberKind()) {
+			return fmt.Errorf("%w: %s: negative sum %s for monotonic instrument", ErrInvalidRecord, desc.Name(), sum.Emit(desc.NumberKind()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+type testSum metric.Number
+
+func (testSum) Kind() aggregation.Kind        { return aggregation.SumKind }
+func (s testSum) Sum() (metric.Number, error) { return metric.Number(s), nil }
+
+type testHistogram aggregation.Buckets
+
+func (testHistogram) Kind() aggregation.Kind      { return aggregation.HistogramKind }
+func (testHistogram) Sum() (metric.Number, error) { return 0, nil }
+func (h testHistogram) Histogram() (aggregation.Buckets, error) {
+	return aggregation.Buckets(h), nil
+}
+
+type testCheckpointSet struct {
+	sync.RWMutex
+	records []export.Record
+}
+
+func (t *testCheckpointSet) ForEach(_ export.ExportKindSelector, f func(export.Record) error) error {
+	for _, r := range t.records {
+		if err := f(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestValidateCheckpointSet(t *testing.T) {
+	counter := metric.NewDescriptor("counter", metric.CounterKind, metric.Int64NumberKind)
+	upDown := metric.NewDescriptor("updown", metric.UpDownCounterKind, metric.Int64NumberKind)
+	recorder := metric.NewDescriptor("recorder", metric.ValueRecorderKind, metric.Float64NumberKind)
+
+	labelsA := label.NewSet(label.String("A", "a"))
+	labelsB := label.NewSet(label.String("B", "b"))
+	start := time.Unix(100, 0)
+	end := start.Add(time.Second)
+
+	record := func(desc *metric.Descriptor, labels *label.Set, agg aggregation.Aggregation, start, end time.Time) export.Record {
+		return export.NewRecord(desc, labels, nil, agg, start, end)
+	}
+
+	tests := []struct {
+		name    string
+		records []export.Record
+		valid   bool
+	}{
+		{
+			name: "valid",
+			records: []export.Record{
+				record(&counter, &labelsA, testSum(metric.NewInt64Number(1)), start, end),
+				record(&counter, &labelsB, testSum(metric.NewInt64Number(2)), start, end),
+				record(&upDown, &labelsA, testSum(metric.NewInt64Number(-1)), start, end),
+				record(&recorder, &labelsA, testHistogram{Boundaries: []float64{1}, Counts: []float64{0, 1}}, start, end),
+			},
+			valid: true,
+		},
+		{
+			name: "duplicate labels",
+			records: []export.Record{
+				record(&counter, &labelsA, testSum(metric.NewInt64Number(1)), start, end),
+				record(&counter, &labelsA, testSum(metric.NewInt64Number(2)), start, end),
+			},
+		},
+		{
+			name: "negative monotonic sum",
+			records: []export.Record{
+				record(&counter, &labelsA, testSum(metric.NewInt64Number(-1)), start, end),
+			},
+		},
+		{
+			name: "start after end",
+			records: []export.Record{
+				record(&counter, &labelsA, testSum(metric.NewInt64Number(1)), end, start),
+			},
+		},
+		{
+			name: "histogram counts",
+			records: []export.Record{
+				record(&recorder, &labelsA, testHistogram{Boundaries: []float64{1, 2}, Counts: []float64{0, 1}}, start, end),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The set is locked as when a controller calls
+			// Exporter.Export.
+			cs := &testCheckpointSet{records: test.records}
+			cs.Lock()
+			defer cs.Unlock()
+
+			err := export.ValidateCheckpointSet(cs, export.CumulativeExporter)
+			if test.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, errors.Is(err, export.ErrInvalidRecord), "%v", err)
+		})
+	}
+}
@@ -16,6 +16,7 @@ package trace // import "go.opentelemetry.io/otel/sdk/export/trace"
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -26,6 +27,11 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
+// ErrExportTimeout is returned, possibly wrapped, when an export does
+// not complete before the deadline of its context.  Retry logic can use
+// errors.Is to distinguish timeouts from permanent failures.
+var ErrExportTimeout = errors.New("span export timed out")
+
 // SpanExporter handles the delivery of SpanData to external receivers. This is
 // the final component in the trace export pipeline.
 type SpanExporter interface {
@@ -40,6 +46,9 @@ type SpanExporter interface {
 	// calls this function will not implement any retry logic. All errors
 	// returned by this function are considered unrecoverable and will be
 	// reported to a configured error Handler.
+	//
+	// If the passed context has a deadline that is exceeded, the SDK
+	// reports the returned error wrapped with ErrExportTimeout.
 	ExportSpans(ctx context.Context, spanData []*SpanData) error
 	// Shutdown notifies the exporter of a pending halt to operations. The
 	// exporter is expected to preform any cleanup or synchronization it
@@ -60,7 +69,7 @@ type SpanData struct {
 	EndTime                  time.Time
 	Attributes               []label.KeyValue
 	MessageEvents            []Event
-	Links                    []apitrace.Link
+	Links                    []Link
 	StatusCode               codes.Code
 	StatusMessage            string
 	HasRemoteParent          bool
@@ -68,6 +77,11 @@ type SpanData struct {
 	DroppedMessageEventCount int
 	DroppedLinkCount         int
 
+	// ParentLinkCount holds the number of Links, at the end of Links,
+	// that were added for the span context ignored by WithNewRoot, as
+	// opposed to links passed with WithLinks.
+	ParentLinkCount int
+
 	// ChildSpanCount holds the number of child span created for this span.
 	ChildSpanCount int
 
@@ -79,6 +93,15 @@ type SpanData struct {
 	InstrumentationLibrary instrumentation.Library
 }
 
+// Link is a link of a span to another span, as recorded by the SDK.
+type Link struct {
+	apitrace.Link
+
+	// DroppedAttributeCount is the number of attributes of the link
+	// dropped because of the MaxAttributesPerLink limit.
+	DroppedAttributeCount int
+}
+
 // Event is thing that happened during a Span's lifetime.
 type Event struct {
 	// Name is the name of this event
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// clockCheckInterval is the monotonic time after which Now
+	// compares the SDK clock with the wall clock of the system.
+	clockCheckInterval = time.Second
+
+	// maxClockDrift is the difference between the SDK clock and the
+	// wall clock above which Now re-anchors the SDK clock to the
+	// wall clock.
+	maxClockDrift = 10 * time.Millisecond
+)
+
+// clockAnchor is a wall clock time together with a reading of the
+// monotonic clock taken at that time.
+type clockAnchor struct {
+	wall time.Time
+	mono time.Time
+	// fixed disables the comparison with the wall clock, for
+	// anchors set by SetClockAnchor.
+	fixed bool
+}
+
+var anchor atomic.Value // *clockAnchor
+
+func init() {
+	now := time.Now()
+	anchor.Store(&clockAnchor{wall: now.Round(0), mono: now})
+}
+
+// Now returns the current time of the SDK clock: the wall clock time
+// of its anchor plus the time elapsed since then, measured with the
+// monotonic clock.
+//
+// The SDK uses Now for all timestamps it generates.  As a result, the
+// duration between two timestamps close in time, e.g., the start and
+// end time of a span, is the actual elapsed time even if the wall
+// clock of the system is stepped, e.g., by NTP, in between.  Now
+// compares the SDK clock with the wall clock at most once per
+// clockCheckInterval and re-anchors it to the wall clock if they
+// differ by more than maxClockDrift, e.g., because the wall clock was
+// synchronized after the process started, or because the monotonic
+// clock stopped while the system was suspended.
+// See https://golang.org/pkg/time/#hdr-Monotonic_Clocks
+func Now() time.Time {
+	a := anchor.Load().(*clockAnchor)
+	now := time.Now()
+	elapsed := now.Sub(a.mono)
+	t := a.wall.Add(elapsed)
+	if a.fixed || elapsed < clockCheckInterval {
+		return t
+	}
+
+	// Re-anchor at t, so that the next comparison happens after
+	// another clockCheckInterval, unless the clocks drifted apart.
+	// Concurrent calls may each store an anchor; they all read the
+	// clocks after a was stored, so any of them is valid.
+	wall := now.Round(0)
+	if drift := wall.Sub(t); drift > maxClockDrift || drift < -maxClockDrift {
+		t = wall
+	}
+	anchor.Store(&clockAnchor{wall: t, mono: now})
+	return t
+}
+
+// ClockAnchor returns the wall clock time at which the SDK clock was
+// last anchored.
+func ClockAnchor() time.Time {
+	return anchor.Load().(*clockAnchor).wall
+}
+
+// SetClockAnchor restarts the SDK clock at the wall clock time t and
+// returns a function that restores the previous anchor.  The SDK
+// clock is not re-anchored to the wall clock until then.  It is meant
+// for tests that need deterministic timestamps.
+func SetClockAnchor(t time.Time) (restore func()) {
+	prev := anchor.Load().(*clockAnchor)
+	anchor.Store(&clockAnchor{wall: t, mono: time.Now(), fixed: true})
+	return func() { anchor.Store(prev) }
+}
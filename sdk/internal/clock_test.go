@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockAnchor(t *testing.T) {
+	prev := ClockAnchor()
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	restore := SetClockAnchor(start)
+
+	if got := ClockAnchor(); !got.Equal(start) {
+		t.Errorf("ClockAnchor() = %v, want %v", got, start)
+	}
+	before := Now()
+	time.Sleep(10 * time.Millisecond)
+	after := Now()
+	if before.Before(start) || before.Sub(start) > time.Second {
+		t.Errorf("Now() = %v, want shortly after %v", before, start)
+	}
+	if d := after.Sub(before); d < 10*time.Millisecond {
+		t.Errorf("elapsed %v, want at least 10ms", d)
+	}
+
+	restore()
+	if got := ClockAnchor(); !got.Equal(prev) {
+		t.Errorf("restored ClockAnchor() = %v, want %v", got, prev)
+	}
+	if now := Now(); time.Since(now) > time.Second || time.Until(now) > time.Second {
+		t.Errorf("Now() = %v, want close to the wall clock", now)
+	}
+}
+
+func TestNowReanchorsToWallClock(t *testing.T) {
+	prev := anchor.Load().(*clockAnchor)
+	defer anchor.Store(prev)
+
+	// An anchor taken an hour before the wall clock was stepped,
+	// e.g., by NTP, or before the system was suspended.
+	now := time.Now()
+	anchor.Store(&clockAnchor{
+		wall: now.Round(0).Add(-time.Hour),
+		mono: now.Add(-clockCheckInterval),
+	})
+	if got := Now(); time.Since(got) > time.Second || time.Until(got) > time.Second {
+		t.Errorf("Now() = %v, want close to the wall clock", got)
+	}
+	if got := ClockAnchor(); time.Since(got) > time.Second {
+		t.Errorf("ClockAnchor() = %v, want re-anchored to the wall clock", got)
+	}
+}
+
+func TestNowKeepsSmallDrift(t *testing.T) {
+	prev := anchor.Load().(*clockAnchor)
+	defer anchor.Store(prev)
+
+	// A drift below maxClockDrift is kept, so that timestamps do
+	// not jump.
+	drift := maxClockDrift / 2
+	now := time.Now()
+	anchor.Store(&clockAnchor{
+		wall: now.Round(0).Add(-clockCheckInterval + drift),
+		mono: now.Add(-clockCheckInterval),
+	})
+	got := Now()
+	if d := got.Sub(time.Now().Round(0)); d < drift-maxClockDrift/4 || d > drift {
+		t.Errorf("Now() is %v from the wall clock, want about %v", d, drift)
+	}
+	if elapsed := time.Since(anchor.Load().(*clockAnchor).mono); elapsed >= clockCheckInterval {
+		t.Errorf("anchor is %v old, want refreshed", elapsed)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/api/global"
+	opentelemetry "go.opentelemetry.io/otel/sdk"
+)
+
+// HandlePanic reports recovered, a value recovered from a panic of the
+// user code described by what, to the global error handler along with
+// the stack of the panic.  It must be called by the deferred function
+// that recovered the panic.  If panic recovery is disabled with
+// opentelemetry.SetPanicRecovery, it panics again with recovered
+// instead.
+func HandlePanic(recovered interface{}, what string) {
+	global.Handle(PanicError(recovered, what))
+}
+
+// PanicError returns an error describing recovered, a value recovered
+// from a panic of the user code described by what, along with the
+// stack of the panic.  It must be called by the deferred function that
+// recovered the panic, for the caller to return the error instead of
+// reporting it.  If panic recovery is disabled with
+// opentelemetry.SetPanicRecovery, it panics again with recovered
+// instead.
+func PanicError(recovered interface{}, what string) error {
+	if !opentelemetry.PanicRecovery() {
+		panic(recovered)
+	}
+	return fmt.Errorf("recovered panic of %s: %v\n%s", what, recovered, debug.Stack())
+}
+
+// RecoverPanic recovers a panic of the user code described by what and
+// handles it with HandlePanic.  It must be deferred directly:
+//
+//	defer internal.RecoverPanic("SpanProcessor.OnEnd")
+func RecoverPanic(what string) {
+	if r := recover(); r != nil {
+		HandlePanic(r, what)
+	}
+}
@@ -31,19 +31,31 @@ func NewInconsistentAggregatorError(a1, a2 export.Aggregator) error {
 }
 
 // RangeTest is a commmon routine for testing for valid input values.
-// This rejects NaN values.  This rejects negative values when the
+// This rejects NaN and Inf values.  This rejects negative values when the
 // metric instrument does not support negative values, including
 // monotonic counter metrics and absolute ValueRecorder metrics.
 func RangeTest(number metric.Number, descriptor *metric.Descriptor) error {
 	numberKind := descriptor.NumberKind()
 
-	if numberKind == metric.Float64NumberKind && math.IsNaN(number.AsFloat64()) {
-		return aggregation.ErrNaNInput
+	if numberKind == metric.Float64NumberKind {
+		if math.IsNaN(number.AsFloat64()) {
+			return aggregation.ErrNaNInput
+		}
+		if math.IsInf(number.AsFloat64(), 0) {
+			return aggregation.ErrInfInput
+		}
 	}
 
+	return SignTest(number, descriptor)
+}
+
+// SignTest rejects negative values when the metric instrument does
+// not support negative values.  Unlike RangeTest, it accepts NaN and
+// Inf values.
+func SignTest(number metric.Number, descriptor *metric.Descriptor) error {
 	switch descriptor.MetricKind() {
 	case metric.CounterKind, metric.SumObserverKind:
-		if number.IsNegative(numberKind) {
+		if number.IsNegative(descriptor.NumberKind()) {
 			return aggregation.ErrNegativeInput
 		}
 	}
@@ -100,3 +100,26 @@ func TestNaNTest(t *testing.T) {
 		})
 	}
 }
+
+func TestInfTest(t *testing.T) {
+	for _, mkind := range []metric.Kind{
+		metric.CounterKind,
+		metric.ValueRecorderKind,
+		metric.ValueObserverKind,
+	} {
+		desc := metric.NewDescriptor("name", mkind, metric.Float64NumberKind)
+		for _, inf := range []float64{math.Inf(+1), math.Inf(-1)} {
+			require.Equal(t, aggregation.ErrInfInput, aggregator.RangeTest(metric.NewFloat64Number(inf), &desc))
+		}
+	}
+}
+
+func TestSignTest(t *testing.T) {
+	desc := metric.NewDescriptor("name", metric.CounterKind, metric.Float64NumberKind)
+	require.Nil(t, aggregator.SignTest(metric.NewFloat64Number(math.Inf(+1)), &desc))
+	require.Nil(t, aggregator.SignTest(metric.NewFloat64Number(math.NaN()), &desc))
+	require.Equal(t, aggregation.ErrNegativeInput, aggregator.SignTest(metric.NewFloat64Number(math.Inf(-1)), &desc))
+
+	desc = metric.NewDescriptor("name", metric.ValueRecorderKind, metric.Float64NumberKind)
+	require.Nil(t, aggregator.SignTest(metric.NewFloat64Number(math.Inf(-1)), &desc))
+}
@@ -16,15 +16,21 @@ package aggregatortest
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"os"
 	"sort"
+	"sync"
 	"testing"
 	"unsafe"
 
+	"github.com/stretchr/testify/require"
+
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
 	ottest "go.opentelemetry.io/otel/internal/testing"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 )
 
@@ -166,8 +172,153 @@ func CheckedUpdate(t *testing.T, agg export.Aggregator, number metric.Number, de
 	}
 }
 
+// sampledSpan is a span with a sampled SpanContext.
+type sampledSpan struct {
+	trace.Span
+	sc trace.SpanContext
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext { return s.sc }
+
+// ContextWithSampledSpan returns a context containing a sampled span
+// with the given IDs, for testing exemplars.
+func ContextWithSampledSpan(ctx context.Context, traceID trace.ID, spanID trace.SpanID) context.Context {
+	return trace.ContextWithSpan(ctx, sampledSpan{
+		Span: trace.SpanFromContext(context.Background()),
+		sc: trace.SpanContext{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		},
+	})
+}
+
 func CheckedMerge(t *testing.T, aggInto, aggFrom export.Aggregator, descriptor *metric.Descriptor) {
 	if err := aggInto.Merge(aggFrom, descriptor); err != nil {
 		t.Error("Unexpected Merge failure", err)
 	}
 }
+
+// otherAggregator is an Aggregator of a type no implementation
+// under test is consistent with.
+type otherAggregator struct{}
+
+func (otherAggregator) Aggregation() aggregation.Aggregation { return otherAggregator{} }
+func (otherAggregator) Kind() aggregation.Kind               { return aggregation.Kind("Other") }
+func (otherAggregator) Update(context.Context, metric.Number, *metric.Descriptor) error {
+	return nil
+}
+func (otherAggregator) SynchronizedMove(export.Aggregator, *metric.Descriptor) error { return nil }
+func (otherAggregator) Merge(export.Aggregator, *metric.Descriptor) error            { return nil }
+
+// SynchronizedMoveTest verifies the collection cycle semantics of the
+// Aggregators returned by nf for instruments of kind mkind: after
+// SynchronizedMove the destination holds the updates made before the
+// move and the source is reset, and moving into an Aggregator of an
+// inconsistent type fails with aggregation.ErrInconsistentType.
+//
+// This is intended for implementers of custom Aggregators.
+func SynchronizedMoveTest(t *testing.T, mkind metric.Kind, nf func(*metric.Descriptor) export.Aggregator) {
+	RunProfiles(t, func(t *testing.T, profile Profile) {
+		descriptor := NewAggregatorTest(mkind, profile.NumberKind)
+		agg, ckpt := nf(descriptor), nf(descriptor)
+
+		one := metric.NewInt64Number(1)
+		if profile.NumberKind == metric.Float64NumberKind {
+			one = metric.NewFloat64Number(1)
+		}
+		for i := 0; i < 10; i++ {
+			CheckedUpdate(t, agg, one, descriptor)
+		}
+
+		require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+		checkTotals(t, ckpt, descriptor, 10)
+
+		// The source was reset by the move.
+		require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+		checkTotals(t, ckpt, descriptor, 0)
+
+		err := agg.SynchronizedMove(otherAggregator{}, descriptor)
+		require.True(t, errors.Is(err, aggregation.ErrInconsistentType), "%v", err)
+	})
+}
+
+// ConcurrentUpdateTest verifies that the Aggregators returned by nf
+// for instruments of kind mkind do not lose updates made concurrently
+// with SynchronizedMove, as the SDK does when collecting while
+// instruments are in use.
+//
+// This is intended for implementers of custom Aggregators.
+func ConcurrentUpdateTest(t *testing.T, mkind metric.Kind, nf func(*metric.Descriptor) export.Aggregator) {
+	const (
+		goroutines = 10
+		updates    = 1000
+	)
+	RunProfiles(t, func(t *testing.T, profile Profile) {
+		descriptor := NewAggregatorTest(mkind, profile.NumberKind)
+		agg, total := nf(descriptor), nf(descriptor)
+
+		one := metric.NewInt64Number(1)
+		if profile.NumberKind == metric.Float64NumberKind {
+			one = metric.NewFloat64Number(1)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for i := 0; i < updates; i++ {
+					CheckedUpdate(t, agg, one, descriptor)
+				}
+			}()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+
+		collect := func() {
+			ckpt := nf(descriptor)
+			require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+			CheckedMerge(t, total, ckpt, descriptor)
+		}
+		for running := true; running; {
+			select {
+			case <-done:
+				running = false
+			default:
+			}
+			collect()
+		}
+
+		checkTotals(t, total, descriptor, goroutines*updates)
+	})
+}
+
+// checkTotals verifies that agg aggregated count updates of 1 using
+// the aggregation interfaces it implements.
+func checkTotals(t *testing.T, agg export.Aggregator, descriptor *metric.Descriptor, count int64) {
+	kind := descriptor.NumberKind()
+	if c, ok := agg.Aggregation().(aggregation.Count); ok {
+		got, err := c.Count()
+		require.NoError(t, err)
+		require.Equal(t, count, got, "count")
+	}
+	if s, ok := agg.Aggregation().(aggregation.Sum); ok {
+		got, err := s.Sum()
+		require.NoError(t, err)
+		require.InEpsilon(t, float64(count)+1, got.CoerceToFloat64(kind)+1, 1e-9, "sum")
+	}
+	if lv, ok := agg.Aggregation().(aggregation.LastValue); ok {
+		got, _, err := lv.LastValue()
+		if count == 0 {
+			require.True(t, errors.Is(err, aggregation.ErrNoData), "%v", err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, 1.0, got.CoerceToFloat64(kind), "last value")
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 )
@@ -328,3 +329,11 @@ func TestArrayFloat64(t *testing.T) {
 		require.Equal(t, all.Points()[i], po[i], "Wrong point at position %d", i)
 	}
 }
+
+func TestArrayConformance(t *testing.T) {
+	nf := func(_ *metric.Descriptor) export.Aggregator {
+		return &New(1)[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.ValueRecorderKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.ValueRecorderKind, nf)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 )
@@ -208,3 +209,11 @@ func TestDDSketchMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestDDSketchConformance(t *testing.T) {
+	nf := func(desc *metric.Descriptor) export.Aggregator {
+		return &New(1, desc, NewDefaultConfig())[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.ValueRecorderKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.ValueRecorderKind, nf)
+}
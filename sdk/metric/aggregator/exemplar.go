@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregator // import "go.opentelemetry.io/otel/sdk/metric/aggregator"
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/internal"
+)
+
+// ReservoirSize is the number of exemplars kept by a Reservoir.
+const ReservoirSize = 4
+
+// NewExemplar returns an Exemplar of number if ctx contains a sampled
+// span, and false otherwise.
+func NewExemplar(ctx context.Context, number metric.Number) (aggregation.Exemplar, bool) {
+	sc := trace.SpanFromContext(ctx).SpanContext()
+	if !sc.IsSampled() {
+		return aggregation.Exemplar{}, false
+	}
+	return aggregation.Exemplar{
+		Value:   number,
+		Time:    internal.Now(),
+		TraceID: sc.TraceID,
+		SpanID:  sc.SpanID,
+	}, true
+}
+
+// Reservoir samples up to ReservoirSize exemplars uniformly among the
+// exemplars offered to it, using reservoir sampling.  The zero value
+// is an empty Reservoir ready to use.
+//
+// Offer does not lock unless the exemplar is sampled: the sampling
+// decision is made by hashing the span ID of the exemplar together
+// with the number of exemplars offered so far, rather than by sharing
+// a random number generator.
+type Reservoir struct {
+	// offered is the number of exemplars offered since the last
+	// MoveTo.  It must be the first field, for 64-bit alignment.
+	offered int64
+
+	lock      sync.Mutex
+	exemplars []aggregation.Exemplar
+
+	// _ pads the struct, of two 8-byte fields and a 3-word slice,
+	// to a multiple of 8 bytes on both 32- and 64-bit platforms,
+	// so that the fields of the elements of an array of structs
+	// embedding a Reservoir, e.g., sum.New(n), stay 64-bit aligned.
+	_ uintptr
+}
+
+// Offer samples e.
+func (r *Reservoir) Offer(e aggregation.Exemplar) {
+	n := atomic.AddInt64(&r.offered, 1)
+	i := n - 1
+	if n > ReservoirSize {
+		if i = sampleIndex(e, n); i >= ReservoirSize {
+			return
+		}
+	}
+	r.lock.Lock()
+	r.store(e, i)
+	r.lock.Unlock()
+}
+
+// offer samples e as one of n offered exemplars it stands for.
+func (r *Reservoir) offer(e aggregation.Exemplar, n int64) {
+	r.offered += n
+	if len(r.exemplars) < ReservoirSize {
+		r.exemplars = append(r.exemplars, e)
+		return
+	}
+	if i := sampleIndex(e, r.offered); i < ReservoirSize {
+		r.exemplars[i] = e
+	}
+}
+
+// store places e in slot i, or appends it while the reservoir is not
+// full.  r.lock must be held.
+func (r *Reservoir) store(e aggregation.Exemplar, i int64) {
+	if len(r.exemplars) < ReservoirSize {
+		r.exemplars = append(r.exemplars, e)
+		return
+	}
+	r.exemplars[i] = e
+}
+
+// sampleIndex returns a pseudo-random index in [0, n) for e, the n-th
+// exemplar offered.  Span IDs are random, so mixing the span ID with n
+// (using the SplitMix64 finalizer) yields indices that are uniformly
+// distributed, also when one span offers many exemplars.
+func sampleIndex(e aggregation.Exemplar, n int64) int64 {
+	x := binary.BigEndian.Uint64(e.SpanID[:]) ^ uint64(n)*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return int64(x % uint64(n))
+}
+
+// Exemplars returns the sampled exemplars.  It must not be called
+// concurrently with other methods.
+func (r *Reservoir) Exemplars() []aggregation.Exemplar {
+	return r.exemplars
+}
+
+// Reset empties the reservoir.  It must not be called concurrently
+// with other methods.
+func (r *Reservoir) Reset() {
+	r.offered, r.exemplars = 0, nil
+}
+
+// MoveTo moves the sampled exemplars into o, leaving r empty.
+func (r *Reservoir) MoveTo(o *Reservoir) {
+	r.lock.Lock()
+	o.offered = atomic.SwapInt64(&r.offered, 0)
+	o.exemplars, r.exemplars = r.exemplars, nil
+	r.lock.Unlock()
+}
+
+// CopyTo replaces the exemplars of o with a copy of those of r.
+func (r *Reservoir) CopyTo(o *Reservoir) {
+	o.offered = r.offered
+	o.exemplars = append(o.exemplars[:0:0], r.exemplars...)
+}
+
+// Merge samples the exemplars of o into r, as if every exemplar
+// offered to o had been offered to r.
+func (r *Reservoir) Merge(o *Reservoir) {
+	if len(o.exemplars) == 0 {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// Each exemplar sampled by o stands for an equal share of the
+	// exemplars offered to o.
+	n := o.offered / int64(len(o.exemplars))
+	for _, e := range o.exemplars {
+		r.offer(e, n)
+	}
+}
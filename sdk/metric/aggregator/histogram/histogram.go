@@ -47,6 +47,11 @@ type (
 		bucketCounts []float64
 		sum          metric.Number
 		count        int64
+
+		// exemplars holds the last exemplar of each bucket, or
+		// is nil if no exemplar was recorded.  Buckets without
+		// an exemplar hold a zero Exemplar.
+		exemplars []aggregation.Exemplar
 	}
 )
 
@@ -54,18 +59,27 @@ var _ export.Aggregator = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
 var _ aggregation.Count = &Aggregator{}
 var _ aggregation.Histogram = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new aggregator for computing Histograms.
 //
 // A Histogram observe events and counts them in pre-defined buckets.
 // And also provides the total sum and count of all observations.
 //
+// The boundaries recommended for the instrument with
+// metric.WithHistogramBoundaries, if any, take precedence over
+// boundaries.
+//
 // Note that this aggregator maintains each value using independent
 // atomic operations, which introduces the possibility that
 // checkpoints are inconsistent.
 func New(cnt int, desc *metric.Descriptor, boundaries []float64) []Aggregator {
 	aggs := make([]Aggregator, cnt)
 
+	if b := desc.HistogramBoundaries(); b != nil {
+		boundaries = b
+	}
+
 	// Boundaries MUST be ordered otherwise the histogram could not
 	// be properly computed.
 	sortedBoundaries := make([]float64, len(boundaries))
@@ -111,6 +125,25 @@ func (c *Aggregator) Histogram() (aggregation.Buckets, error) {
 	}, nil
 }
 
+// Exemplars returns the last exemplar recorded in each bucket of the
+// checkpoint, in bucket order, skipping buckets without exemplars.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	var exemplars []aggregation.Exemplar
+	for _, e := range c.state.exemplars {
+		if !e.Time.IsZero() {
+			exemplars = append(exemplars, e)
+		}
+	}
+	return exemplars, nil
+}
+
+// ResetExemplars discards the exemplars of the checkpoint.  It is
+// called before merging into a cumulative checkpoint so that only
+// the exemplars of the latest interval are reported.
+func (c *Aggregator) ResetExemplars() {
+	c.state.exemplars = nil
+}
+
 // SynchronizedMove saves the current state into oa and resets the current state to
 // the empty set.  Since no locks are taken, there is a chance that
 // the independent Sum, Count and Bucket Count are not consistent with each
@@ -133,8 +166,10 @@ func emptyState(boundaries []float64) state {
 	}
 }
 
-// Update adds the recorded measurement to the current data set.
-func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metric.Descriptor) error {
+// Update adds the recorded measurement to the current data set.  If
+// ctx contains a sampled span, the measurement becomes the exemplar
+// of its bucket.
+func (c *Aggregator) Update(ctx context.Context, number metric.Number, desc *metric.Descriptor) error {
 	kind := desc.NumberKind()
 	asFloat := number.CoerceToFloat64(kind)
 
@@ -157,12 +192,20 @@ func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metri
 	// 256 and 512 elements, which is a relatively large histogram, so we
 	// continue to prefer linear search.
 
+	exemplar, sampled := aggregator.NewExemplar(ctx, number)
+
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.state.count++
 	c.state.sum.AddNumber(kind, number)
 	c.state.bucketCounts[bucketID]++
+	if sampled {
+		if c.state.exemplars == nil {
+			c.state.exemplars = make([]aggregation.Exemplar, len(c.state.bucketCounts))
+		}
+		c.state.exemplars[bucketID] = exemplar
+	}
 
 	return nil
 }
@@ -180,5 +223,18 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 	for i := 0; i < len(c.state.bucketCounts); i++ {
 		c.state.bucketCounts[i] += o.state.bucketCounts[i]
 	}
+
+	// The most recent exemplar of each bucket is kept.
+	for i, e := range o.state.exemplars {
+		if e.Time.IsZero() {
+			continue
+		}
+		if c.state.exemplars == nil {
+			c.state.exemplars = make([]aggregation.Exemplar, len(c.state.bucketCounts))
+		}
+		if e.Time.After(c.state.exemplars[i].Time) {
+			c.state.exemplars[i] = e
+		}
+	}
 	return nil
 }
@@ -15,6 +15,7 @@
 package histogram_test
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"sort"
@@ -23,6 +24,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
 )
@@ -248,3 +251,56 @@ func calcBuckets(points []metric.Number, profile aggregatortest.Profile) []uint6
 
 	return counts
 }
+
+func TestHistogramConformance(t *testing.T) {
+	nf := func(desc *metric.Descriptor) export.Aggregator {
+		return &histogram.New(1, desc, boundaries)[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.ValueRecorderKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.ValueRecorderKind, nf)
+}
+
+func TestHistogramExemplars(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(metric.ValueRecorderKind, metric.Int64NumberKind)
+	agg, ckpt, other, _ := new4(descriptor)
+	ctx := context.Background()
+	sampled := aggregatortest.ContextWithSampledSpan(ctx, trace.ID{1}, trace.SpanID{2})
+
+	require.NoError(t, agg.Update(sampled, metric.NewInt64Number(600), descriptor))
+	require.NoError(t, agg.Update(sampled, metric.NewInt64Number(100), descriptor))
+	require.NoError(t, agg.Update(sampled, metric.NewInt64Number(200), descriptor))
+	require.NoError(t, agg.Update(ctx, metric.NewInt64Number(900), descriptor))
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	// The last exemplar of each bucket is kept, in bucket order.
+	exemplars, err := ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2)
+	require.Equal(t, metric.NewInt64Number(200), exemplars[0].Value)
+	require.Equal(t, metric.NewInt64Number(600), exemplars[1].Value)
+	require.Equal(t, trace.ID{1}, exemplars[0].TraceID)
+	require.Equal(t, trace.SpanID{2}, exemplars[0].SpanID)
+
+	exemplars, err = agg.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars)
+
+	require.NoError(t, other.Update(sampled, metric.NewInt64Number(800), descriptor))
+	require.NoError(t, ckpt.Merge(other, descriptor))
+	exemplars, err = ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 3)
+	require.Equal(t, metric.NewInt64Number(800), exemplars[2].Value)
+}
+
+func TestHistogramDescriptorBoundaries(t *testing.T) {
+	desc := metric.NewDescriptor("histogram", metric.ValueRecorderKind, metric.Float64NumberKind,
+		metric.WithHistogramBoundaries(10, 1, 5),
+	)
+
+	agg := &histogram.New(1, &desc, boundaries)[0]
+	buckets, err := agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 5, 10}, buckets.Boundaries)
+	require.Len(t, buckets.Counts, 4)
+}
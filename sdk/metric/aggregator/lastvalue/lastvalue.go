@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/otel/api/metric"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 )
 
@@ -104,7 +105,7 @@ func (g *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor
 func (g *Aggregator) Update(_ context.Context, number metric.Number, desc *metric.Descriptor) error {
 	ngd := &lastValueData{
 		value:     number,
-		timestamp: time.Now(),
+		timestamp: internal.Now(),
 	}
 	atomic.StorePointer(&g.value, unsafe.Pointer(ngd))
 	return nil
@@ -131,3 +131,11 @@ func TestLastValueNotSet(t *testing.T) {
 
 	checkZero(t, g)
 }
+
+func TestLastValueConformance(t *testing.T) {
+	nf := func(_ *metric.Descriptor) export.Aggregator {
+		return &New(1)[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.ValueObserverKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.ValueObserverKind, nf)
+}
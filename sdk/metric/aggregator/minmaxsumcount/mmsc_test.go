@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 )
@@ -234,3 +235,11 @@ func TestMaxSumCountNotSet(t *testing.T) {
 		require.Equal(t, metric.Number(0), max)
 	})
 }
+
+func TestMinMaxSumCountConformance(t *testing.T) {
+	nf := func(desc *metric.Descriptor) export.Aggregator {
+		return &New(1, desc)[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.ValueRecorderKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.ValueRecorderKind, nf)
+}
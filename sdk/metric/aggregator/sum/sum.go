@@ -28,11 +28,16 @@ type Aggregator struct {
 	// current holds current increments to this counter record
 	// current needs to be aligned for 64-bit atomic operations.
 	value metric.Number
+
+	// exemplars samples the measurements made in the context of
+	// a sampled span.
+	exemplars aggregator.Reservoir
 }
 
 var _ export.Aggregator = &Aggregator{}
 var _ export.Subtractor = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new counter aggregator implemented by atomic
 // operations.  This aggregator implements the aggregation.Sum
@@ -57,6 +62,19 @@ func (c *Aggregator) Sum() (metric.Number, error) {
 	return c.value, nil
 }
 
+// Exemplars returns the exemplars sampled in the last checkpoint.
+// This will never return an error.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	return c.exemplars.Exemplars(), nil
+}
+
+// ResetExemplars discards the exemplars of the checkpoint.  It is
+// called before merging into a cumulative checkpoint so that only
+// the exemplars of the latest interval are reported.
+func (c *Aggregator) ResetExemplars() {
+	c.exemplars.Reset()
+}
+
 // SynchronizedMove atomically saves the current value into oa and resets the
 // current sum to zero.
 func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor) error {
@@ -65,12 +83,17 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	o.value = c.value.SwapNumberAtomic(metric.Number(0))
+	c.exemplars.MoveTo(&o.exemplars)
 	return nil
 }
 
-// Update atomically adds to the current value.
-func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metric.Descriptor) error {
+// Update atomically adds to the current value.  If ctx contains a
+// sampled span, the measurement is offered as an exemplar.
+func (c *Aggregator) Update(ctx context.Context, number metric.Number, desc *metric.Descriptor) error {
 	c.value.AddNumberAtomic(desc.NumberKind(), number)
+	if e, ok := aggregator.NewExemplar(ctx, number); ok {
+		c.exemplars.Offer(e)
+	}
 	return nil
 }
 
@@ -81,6 +104,7 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	c.value.AddNumber(desc.NumberKind(), o.value)
+	c.exemplars.Merge(&o.exemplars)
 	return nil
 }
 
@@ -97,5 +121,6 @@ func (c *Aggregator) Subtract(opAgg, resAgg export.Aggregator, descriptor *metri
 
 	res.value = c.value
 	res.value.AddNumber(descriptor.NumberKind(), metric.NewNumberSignChange(descriptor.NumberKind(), op.value))
+	c.exemplars.CopyTo(&res.exemplars)
 	return nil
 }
@@ -15,6 +15,7 @@
 package sum
 
 import (
+	"context"
 	"os"
 	"testing"
 	"unsafe"
@@ -22,7 +23,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
 	ottest "go.opentelemetry.io/otel/internal/testing"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 )
 
@@ -35,6 +39,11 @@ func TestMain(m *testing.M) {
 			Name:   "Aggregator.value",
 			Offset: unsafe.Offsetof(Aggregator{}.value),
 		},
+		{
+			// The value of the next Aggregator of New(n).
+			Name:   "Aggregator size",
+			Offset: unsafe.Sizeof(Aggregator{}),
+		},
 	}
 	if !ottest.Aligned8Byte(fields, os.Stderr) {
 		os.Exit(1)
@@ -140,3 +149,45 @@ func TestCounterMerge(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestSumConformance(t *testing.T) {
+	nf := func(_ *metric.Descriptor) export.Aggregator {
+		return &New(1)[0]
+	}
+	aggregatortest.SynchronizedMoveTest(t, metric.CounterKind, nf)
+	aggregatortest.ConcurrentUpdateTest(t, metric.CounterKind, nf)
+}
+
+func TestCounterExemplars(t *testing.T) {
+	agg, ckpt, other, merged := new4()
+	descriptor := aggregatortest.NewAggregatorTest(metric.CounterKind, metric.Int64NumberKind)
+	ctx := context.Background()
+	sampled := aggregatortest.ContextWithSampledSpan(ctx, trace.ID{1}, trace.SpanID{2})
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, agg.Update(sampled, metric.NewInt64Number(1), descriptor))
+		require.NoError(t, agg.Update(ctx, metric.NewInt64Number(1), descriptor))
+	}
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	exemplars, err := agg.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars)
+
+	exemplars, err = ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, aggregator.ReservoirSize)
+	for _, e := range exemplars {
+		require.Equal(t, metric.NewInt64Number(1), e.Value)
+		require.Equal(t, trace.ID{1}, e.TraceID)
+		require.Equal(t, trace.SpanID{2}, e.SpanID)
+		require.False(t, e.Time.IsZero())
+	}
+
+	require.NoError(t, other.Update(sampled, metric.NewInt64Number(2), descriptor))
+	require.NoError(t, merged.Merge(other, descriptor))
+	require.NoError(t, merged.Merge(ckpt, descriptor))
+	exemplars, err = merged.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, aggregator.ReservoirSize)
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+)
+
+type discardProcessor struct {
+	export.AggregatorSelector
+}
+
+func (discardProcessor) Process(export.Accumulation) error { return nil }
+
+// TestAllocationBudget guards the number of allocations of the
+// measurement hot path against regressions.  Lower the budgets when an
+// optimization reduces the number of allocations; raising them needs a
+// reason.
+//
+// Bound instruments do not allocate.  Unbound measurements with labels
+// that already have a record allocate twice in acquireHandle before
+// the record is found: the candidate record, whose sortSlice is used
+// to sort the labels without an allocation, and the label.Distinct of
+// the sorted labels, which boxes a copy of them in an interface to key
+// the map of records.  Both are needed to look up the record, so two
+// is the floor until the labels of unbound measurements are cached.
+func TestAllocationBudget(t *testing.T) {
+	ctx := context.Background()
+	accum := sdk.NewAccumulator(discardProcessor{processortest.AggregatorSelector()})
+	meter := metric.Must(metric.WrapMeterImpl(accum, "allocations"))
+	labels := []label.KeyValue{label.String("A", "a"), label.String("B", "b")}
+
+	counter := meter.NewInt64Counter("int64.sum")
+	boundCounter := counter.Bind(labels...)
+	defer boundCounter.Unbind()
+	recorder := meter.NewFloat64ValueRecorder("float64.histogram")
+	boundRecorder := recorder.Bind(labels...)
+	defer boundRecorder.Unbind()
+	_ = meter.NewInt64ValueObserver("int64.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, labels...)
+	})
+
+	tests := []struct {
+		name   string
+		budget float64
+		run    func()
+	}{
+		{"BoundCounterAdd", 0, func() { boundCounter.Add(ctx, 1) }},
+		{"BoundValueRecorderRecord", 0, func() { boundRecorder.Record(ctx, 1) }},
+		{"CounterAddExistingLabels", 2, func() { counter.Add(ctx, 1, labels...) }},
+		{"ValueRecorderRecordExistingLabels", 2, func() { recorder.Record(ctx, 1, labels...) }},
+		{"CollectObserver", 5, func() { accum.Collect(ctx) }},
+	}
+	// Create the records of all label sets before measuring.
+	for _, test := range tests {
+		test.run()
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, test.run)
+			if allocs > test.budget {
+				t.Errorf("got %v allocations, budget is %v", allocs, test.budget)
+			}
+		})
+	}
+}
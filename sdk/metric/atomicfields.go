@@ -18,7 +18,11 @@ import "unsafe"
 
 func AtomicFieldOffsets() map[string]uintptr {
 	return map[string]uintptr{
-		"record.refMapped.value": unsafe.Offsetof(record{}.refMapped.value),
-		"record.updateCount":     unsafe.Offsetof(record{}.updateCount),
+		"record.refMapped.value":    unsafe.Offsetof(record{}.refMapped.value),
+		"record.updateCount":        unsafe.Offsetof(record{}.updateCount),
+		"Accumulator.self":          unsafe.Offsetof(Accumulator{}.self),
+		"instrumentState.folded":    unsafe.Offsetof(instrumentState{}.folded),
+		"syncInstrument.labelSets":  unsafe.Offsetof(syncInstrument{}.labelSets),
+		"syncInstrument.overflowed": unsafe.Offsetof(syncInstrument{}.overflowed),
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/label"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
 )
 
@@ -90,7 +91,7 @@ func benchmarkLabels(b *testing.B, n int) {
 	ctx := context.Background()
 	fix := newFixture(b)
 	labs := makeLabels(n)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 
 	b.ResetTimer()
 
@@ -125,7 +126,7 @@ func BenchmarkInt64CounterAddWithLabels_16(b *testing.B) {
 func BenchmarkAcquireNewHandle(b *testing.B) {
 	fix := newFixture(b)
 	labelSets := makeManyLabels(b.N)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 
 	b.ResetTimer()
 
@@ -137,7 +138,7 @@ func BenchmarkAcquireNewHandle(b *testing.B) {
 func BenchmarkAcquireExistingHandle(b *testing.B) {
 	fix := newFixture(b)
 	labelSets := makeManyLabels(b.N)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 
 	for i := 0; i < b.N; i++ {
 		cnt.Bind(labelSets[i]...).Unbind()
@@ -153,7 +154,7 @@ func BenchmarkAcquireExistingHandle(b *testing.B) {
 func BenchmarkAcquireReleaseExistingHandle(b *testing.B) {
 	fix := newFixture(b)
 	labelSets := makeManyLabels(b.N)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 
 	for i := 0; i < b.N; i++ {
 		cnt.Bind(labelSets[i]...).Unbind()
@@ -218,7 +219,7 @@ func BenchmarkGlobalInt64CounterAddWithSDK(b *testing.B) {
 	global.SetMeterProvider(fix)
 
 	labs := []label.KeyValue{label.String("A", "B")}
-	cnt := Must(sdk).NewInt64Counter("int64.counter")
+	cnt := Must(sdk).NewInt64Counter("int64.sum")
 
 	b.ResetTimer()
 
@@ -231,7 +232,7 @@ func BenchmarkInt64CounterAdd(b *testing.B) {
 	ctx := context.Background()
 	fix := newFixture(b)
 	labs := makeLabels(1)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 
 	b.ResetTimer()
 
@@ -244,7 +245,7 @@ func BenchmarkInt64CounterHandleAdd(b *testing.B) {
 	ctx := context.Background()
 	fix := newFixture(b)
 	labs := makeLabels(1)
-	cnt := fix.meterMust().NewInt64Counter("int64.counter")
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
 	handle := cnt.Bind(labs...)
 
 	b.ResetTimer()
@@ -258,7 +259,7 @@ func BenchmarkFloat64CounterAdd(b *testing.B) {
 	ctx := context.Background()
 	fix := newFixture(b)
 	labs := makeLabels(1)
-	cnt := fix.meterMust().NewFloat64Counter("float64.counter")
+	cnt := fix.meterMust().NewFloat64Counter("float64.sum")
 
 	b.ResetTimer()
 
@@ -271,7 +272,7 @@ func BenchmarkFloat64CounterHandleAdd(b *testing.B) {
 	ctx := context.Background()
 	fix := newFixture(b)
 	labs := makeLabels(1)
-	cnt := fix.meterMust().NewFloat64Counter("float64.counter")
+	cnt := fix.meterMust().NewFloat64Counter("float64.sum")
 	handle := cnt.Bind(labs...)
 
 	b.ResetTimer()
@@ -281,6 +282,54 @@ func BenchmarkFloat64CounterHandleAdd(b *testing.B) {
 	}
 }
 
+// Concurrent updates of the same record
+
+func BenchmarkInt64CounterAddParallel(b *testing.B) {
+	ctx := context.Background()
+	fix := newFixture(b)
+	labs := makeLabels(1)
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cnt.Add(ctx, 1, labs...)
+		}
+	})
+}
+
+func BenchmarkFloat64CounterAddParallel(b *testing.B) {
+	ctx := context.Background()
+	fix := newFixture(b)
+	labs := makeLabels(1)
+	cnt := fix.meterMust().NewFloat64Counter("float64.sum")
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cnt.Add(ctx, 1.1, labs...)
+		}
+	})
+}
+
+func BenchmarkInt64CounterHandleAddParallel(b *testing.B) {
+	ctx := context.Background()
+	fix := newFixture(b)
+	labs := makeLabels(1)
+	cnt := fix.meterMust().NewInt64Counter("int64.sum")
+	handle := cnt.Bind(labs...)
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			handle.Add(ctx, 1)
+		}
+	})
+}
+
 // LastValue
 
 func BenchmarkInt64LastValueAdd(b *testing.B) {
@@ -537,7 +586,7 @@ func BenchmarkRepeatedDirectCalls(b *testing.B) {
 	ctx := context.Background()
 	fix := newFixture(b)
 
-	c := fix.meterMust().NewInt64Counter("int64.counter")
+	c := fix.meterMust().NewInt64Counter("int64.sum")
 	k := label.String("bench", "true")
 
 	b.ResetTimer()
@@ -547,3 +596,40 @@ func BenchmarkRepeatedDirectCalls(b *testing.B) {
 		fix.accumulator.Collect(ctx)
 	}
 }
+
+func benchmarkCollect(b *testing.B, kind export.ExportKind) {
+	ctx := context.Background()
+	processor := basic.New(processortest.AggregatorSelector(), kind)
+	accumulator := sdk.NewAccumulator(processor)
+	meter := metric.Must(metric.WrapMeterImpl(accumulator, "benchmarks"))
+
+	counter := meter.NewInt64Counter("int64.sum")
+	recorder := meter.NewFloat64ValueRecorder("float64.histogram")
+	labelSets := makeManyLabels(10)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, labels := range labelSets {
+			counter.Add(ctx, 1, labels...)
+			recorder.Record(ctx, 1, labels...)
+		}
+		processor.StartCollection()
+		accumulator.Collect(ctx)
+		if err := processor.FinishCollection(); err != nil {
+			b.Fatal(err)
+		}
+		if err := processor.CheckpointSet().ForEach(kind, func(export.Record) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCollectCumulative(b *testing.B) {
+	benchmarkCollect(b, export.CumulativeExporter)
+}
+
+func BenchmarkCollectDelta(b *testing.B) {
+	benchmarkCollect(b, export.DeltaExporter)
+}
@@ -15,6 +15,7 @@
 package metric
 
 import (
+	"go.opentelemetry.io/otel/label"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -23,8 +24,77 @@ type Config struct {
 	// Resource describes all the metric records processed by the
 	// Accumulator.
 	Resource *resource.Resource
+
+	// NonFinitePolicy determines how NaN and Inf measurements of
+	// floating point instruments are handled.
+	NonFinitePolicy NonFinitePolicy
+
+	// ValidateUnits enables the validation of the unit of every
+	// instrument against the UCUM syntax when it is created.
+	// Invalid units are reported to the global error handler; the
+	// instrument is created regardless.
+	ValidateUnits bool
+
+	// OverflowLabel is the label of the label set that measurements
+	// exceeding the MaxLabelSets of their instrument are folded
+	// into.  If its key is not defined, DefaultOverflowLabel is used.
+	OverflowLabel label.KeyValue
+
+	// ValidateMonotonicObservations enables the validation of the
+	// observations of SumObserver instruments: an observation lower
+	// than the previous observation of the same label set is
+	// reported to the global error handler and dropped.  Since a
+	// decrease is also how a reset of the observed sum shows, e.g.,
+	// after the process owning it restarted, this is meant for
+	// sources that never reset.  Validation can be disabled per
+	// instrument with Accumulator.SetInstrumentValidation.
+	ValidateMonotonicObservations bool
+
+	// MetricNameFormatter, if not nil, returns the name an
+	// instrument is exported with given its instrumentation name
+	// and its name.  Instruments of different instrumentation or
+	// name that are given the same name are reported to the global
+	// error handler.
+	MetricNameFormatter func(instrumentationName, name string) string
+
+	// SelfObservability enables the instruments through which the
+	// Accumulator reports its own health under the
+	// SelfObservabilityInstrumentationName: the duration of
+	// collections, the data points collected, the asynchronous
+	// instrument callbacks that panicked and the measurements folded
+	// into the overflow label set.
+	SelfObservability bool
+
+	// InstrumentationFilter, if not nil, returns whether the
+	// instruments of the given instrumentation library are enabled.
+	// The instruments of a disabled library are no-ops: their
+	// measurements cost no more than a function call and the
+	// callbacks of their asynchronous instruments are never called.
+	// Unlike Accumulator.SetInstrumentEnabled, this applies when
+	// instruments are created and cannot be changed afterwards.
+	InstrumentationFilter func(instrumentationName string) bool
 }
 
+// NonFinitePolicy determines how the Accumulator handles NaN and Inf
+// measurements of floating point instruments.
+type NonFinitePolicy int
+
+const (
+	// DropNonFinite drops NaN and Inf measurements and reports an
+	// error to the global error handler.  This is the default.
+	DropNonFinite NonFinitePolicy = iota
+
+	// ClampNonFinite replaces +Inf and -Inf measurements with the
+	// largest and smallest finite float64 values, respectively.  NaN
+	// measurements are dropped and reported as with DropNonFinite.
+	ClampNonFinite
+
+	// RecordNonFinite records NaN and Inf measurements as-is.  Note
+	// that a single such measurement makes the sum of the
+	// aggregation non-finite until it is reset.
+	RecordNonFinite
+)
+
 // Option is the interface that applies the value to a configuration option.
 type Option interface {
 	// Apply sets the Option value of a Config.
@@ -43,3 +113,99 @@ type resourceOption struct {
 func (o resourceOption) Apply(config *Config) {
 	config.Resource = o.Resource
 }
+
+// WithNonFinitePolicy sets the NonFinitePolicy configuration option of a
+// Config.
+func WithNonFinitePolicy(policy NonFinitePolicy) Option {
+	return nonFinitePolicyOption(policy)
+}
+
+type nonFinitePolicyOption NonFinitePolicy
+
+func (o nonFinitePolicyOption) Apply(config *Config) {
+	config.NonFinitePolicy = NonFinitePolicy(o)
+}
+
+// WithUnitValidation enables the validation of instrument units, see
+// Config.ValidateUnits.
+func WithUnitValidation() Option {
+	return unitValidationOption{}
+}
+
+type unitValidationOption struct{}
+
+func (unitValidationOption) Apply(config *Config) {
+	config.ValidateUnits = true
+}
+
+// WithOverflowLabel sets the OverflowLabel configuration option of a
+// Config, for backends that expect a specific label on the series of
+// measurements exceeding the MaxLabelSets of their instrument.
+func WithOverflowLabel(kv label.KeyValue) Option {
+	return overflowLabelOption(kv)
+}
+
+type overflowLabelOption label.KeyValue
+
+func (o overflowLabelOption) Apply(config *Config) {
+	config.OverflowLabel = label.KeyValue(o)
+}
+
+// WithMonotonicObservationValidation enables the validation of the
+// observations of SumObserver instruments, see
+// Config.ValidateMonotonicObservations.
+func WithMonotonicObservationValidation() Option {
+	return monotonicObservationValidationOption{}
+}
+
+type monotonicObservationValidationOption struct{}
+
+func (monotonicObservationValidationOption) Apply(config *Config) {
+	config.ValidateMonotonicObservations = true
+}
+
+// WithMetricNameFormatter sets the MetricNameFormatter configuration
+// option of a Config, to enforce naming conventions such as a common
+// prefix for all instruments:
+//
+//	metricsdk.WithMetricNameFormatter(func(_, name string) string {
+//		return "myapp." + name
+//	})
+func WithMetricNameFormatter(f func(instrumentationName, name string) string) Option {
+	return metricNameFormatterOption(f)
+}
+
+type metricNameFormatterOption func(instrumentationName, name string) string
+
+func (o metricNameFormatterOption) Apply(config *Config) {
+	config.MetricNameFormatter = o
+}
+
+// WithSelfObservability enables the instruments reporting the health
+// of the Accumulator, see Config.SelfObservability.
+func WithSelfObservability() Option {
+	return selfObservabilityOption{}
+}
+
+type selfObservabilityOption struct{}
+
+func (selfObservabilityOption) Apply(config *Config) {
+	config.SelfObservability = true
+}
+
+// WithInstrumentationFilter sets the InstrumentationFilter
+// configuration option of a Config, e.g., to turn off the metrics of a
+// noisy third-party library:
+//
+//	metricsdk.WithInstrumentationFilter(func(name string) bool {
+//		return name != "github.com/example/noisy"
+//	})
+func WithInstrumentationFilter(f func(instrumentationName string) bool) Option {
+	return instrumentationFilterOption(f)
+}
+
+type instrumentationFilterOption func(instrumentationName string) bool
+
+func (o instrumentationFilterOption) Apply(config *Config) {
+	config.InstrumentationFilter = o
+}
@@ -17,6 +17,7 @@ package pull
 import (
 	"time"
 
+	"go.opentelemetry.io/otel/api/metric/registry"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -33,6 +34,18 @@ type Config struct {
 	// If the period is zero, caching of the result is disabled.
 	// The default value is 10 seconds.
 	CachePeriod time.Duration
+
+	// DuplicateInstrumentPolicy determines how an instrument is
+	// handled that differs from the instrument registered by the
+	// same name only by its unit or description.  The default,
+	// registry.LenientDuplicates, returns the registered instrument
+	// and reports the conflict to the global error handler.
+	DuplicateInstrumentPolicy registry.DuplicateInstrumentPolicy
+
+	// InstrumentationFilter, if not nil, returns whether the
+	// instruments of the given instrumentation library are
+	// enabled.  See sdk.Config.InstrumentationFilter.
+	InstrumentationFilter func(instrumentationName string) bool
 }
 
 // Option is the interface that applies the value to a configuration option.
@@ -62,3 +75,27 @@ type cachePeriodOption time.Duration
 func (o cachePeriodOption) Apply(config *Config) {
 	config.CachePeriod = time.Duration(o)
 }
+
+// WithDuplicateInstrumentPolicy sets the DuplicateInstrumentPolicy
+// configuration option of a Config.
+func WithDuplicateInstrumentPolicy(policy registry.DuplicateInstrumentPolicy) Option {
+	return duplicateInstrumentPolicyOption(policy)
+}
+
+type duplicateInstrumentPolicyOption registry.DuplicateInstrumentPolicy
+
+func (o duplicateInstrumentPolicyOption) Apply(config *Config) {
+	config.DuplicateInstrumentPolicy = registry.DuplicateInstrumentPolicy(o)
+}
+
+// WithInstrumentationFilter sets the InstrumentationFilter
+// configuration option of a Config.
+func WithInstrumentationFilter(f func(instrumentationName string) bool) Option {
+	return instrumentationFilterOption(f)
+}
+
+type instrumentationFilterOption func(instrumentationName string) bool
+
+func (o instrumentationFilterOption) Apply(config *Config) {
+	config.InstrumentationFilter = o
+}
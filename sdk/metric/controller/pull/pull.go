@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/metric/registry"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
@@ -61,11 +62,16 @@ func New(checkpointer export.Checkpointer, options ...Option) *Controller {
 	accum := sdk.NewAccumulator(
 		checkpointer,
 		sdk.WithResource(config.Resource),
+		sdk.WithInstrumentationFilter(config.InstrumentationFilter),
+	)
+	provider := registry.NewProvider(accum,
+		registry.WithDuplicateInstrumentPolicy(config.DuplicateInstrumentPolicy),
+		registry.WithErrorHandler(global.ErrorHandler()),
 	)
 	return &Controller{
 		accumulator:  accum,
 		checkpointer: checkpointer,
-		provider:     registry.NewProvider(accum),
+		provider:     provider,
 		period:       config.CachePeriod,
 		checkpoint:   checkpointer.CheckpointSet(),
 		clock:        controllerTime.RealClock{},
@@ -85,6 +91,21 @@ func (c *Controller) Provider() metric.Provider {
 	return c.provider
 }
 
+// SetInstrumentEnabled enables or disables the instruments of this
+// controller with the given instrumentation name and instrument name.
+// See sdk.Accumulator.SetInstrumentEnabled.
+func (c *Controller) SetInstrumentEnabled(instrumentationName, name string, enabled bool) {
+	c.accumulator.SetInstrumentEnabled(instrumentationName, name, enabled)
+}
+
+// Shutdown shuts the Accumulator of this controller down so that
+// instruments become no-ops and their state can be garbage collected.
+// See sdk.Accumulator.Shutdown.  The last collected CheckpointSet
+// remains available to ForEach.
+func (c *Controller) Shutdown() {
+	c.accumulator.Shutdown()
+}
+
 // Foreach gives the caller read-locked access to the current
 // export.CheckpointSet.
 func (c *Controller) ForEach(ks export.ExportKindSelector, f func(export.Record) error) error {
@@ -96,10 +117,44 @@ func (c *Controller) ForEach(ks export.ExportKindSelector, f func(export.Record)
 
 // Collect requests a collection.  The collection will be skipped if
 // the last collection is aged less than the CachePeriod.
+//
+// Concurrent calls to Collect, ForEach, and CollectAndForEach are
+// safe.  Collections are serialized, and ForEach never observes a
+// collection in progress.  However, a ForEach that follows a Collect
+// may observe the result of a later collection made by another
+// caller.  Use CollectAndForEach when multiple readers, e.g., two
+// scrapers, each need the records of their own collection.
 func (c *Controller) Collect(ctx context.Context) error {
 	c.checkpointer.CheckpointSet().Lock()
 	defer c.checkpointer.CheckpointSet().Unlock()
 
+	return c.collectLocked(ctx)
+}
+
+// CollectAndForEach requests a collection, as Collect does, and then
+// visits the resulting records, as ForEach does, without allowing
+// another collection in between.  Concurrent calls are serialized, so
+// that each caller observes a consistent snapshot.  When the
+// collection is skipped because of the CachePeriod, the records of the
+// last collection are visited.
+//
+// A collection error is returned only after the records have been
+// visited, since a partial collection still yields useful records.
+func (c *Controller) CollectAndForEach(ctx context.Context, ks export.ExportKindSelector, f func(export.Record) error) error {
+	c.checkpointer.CheckpointSet().Lock()
+	defer c.checkpointer.CheckpointSet().Unlock()
+
+	collectErr := c.collectLocked(ctx)
+	if err := c.checkpoint.ForEach(ks, f); err != nil {
+		return err
+	}
+	return collectErr
+}
+
+// collectLocked performs a collection unless the last one is aged
+// less than the CachePeriod.  It must be called with the CheckpointSet
+// locked.
+func (c *Controller) collectLocked(ctx context.Context) error {
 	if c.period > 0 {
 		now := c.clock.Now()
 		elapsed := now.Sub(c.lastCollect)
@@ -16,15 +16,19 @@ package pull_test
 
 import (
 	"context"
+	"errors"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/metric/registry"
 	"go.opentelemetry.io/otel/label"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
 	"go.opentelemetry.io/otel/sdk/metric/controller/pull"
 	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
@@ -117,3 +121,66 @@ func TestPullWithCache(t *testing.T) {
 	}, records.Map())
 
 }
+
+func TestPullConcurrentCollectAndForEach(t *testing.T) {
+	puller := pull.New(
+		basic.New(
+			selector.NewWithExactDistribution(),
+			export.DeltaExporter,
+		),
+		pull.WithCachePeriod(0),
+	)
+
+	ctx := context.Background()
+	meter := puller.Provider().Meter("concurrent")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+
+	var mu sync.Mutex
+	var total int64
+	scrape := func() {
+		require.NoError(t, puller.CollectAndForEach(ctx, export.DeltaExporter, func(r export.Record) error {
+			sum, err := r.Aggregation().(aggregation.Sum).Sum()
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			total += sum.AsInt64()
+			mu.Unlock()
+			return nil
+		}))
+	}
+
+	const scrapers = 10
+	var wg sync.WaitGroup
+	wg.Add(scrapers)
+	for i := 0; i < scrapers; i++ {
+		go func() {
+			defer wg.Done()
+			counter.Add(ctx, 1)
+			scrape()
+		}()
+	}
+	wg.Wait()
+	scrape()
+
+	// Every delta was observed by exactly one scraper.
+	require.EqualValues(t, scrapers, total)
+}
+
+func TestPullDuplicateInstrumentPolicy(t *testing.T) {
+	puller := pull.New(
+		basic.New(
+			selector.NewWithExactDistribution(),
+			export.CumulativeExporter,
+		),
+		pull.WithDuplicateInstrumentPolicy(registry.StrictDuplicates),
+	)
+	meter := puller.Provider().Meter("duplicates")
+
+	_, err := meter.NewInt64Counter("counter.sum", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	_, err = meter.NewInt64Counter("counter.sum", metric.WithUnit("ms"))
+	require.NoError(t, err)
+	_, err = meter.NewInt64Counter("counter.sum", metric.WithUnit("s"))
+	require.True(t, errors.Is(err, registry.ErrDuplicateInstrument))
+}
@@ -17,6 +17,7 @@ package push
 import (
 	"time"
 
+	"go.opentelemetry.io/otel/api/metric/registry"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -33,6 +34,41 @@ type Config struct {
 	// integrate, and export) can last before it is canceled. Defaults to
 	// the controller push period.
 	Timeout time.Duration
+
+	// Jitter is the fraction of the Period by which the collections
+	// are offset at random, between 0 and 1.  The offset is chosen
+	// once when the controller is started, so that the collections
+	// of a fleet of processes started at the same time are spread
+	// over the period while each process still collects every
+	// Period.  Zero, the default, means no offset.
+	Jitter float64
+
+	// Aligned aligns the collections to multiples of the Period
+	// since the zero time, e.g., to the top of every minute for a
+	// Period of one minute, so that the timestamps of different
+	// processes are comparable.  The random offset of Jitter is
+	// added to the aligned times.
+	Aligned bool
+
+	// DuplicateInstrumentPolicy determines how an instrument is
+	// handled that differs from the instrument registered by the
+	// same name only by its unit or description.  The default,
+	// registry.LenientDuplicates, returns the registered instrument
+	// and reports the conflict to the global error handler.
+	DuplicateInstrumentPolicy registry.DuplicateInstrumentPolicy
+
+	// InstrumentationFilter, if not nil, returns whether the
+	// instruments of the given instrumentation library are
+	// enabled.  See sdk.Config.InstrumentationFilter.
+	InstrumentationFilter func(instrumentationName string) bool
+
+	// OnCollectStart, if not nil, is called when a collection
+	// starts.
+	OnCollectStart func()
+
+	// OnCollectEnd, if not nil, is called with the timing
+	// breakdown of every collection once its export completes.
+	OnCollectEnd func(CollectStats)
 }
 
 // Option is the interface that applies the value to a configuration option.
@@ -73,3 +109,68 @@ type timeoutOption time.Duration
 func (o timeoutOption) Apply(config *Config) {
 	config.Timeout = time.Duration(o)
 }
+
+// WithJitter sets the Jitter configuration option of a Config.
+func WithJitter(fraction float64) Option {
+	return jitterOption(fraction)
+}
+
+type jitterOption float64
+
+func (o jitterOption) Apply(config *Config) {
+	config.Jitter = float64(o)
+}
+
+// WithAlignedInterval sets the Aligned configuration option of a
+// Config.
+func WithAlignedInterval() Option {
+	return alignedOption{}
+}
+
+type alignedOption struct{}
+
+func (alignedOption) Apply(config *Config) {
+	config.Aligned = true
+}
+
+// WithDuplicateInstrumentPolicy sets the DuplicateInstrumentPolicy
+// configuration option of a Config.
+func WithDuplicateInstrumentPolicy(policy registry.DuplicateInstrumentPolicy) Option {
+	return duplicateInstrumentPolicyOption(policy)
+}
+
+type duplicateInstrumentPolicyOption registry.DuplicateInstrumentPolicy
+
+func (o duplicateInstrumentPolicyOption) Apply(config *Config) {
+	config.DuplicateInstrumentPolicy = registry.DuplicateInstrumentPolicy(o)
+}
+
+// WithInstrumentationFilter sets the InstrumentationFilter
+// configuration option of a Config.
+func WithInstrumentationFilter(f func(instrumentationName string) bool) Option {
+	return instrumentationFilterOption(f)
+}
+
+type instrumentationFilterOption func(instrumentationName string) bool
+
+func (o instrumentationFilterOption) Apply(config *Config) {
+	config.InstrumentationFilter = o
+}
+
+// WithCollectHooks sets the OnCollectStart and OnCollectEnd
+// configuration options of a Config.  Either hook may be nil.  The
+// hooks are called synchronously by the collection, so they must not
+// block.
+func WithCollectHooks(onStart func(), onEnd func(CollectStats)) Option {
+	return collectHooksOption{onStart: onStart, onEnd: onEnd}
+}
+
+type collectHooksOption struct {
+	onStart func()
+	onEnd   func(CollectStats)
+}
+
+func (o collectHooksOption) Apply(config *Config) {
+	config.OnCollectStart = o.onStart
+	config.OnCollectEnd = o.onEnd
+}
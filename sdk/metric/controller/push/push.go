@@ -16,6 +16,9 @@ package push // import "go.opentelemetry.io/otel/sdk/metric/controller/push"
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -23,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel/api/metric"
 	"go.opentelemetry.io/otel/api/metric/registry"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/internal"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
 	controllerTime "go.opentelemetry.io/otel/sdk/metric/controller/time"
 )
@@ -33,22 +37,65 @@ const DefaultPushPeriod = 10 * time.Second
 // Controller organizes a periodic push of metric data.
 type Controller struct {
 	lock         sync.Mutex
+	collectLock  sync.Mutex
 	accumulator  *sdk.Accumulator
 	provider     *registry.Provider
 	checkpointer export.Checkpointer
-	exporter     export.Exporter
+	export       CallbackFunc
 	wg           sync.WaitGroup
 	ch           chan struct{}
 	period       time.Duration
 	timeout      time.Duration
+	jitter       float64
+	aligned      bool
 	clock        controllerTime.Clock
 	ticker       controllerTime.Ticker
+
+	onCollectStart func()
+	onCollectEnd   func(CollectStats)
+
+	// lastStats is the CollectStats of the last collection,
+	// guarded by collectLock.
+	lastStats CollectStats
+}
+
+// CollectStats is the timing breakdown of a collection and export of a
+// Controller, to diagnose slow collection cycles.
+type CollectStats struct {
+	sdk.CollectTimings
+
+	// Export is the time spent exporting the checkpoint.
+	Export time.Duration
+
+	// Err is the error of the export, if any.
+	Err error
 }
 
+// CallbackFunc is called with the checkpoint of every collection of a
+// Controller constructed with NewWithCallback.  The CheckpointSet is
+// locked for the duration of the call and must not be used after
+// it returns.
+type CallbackFunc func(ctx context.Context, checkpointSet export.CheckpointSet) error
+
 // New constructs a Controller, an implementation of metric.Provider,
 // using the provided checkpointer, exporter, and options to configure
 // an SDK with periodic collection.
 func New(checkpointer export.Checkpointer, exporter export.Exporter, opts ...Option) *Controller {
+	return NewWithCallback(checkpointer, func(ctx context.Context, checkpointSet export.CheckpointSet) error {
+		return exporter.Export(ctx, checkpointSet)
+	}, opts...)
+}
+
+// NewWithCallback constructs a Controller like New, except that f is
+// called with the checkpoint of every collection instead of exporting
+// it with an Exporter.  This simplifies embedding the SDK in agents or
+// control planes that consume metric data directly.  As with an
+// Exporter, f is never called concurrently and errors it returns are
+// reported to the global error handler.
+//
+// The ExportKind of each record is the one selected by the
+// ExportKindSelector the checkpointer was constructed with.
+func NewWithCallback(checkpointer export.Checkpointer, f CallbackFunc, opts ...Option) *Controller {
 	c := &Config{
 		Period: DefaultPushPeriod,
 	}
@@ -62,16 +109,26 @@ func New(checkpointer export.Checkpointer, exporter export.Exporter, opts ...Opt
 	impl := sdk.NewAccumulator(
 		checkpointer,
 		sdk.WithResource(c.Resource),
+		sdk.WithInstrumentationFilter(c.InstrumentationFilter),
+	)
+	provider := registry.NewProvider(impl,
+		registry.WithDuplicateInstrumentPolicy(c.DuplicateInstrumentPolicy),
+		registry.WithErrorHandler(global.ErrorHandler()),
 	)
 	return &Controller{
-		provider:     registry.NewProvider(impl),
+		provider:     provider,
 		accumulator:  impl,
 		checkpointer: checkpointer,
-		exporter:     exporter,
+		export:       f,
 		ch:           make(chan struct{}),
 		period:       c.Period,
 		timeout:      c.Timeout,
+		jitter:       c.Jitter,
+		aligned:      c.Aligned,
 		clock:        controllerTime.RealClock{},
+
+		onCollectStart: c.OnCollectStart,
+		onCollectEnd:   c.OnCollectEnd,
 	}
 }
 
@@ -88,8 +145,17 @@ func (c *Controller) Provider() metric.Provider {
 	return c.provider
 }
 
+// SetInstrumentEnabled enables or disables the instruments of this
+// controller with the given instrumentation name and instrument name.
+// See sdk.Accumulator.SetInstrumentEnabled.
+func (c *Controller) SetInstrumentEnabled(instrumentationName, name string, enabled bool) {
+	c.accumulator.SetInstrumentEnabled(instrumentationName, name, enabled)
+}
+
 // Start begins a ticker that periodically collects and exports
-// metrics with the configured interval.
+// metrics with the configured interval.  If the collections are
+// aligned or jittered, the first collection happens at the first
+// aligned time, plus the random offset, after Start.
 func (c *Controller) Start() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -98,13 +164,42 @@ func (c *Controller) Start() {
 		return
 	}
 
-	c.ticker = c.clock.Ticker(c.period)
+	delay := c.startDelay()
+	if delay > 0 {
+		c.ticker = c.clock.Ticker(delay)
+	} else {
+		c.ticker = c.clock.Ticker(c.period)
+	}
 	c.wg.Add(1)
-	go c.run(c.ch)
+	go c.run(c.ch, c.ticker, delay > 0)
 }
 
-// Stop waits for the background goroutine to return and then collects
-// and exports metrics one last time before returning.
+// startDelay returns the time until the first collection if it is
+// aligned or jittered, or zero otherwise.
+func (c *Controller) startDelay() time.Duration {
+	var delay time.Duration
+	if c.aligned {
+		now := c.clock.Now()
+		delay = now.Truncate(c.period).Add(c.period).Sub(now)
+	}
+	if c.jitter > 0 {
+		delay += time.Duration(rand.Float64() * c.jitter * float64(c.period))
+	}
+	return delay
+}
+
+// Stop stops the periodic collection and performs one final
+// collection and export.  A collection that is in progress when Stop
+// is called completes before the final collection begins, so the
+// exporter is never called concurrently and receives exactly one
+// final export.  Stop may be called without a prior call to Start,
+// in which case only the final collection is performed.  Subsequent
+// calls to Stop have no effect.
+//
+// Every export, including the final one, is canceled after the
+// configured Timeout, so Stop returns within about twice the Timeout
+// even if the collector is unreachable, provided the exporter honors
+// the cancellation of its context.
 func (c *Controller) Stop() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -116,38 +211,115 @@ func (c *Controller) Stop() {
 	close(c.ch)
 	c.ch = nil
 	c.wg.Wait()
-	c.ticker.Stop()
 
 	c.tick()
 }
 
-func (c *Controller) run(ch chan struct{}) {
+// Shutdown stops the controller as Stop does, including the final
+// collection and export, and then shuts its Accumulator down so that
+// instruments become no-ops and their state can be garbage collected.
+// See sdk.Accumulator.Shutdown.  The controller cannot be restarted.
+func (c *Controller) Shutdown() {
+	c.Stop()
+	c.accumulator.Shutdown()
+}
+
+// run collects on every tick of ticker until ch is closed.  If
+// delayed is true, the first tick of ticker starts the periodic
+// collection.
+func (c *Controller) run(ch chan struct{}, ticker controllerTime.Ticker, delayed bool) {
+	defer c.wg.Done()
+	defer func() { ticker.Stop() }()
 	for {
 		select {
 		case <-ch:
-			c.wg.Done()
 			return
-		case <-c.ticker.C():
+		case <-ticker.C():
+			if delayed {
+				ticker.Stop()
+				ticker = c.clock.Ticker(c.period)
+				delayed = false
+			}
 			c.tick()
 		}
 	}
 }
 
+// Collect performs a collection and export immediately, outside of
+// the periodic schedule, e.g., to serve a health endpoint or before a
+// process is suspended.  It is safe to call concurrently with the
+// periodic collection, which it does not delay or reset: collections
+// are serialized, so the exporter is never called concurrently.  The
+// export is canceled after the configured Timeout or when ctx is
+// done.  Unlike errors of the periodic collection, which are reported
+// to the global error handler, the error of the export is returned.
+func (c *Controller) Collect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.collect(ctx)
+}
+
 func (c *Controller) tick() {
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
+	if err := c.collect(ctx); err != nil {
+		global.Handle(err)
+	}
+}
+
+// collect collects and exports, returning the error of the export.
+func (c *Controller) collect(ctx context.Context) error {
+	c.collectLock.Lock()
+	defer c.collectLock.Unlock()
 
 	ckpt := c.checkpointer.CheckpointSet()
 	ckpt.Lock()
 	defer ckpt.Unlock()
 
+	if c.onCollectStart != nil {
+		c.onCollectStart()
+	}
+
 	c.checkpointer.StartCollection()
-	c.accumulator.Collect(ctx)
+	_, timings := c.accumulator.CollectWithTimings(ctx)
 	if err := c.checkpointer.FinishCollection(); err != nil {
 		global.Handle(err)
 	}
 
-	if err := c.exporter.Export(ctx, ckpt); err != nil {
-		global.Handle(err)
+	start := internal.Now()
+	err := c.safeExport(ctx, ckpt)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !errors.Is(err, export.ErrExportTimeout) {
+		err = fmt.Errorf("%w: %v", export.ErrExportTimeout, err)
+	}
+
+	c.lastStats = CollectStats{
+		CollectTimings: timings,
+		Export:         internal.Now().Sub(start),
+		Err:            err,
+	}
+	if c.onCollectEnd != nil {
+		c.onCollectEnd(c.lastStats)
 	}
+	return err
+}
+
+// LastCollectStats returns the CollectStats of the last collection,
+// or the zero value if there was none.  It waits for a collection in
+// progress to complete.
+func (c *Controller) LastCollectStats() CollectStats {
+	c.collectLock.Lock()
+	defer c.collectLock.Unlock()
+	return c.lastStats
+}
+
+// safeExport calls the export callback, recovering its panic so that
+// it does not stop the periodic collection.  A recovered panic is
+// returned as an error.
+func (c *Controller) safeExport(ctx context.Context, ckpt export.CheckpointSet) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = internal.PanicError(r, "metric exporter")
+		}
+	}()
+	return c.export(ctx, ckpt)
 }
@@ -16,6 +16,7 @@ package push_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -147,6 +148,183 @@ func TestPushTicker(t *testing.T) {
 	p.Stop()
 }
 
+func TestPushAlignedInterval(t *testing.T) {
+	exporter := newExporter()
+	p := push.New(
+		newCheckpointer(),
+		exporter,
+		push.WithPeriod(time.Minute),
+		push.WithAlignedInterval(),
+		push.WithResource(testResource),
+	)
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+	mock.Add(20 * time.Second)
+
+	p.Start()
+	defer p.Stop()
+
+	// The first collection happens at the top of the minute.
+	mock.Add(39 * time.Second)
+	runtime.Gosched()
+	require.Equal(t, 0, exporter.ExportCount())
+
+	mock.Add(time.Second)
+	require.Eventually(t, func() bool { return exporter.ExportCount() == 1 }, time.Second, time.Millisecond)
+
+	// Then every minute.
+	mock.Add(time.Minute)
+	require.Eventually(t, func() bool { return exporter.ExportCount() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestPushJitter(t *testing.T) {
+	exporter := newExporter()
+	p := push.New(
+		newCheckpointer(),
+		exporter,
+		push.WithPeriod(time.Minute),
+		push.WithAlignedInterval(),
+		push.WithJitter(0.5),
+		push.WithResource(testResource),
+	)
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	p.Start()
+	defer p.Stop()
+
+	// The first collection is offset by up to half a minute from
+	// the top of the minute.
+	mock.Add(59 * time.Second)
+	runtime.Gosched()
+	require.Equal(t, 0, exporter.ExportCount())
+
+	mock.Add(31 * time.Second)
+	require.Eventually(t, func() bool { return exporter.ExportCount() == 1 }, time.Second, time.Millisecond)
+
+	mock.Add(time.Minute)
+	require.Eventually(t, func() bool { return exporter.ExportCount() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestPushCallback(t *testing.T) {
+	var (
+		calls int
+		names []string
+	)
+	errCallback := errors.New("callback failed")
+	p := push.NewWithCallback(
+		newCheckpointer(),
+		func(_ context.Context, cs export.CheckpointSet) error {
+			calls++
+			err := cs.ForEach(export.PassThroughExporter, func(r export.Record) error {
+				names = append(names, r.Descriptor().Name())
+				return nil
+			})
+			require.NoError(t, err)
+			return errCallback
+		},
+		push.WithPeriod(time.Second),
+	)
+	meter := p.Provider().Meter("name")
+
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+	p.Start()
+
+	counter.Add(context.Background(), 3)
+	mock.Add(time.Second)
+	runtime.Gosched()
+
+	// Stop performs a final collection.
+	p.Stop()
+	require.Equal(t, 2, calls)
+	require.Equal(t, []string{"counter.sum", "counter.sum"}, names)
+	require.True(t, errors.Is(testHandler.Flush(), errCallback))
+}
+
+func TestPushCollect(t *testing.T) {
+	var calls int
+	errCallback := errors.New("callback failed")
+	p := push.NewWithCallback(
+		newCheckpointer(),
+		func(_ context.Context, cs export.CheckpointSet) error {
+			calls++
+			return errCallback
+		},
+		push.WithPeriod(time.Hour),
+	)
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+	p.Start()
+
+	// Collect does not wait for the period and returns the error
+	// rather than reporting it.
+	require.True(t, errors.Is(p.Collect(context.Background()), errCallback))
+	require.Equal(t, 1, calls)
+	require.NoError(t, testHandler.Flush())
+
+	p.Stop()
+	require.Equal(t, 2, calls)
+	require.True(t, errors.Is(testHandler.Flush(), errCallback))
+}
+
+func TestPushCollectPanic(t *testing.T) {
+	p := push.NewWithCallback(
+		newCheckpointer(),
+		func(context.Context, export.CheckpointSet) error {
+			panic("export")
+		},
+		push.WithPeriod(time.Hour),
+	)
+
+	// The recovered panic is the error of the collection.
+	err := p.Collect(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "recovered panic of metric exporter: export")
+	require.Equal(t, err, p.LastCollectStats().Err)
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestPushCollectHooks(t *testing.T) {
+	const delay = 10 * time.Millisecond
+	errCallback := errors.New("callback failed")
+	var started int
+	var ended []push.CollectStats
+	p := push.NewWithCallback(
+		newCheckpointer(),
+		func(context.Context, export.CheckpointSet) error {
+			time.Sleep(delay)
+			return errCallback
+		},
+		push.WithPeriod(time.Hour),
+		push.WithCollectHooks(
+			func() { started++ },
+			func(s push.CollectStats) { ended = append(ended, s) },
+		),
+	)
+	require.Equal(t, push.CollectStats{}, p.LastCollectStats())
+
+	_ = metric.Must(p.Provider().Meter("test")).NewInt64ValueObserver("observer.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			time.Sleep(delay)
+			result.Observe(1)
+		})
+
+	require.True(t, errors.Is(p.Collect(context.Background()), errCallback))
+	require.Equal(t, 1, started)
+	require.Len(t, ended, 1)
+
+	stats := ended[0]
+	require.Equal(t, stats, p.LastCollectStats())
+	require.False(t, stats.Start.IsZero())
+	require.GreaterOrEqual(t, int64(stats.Callbacks), int64(delay))
+	require.GreaterOrEqual(t, int64(stats.Aggregation), int64(0))
+	require.GreaterOrEqual(t, int64(stats.Export), int64(delay))
+	require.True(t, errors.Is(stats.Err, errCallback))
+}
+
 func TestPushExportError(t *testing.T) {
 	injector := func(name string, e error) func(r export.Record) error {
 		return func(r export.Record) error {
@@ -223,3 +401,138 @@ func TestPushExportError(t *testing.T) {
 		})
 	}
 }
+
+// blockingExporter blocks each Export until released.
+type blockingExporter struct {
+	*processorTest.Exporter
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExporter) Export(ctx context.Context, ckpt export.CheckpointSet) error {
+	e.entered <- struct{}{}
+	<-e.release
+	return e.Exporter.Export(ctx, ckpt)
+}
+
+func TestPushStopWaitsForExport(t *testing.T) {
+	exporter := &blockingExporter{
+		Exporter: newExporter(),
+		entered:  make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	checkpointer := newCheckpointer()
+	p := push.New(
+		checkpointer,
+		exporter,
+		push.WithPeriod(time.Second),
+		push.WithResource(testResource),
+	)
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	counter := metric.Must(p.Provider().Meter("name")).NewInt64Counter("counter.sum")
+
+	p.Start()
+	counter.Add(context.Background(), 3)
+	mock.Add(time.Second)
+
+	// The periodic export is now in progress.
+	<-exporter.entered
+
+	stopped := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(stopped)
+	}()
+
+	counter.Add(context.Background(), 4)
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while an export was in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Release the periodic export, then the final export.
+	exporter.release <- struct{}{}
+	<-exporter.entered
+	exporter.release <- struct{}{}
+	<-stopped
+
+	require.Equal(t, 2, exporter.ExportCount())
+
+	// Stop is idempotent and does not export again.
+	p.Stop()
+	require.Equal(t, 2, exporter.ExportCount())
+}
+
+func TestPushStopWithoutStart(t *testing.T) {
+	exporter := newExporter()
+	checkpointer := newCheckpointer()
+	p := push.New(checkpointer, exporter, push.WithResource(testResource))
+
+	counter := metric.Must(p.Provider().Meter("name")).NewInt64Counter("counter.sum")
+	counter.Add(context.Background(), 3)
+
+	p.Stop()
+
+	require.Equal(t, 1, exporter.ExportCount())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+}
+
+func TestPushShutdown(t *testing.T) {
+	exporter := newExporter()
+	checkpointer := newCheckpointer()
+	p := push.New(checkpointer, exporter, push.WithResource(testResource))
+	p.Start()
+
+	counter := metric.Must(p.Provider().Meter("name")).NewInt64Counter("counter.sum")
+	counter.Add(context.Background(), 3)
+
+	p.Shutdown()
+
+	// Shutdown performs the final export of Stop.
+	require.Equal(t, 1, exporter.ExportCount())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+
+	// Measurements after Shutdown are dropped.
+	exporter.Reset()
+	counter.Add(context.Background(), 3)
+	p.Stop()
+	require.EqualValues(t, map[string]float64{}, exporter.Values())
+}
+
+type timeoutExporter struct {
+	*processorTest.Exporter
+}
+
+func (e timeoutExporter) Export(ctx context.Context, _ export.CheckpointSet) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestPushExportTimeout(t *testing.T) {
+	exporter := timeoutExporter{Exporter: newExporter()}
+	checkpointer := newCheckpointer()
+	p := push.New(
+		checkpointer,
+		exporter,
+		push.WithResource(testResource),
+		push.WithTimeout(time.Millisecond),
+	)
+
+	counter := metric.Must(p.Provider().Meter("name")).NewInt64Counter("counter.sum")
+	counter.Add(context.Background(), 3)
+
+	require.NoError(t, testHandler.Flush())
+	p.Stop()
+
+	err := testHandler.Flush()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, export.ErrExportTimeout))
+}
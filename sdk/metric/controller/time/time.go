@@ -17,6 +17,8 @@ package time // import "go.opentelemetry.io/otel/sdk/metric/controller/time"
 import (
 	"time"
 	lib "time"
+
+	"go.opentelemetry.io/otel/sdk/internal"
 )
 
 // Several types below are created to match "github.com/benbjohnson/clock"
@@ -42,8 +44,10 @@ type RealTicker struct {
 var _ Clock = RealClock{}
 var _ Ticker = RealTicker{}
 
+// Now returns the current time of the SDK clock, see
+// "go.opentelemetry.io/otel/sdk/internal".Now.
 func (RealClock) Now() time.Time {
-	return time.Now()
+	return internal.Now()
 }
 
 func (RealClock) Ticker(period time.Duration) Ticker {
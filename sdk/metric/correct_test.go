@@ -16,6 +16,7 @@ package metric_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -25,12 +26,15 @@ import (
 
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/metric/registry"
 	"go.opentelemetry.io/otel/label"
+	opentelemetry "go.opentelemetry.io/otel/sdk"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/unit"
 )
 
 var Must = metric.Must
@@ -173,6 +177,115 @@ func TestInputRangeValueRecorder(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestInputRangeInf(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := Must(meter).NewFloat64Counter("name.sum")
+
+	counter.Add(ctx, math.Inf(+1))
+	require.Equal(t, aggregation.ErrInfInput, testHandler.Flush())
+
+	checkpointed := sdk.Collect(ctx)
+	require.Equal(t, 0, checkpointed)
+
+	counter.Add(ctx, 1)
+	processor.accumulations = nil
+	checkpointed = sdk.Collect(ctx)
+	sum, err := processor.accumulations[0].Aggregator().(aggregation.Sum).Sum()
+	require.Equal(t, 1.0, sum.AsFloat64())
+	require.Equal(t, 1, checkpointed)
+	require.Nil(t, err)
+	require.Nil(t, testHandler.Flush())
+}
+
+func TestNonFinitePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name   string
+		policy metricsdk.NonFinitePolicy
+		input  float64
+		sum    float64
+		err    error
+	}{
+		{"drop/+Inf", metricsdk.DropNonFinite, math.Inf(+1), 1, aggregation.ErrInfInput},
+		{"drop/-Inf", metricsdk.DropNonFinite, math.Inf(-1), 1, aggregation.ErrInfInput},
+		{"clamp/+Inf", metricsdk.ClampNonFinite, math.Inf(+1), math.MaxFloat64, nil},
+		{"clamp/-Inf", metricsdk.ClampNonFinite, math.Inf(-1), -math.MaxFloat64, nil},
+		{"clamp/NaN", metricsdk.ClampNonFinite, math.NaN(), 1, aggregation.ErrNaNInput},
+		{"record/+Inf", metricsdk.RecordNonFinite, math.Inf(+1), math.Inf(+1), nil},
+		{"record/NaN", metricsdk.RecordNonFinite, math.NaN(), math.NaN(), nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testHandler.Reset()
+			processor := &correctnessProcessor{
+				t:            t,
+				testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+			}
+			accum := metricsdk.NewAccumulator(processor, metricsdk.WithNonFinitePolicy(tc.policy))
+			meter := metric.WrapMeterImpl(accum, "test")
+
+			counter := Must(meter).NewFloat64UpDownCounter("name.sum")
+			counter.Add(ctx, 1)
+			counter.Add(ctx, tc.input)
+			require.Equal(t, tc.err, testHandler.Flush())
+
+			require.Equal(t, 1, accum.Collect(ctx))
+			sum, err := processor.accumulations[0].Aggregator().(aggregation.Sum).Sum()
+			require.NoError(t, err)
+			if math.IsNaN(tc.sum) {
+				require.True(t, math.IsNaN(sum.AsFloat64()))
+			} else {
+				require.Equal(t, tc.sum, sum.AsFloat64())
+			}
+		})
+	}
+}
+
+func TestNonFinitePolicyMonotonic(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(processor, metricsdk.WithNonFinitePolicy(metricsdk.RecordNonFinite))
+	meter := metric.WrapMeterImpl(accum, "test")
+
+	counter := Must(meter).NewFloat64Counter("name.sum")
+	counter.Add(ctx, math.Inf(-1))
+	require.Equal(t, aggregation.ErrNegativeInput, testHandler.Flush())
+	require.Equal(t, 0, accum.Collect(ctx))
+}
+
+func TestUnitValidation(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(processor, metricsdk.WithUnitValidation())
+	meter := metric.WrapMeterImpl(accum, "test")
+
+	Must(meter).NewInt64Counter("valid.sum", metric.WithUnit(unit.BytesPerSec))
+	require.NoError(t, testHandler.Flush())
+
+	counter := Must(meter).NewInt64Counter("invalid.sum", metric.WithUnit("milli seconds"))
+	require.True(t, errors.Is(testHandler.Flush(), unit.ErrInvalidUnit))
+
+	// The instrument is usable regardless.
+	counter.Add(ctx, 1)
+	require.Equal(t, 1, accum.Collect(ctx))
+
+	// Units are not validated by default.
+	accum = metricsdk.NewAccumulator(processor)
+	meter = metric.WrapMeterImpl(accum, "test")
+	Must(meter).NewInt64Counter("invalid.sum", metric.WithUnit("milli seconds"))
+	require.NoError(t, testHandler.Flush())
+}
+
 func TestDisabledInstrument(t *testing.T) {
 	ctx := context.Background()
 	meter, sdk, processor := newSDK(t)
@@ -396,6 +509,68 @@ func TestSumObserverInputRange(t *testing.T) {
 	require.NoError(t, testHandler.Flush())
 }
 
+func TestSumObserverMonotonicValidation(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(
+		processor,
+		metricsdk.WithResource(testResource),
+		metricsdk.WithMonotonicObservationValidation(),
+	)
+	meter := metric.WrapMeterImpl(accum, "test")
+
+	var value int64
+	_ = Must(meter).NewInt64SumObserver("validated.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(value, label.String("A", "B"))
+	})
+	_ = Must(meter).NewInt64SumObserver("unvalidated.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(value, label.String("A", "B"))
+	})
+	_ = Must(meter).NewInt64UpDownSumObserver("updown.lastvalue", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(value, label.String("A", "B"))
+	})
+	accum.SetInstrumentValidation("test", "unvalidated.lastvalue", false)
+
+	collect := func(v int64) map[string]float64 {
+		value = v
+		processor.accumulations = nil
+		accum.Collect(ctx)
+		out := processortest.NewOutput(label.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+
+	require.EqualValues(t, map[string]float64{
+		"validated.lastvalue/A=B/R=V":   10,
+		"unvalidated.lastvalue/A=B/R=V": 10,
+		"updown.lastvalue/A=B/R=V":      10,
+	}, collect(10))
+	require.NoError(t, testHandler.Flush())
+
+	// The decreasing observation of the validated instrument is
+	// dropped and reported.
+	require.EqualValues(t, map[string]float64{
+		"unvalidated.lastvalue/A=B/R=V": 5,
+		"updown.lastvalue/A=B/R=V":      5,
+	}, collect(5))
+	err := testHandler.Flush()
+	require.True(t, errors.Is(err, metricsdk.ErrDecreasingObservation))
+	require.Contains(t, err.Error(), `"validated.lastvalue": 5 < 10`)
+
+	require.EqualValues(t, map[string]float64{
+		"validated.lastvalue/A=B/R=V":   12,
+		"unvalidated.lastvalue/A=B/R=V": 12,
+		"updown.lastvalue/A=B/R=V":      12,
+	}, collect(12))
+	require.NoError(t, testHandler.Flush())
+}
+
 func TestObserverBatch(t *testing.T) {
 	ctx := context.Background()
 	meter, sdk, processor := newSDK(t)
@@ -507,6 +682,300 @@ func TestRecordBatch(t *testing.T) {
 	}, out.Map())
 }
 
+func TestRecordBatchMaxLabelSets(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	limited := Must(meter).NewInt64Counter("limited.sum", metric.WithMaxLabelSets(1))
+	free := Must(meter).NewInt64Counter("free.sum")
+
+	for i := 1; i <= 2; i++ {
+		sdk.RecordBatch(ctx, []label.KeyValue{label.Int("A", i)},
+			limited.Measurement(int64(i)),
+			free.Measurement(int64(i)),
+		)
+	}
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	// The label set of the second batch overflows the limited
+	// counter only.
+	require.EqualValues(t, map[string]float64{
+		"limited.sum/A=1/R=V":                       1,
+		"limited.sum/otel.metric.overflow=true/R=V": 2,
+		"free.sum/A=1/R=V":                          1,
+		"free.sum/A=2/R=V":                          2,
+	}, out.Map())
+	require.Error(t, testHandler.Flush())
+}
+
+func TestSetInstrumentEnabled(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	// Disabling applies to instruments created later.
+	sdk.SetInstrumentEnabled("test", "int64.sum", false)
+
+	counter := Must(meter).NewInt64Counter("int64.sum")
+	bound := counter.Bind(label.String("A", "B"))
+	defer bound.Unbind()
+	other := Must(meter).NewInt64Counter("other.sum")
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5)
+	})
+	sdk.SetInstrumentEnabled("test", "int64.sumobserver.sum", false)
+	// Instruments of other instrumentation libraries are unaffected.
+	sdk.SetInstrumentEnabled("other", "other.sum", false)
+
+	record := func() map[string]float64 {
+		counter.Add(ctx, 1)
+		bound.Add(ctx, 2)
+		other.Add(ctx, 3)
+		sdk.RecordBatch(ctx, nil, counter.Measurement(4), other.Measurement(5))
+
+		processor.accumulations = nil
+		sdk.Collect(ctx)
+		out := processortest.NewOutput(label.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+
+	require.EqualValues(t, map[string]float64{
+		"other.sum//R=V": 8,
+	}, record())
+
+	sdk.SetInstrumentEnabled("test", "int64.sum", true)
+	sdk.SetInstrumentEnabled("test", "int64.sumobserver.sum", true)
+	require.EqualValues(t, map[string]float64{
+		"int64.sum//R=V":             5,
+		"int64.sum/A=B/R=V":          2,
+		"int64.sumobserver.sum//R=V": 5,
+		"other.sum//R=V":             8,
+	}, record())
+}
+
+func TestShutdown(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := Must(meter).NewInt64Counter("int64.sum")
+	bound := counter.Bind(label.String("A", "B"))
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5)
+	})
+	counter.Add(ctx, 1)
+	bound.Add(ctx, 2)
+	require.Equal(t, 3, sdk.Collect(ctx))
+
+	sdk.Shutdown()
+	sdk.Shutdown()
+
+	processor.accumulations = nil
+	counter.Add(ctx, 1)
+	bound.Add(ctx, 2)
+	bound.Unbind()
+	counter.Bind(label.String("A", "C")).Add(ctx, 3)
+	sdk.RecordBatch(ctx, nil, counter.Measurement(4))
+	require.Equal(t, 0, sdk.Collect(ctx))
+	require.Empty(t, processor.accumulations)
+
+	// New synchronous instruments are no-ops.
+	after, err := meter.NewInt64Counter("after.sum")
+	require.NoError(t, err)
+	after.Add(ctx, 1)
+	require.Equal(t, 0, sdk.Collect(ctx))
+
+	// New asynchronous instruments fail.
+	_, err = meter.NewInt64SumObserver("after.sumobserver.sum", func(context.Context, metric.Int64ObserverResult) {})
+	require.True(t, errors.Is(err, metricsdk.ErrShutdown))
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestRecordLabelKeys(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := Must(meter).NewInt64Counter("int64.sum", metric.WithLabelKeys("A"))
+	recorder := Must(meter).NewFloat64ValueRecorder("float64.exact")
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5, label.String("A", "B"), label.String("C", "D"))
+	}, metric.WithLabelKeys("C"))
+
+	counter.Add(ctx, 1, label.String("A", "B"), label.String("C", "D"))
+	counter.Add(ctx, 2, label.String("A", "B"), label.String("C", "E"))
+	sdk.RecordBatch(
+		ctx,
+		[]label.KeyValue{
+			label.String("A", "B"),
+			label.String("C", "D"),
+		},
+		counter.Measurement(3),
+		recorder.Measurement(4),
+	)
+
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/A=B/R=V":             6,
+		"float64.exact/A=B,C=D/R=V":     4,
+		"int64.sumobserver.sum/C=D/R=V": 5,
+	}, out.Map())
+}
+
+func TestInstrumentationLabels(t *testing.T) {
+	ctx := context.Background()
+	_, sdk, processor := newSDK(t)
+	meter := metric.WrapMeterImpl(sdk, "test", metric.WithInstrumentationLabels(label.String("module", "checkout")))
+
+	counter := Must(meter).NewInt64Counter("int64.sum")
+	filtered := Must(meter).NewInt64Counter("filtered.sum", metric.WithLabelKeys("A"))
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5, label.String("A", "B"))
+	})
+
+	counter.Add(ctx, 1, label.String("A", "B"))
+	// Measurement labels take precedence.
+	counter.Add(ctx, 2, label.String("module", "cart"))
+	sdk.RecordBatch(ctx, []label.KeyValue{label.String("A", "B"), label.String("C", "D")},
+		counter.Measurement(3),
+		filtered.Measurement(4),
+	)
+
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/A=B,module=checkout/R=V":             1,
+		"int64.sum/module=cart/R=V":                     2,
+		"int64.sum/A=B,C=D,module=checkout/R=V":         3,
+		"filtered.sum/A=B,module=checkout/R=V":          4,
+		"int64.sumobserver.sum/A=B,module=checkout/R=V": 5,
+	}, out.Map())
+}
+
+func TestObserverMaxLabelSets(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		for i := 0; i < 5; i++ {
+			result.Observe(int64(i+1), label.Int("I", i))
+		}
+		// A repeated observation does not count towards the limit.
+		result.Observe(10, label.Int("I", 0))
+	}, metric.WithMaxLabelSets(2))
+
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sumobserver.sum/I=0/R=V":                       10,
+		"int64.sumobserver.sum/I=1/R=V":                       2,
+		"int64.sumobserver.sum/otel.metric.overflow=true/R=V": 3 + 4 + 5,
+	}, out.Map())
+
+	err := testHandler.Flush()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "3 observations exceeding 2 label sets")
+
+	require.EqualValues(t, 3, sdk.FoldedObservations("test", "int64.sumobserver.sum"))
+
+	// The limit applies per collection.
+	processor.accumulations = nil
+	sdk.Collect(ctx)
+	require.Len(t, processor.accumulations, 3)
+	require.EqualValues(t, 6, sdk.FoldedObservations("test", "int64.sumobserver.sum"))
+}
+
+func TestObserverOverflowLabel(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(
+		processor,
+		metricsdk.WithResource(testResource),
+		metricsdk.WithOverflowLabel(label.String("overflow", "other")),
+	)
+	meter := metric.WrapMeterImpl(accum, "test")
+
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		for i := 0; i < 3; i++ {
+			result.Observe(int64(i+1), label.Int("I", i))
+		}
+	}, metric.WithMaxLabelSets(1))
+
+	accum.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sumobserver.sum/I=0/R=V":            1,
+		"int64.sumobserver.sum/overflow=other/R=V": 2 + 3,
+	}, out.Map())
+	require.Contains(t, testHandler.Flush().Error(), "overflow=other")
+	require.EqualValues(t, 2, accum.FoldedObservations("test", "int64.sumobserver.sum"))
+	require.Zero(t, accum.FoldedObservations("test", "unknown"))
+}
+
+func TestSyncInstrumentMaxLabelSets(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := Must(meter).NewInt64Counter("int64.sum", metric.WithMaxLabelSets(2))
+	for i := 0; i < 4; i++ {
+		counter.Add(ctx, int64(i+1), label.Int("I", i))
+	}
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/I=0/R=V":                       1,
+		"int64.sum/I=1/R=V":                       2,
+		"int64.sum/otel.metric.overflow=true/R=V": 3 + 4,
+	}, out.Map())
+	require.Contains(t, testHandler.Flush().Error(), "2 measurements exceeding 2 label sets")
+	require.EqualValues(t, 2, sdk.FoldedObservations("test", "int64.sum"))
+
+	// Records without updates are removed by the next collection,
+	// making room for new label sets.
+	sdk.Collect(ctx)
+	processor.accumulations = nil
+	counter.Add(ctx, 5, label.Int("I", 2))
+	sdk.Collect(ctx)
+
+	out = processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/I=2/R=V": 5,
+	}, out.Map())
+	require.EqualValues(t, 2, sdk.FoldedObservations("test", "int64.sum"))
+}
+
 // TestRecordPersistence ensures that a direct-called instrument that
 // is repeatedly used each interval results in a persistent record, so
 // that its encoded labels will be cached across collection intervals.
@@ -586,3 +1055,174 @@ func TestSyncInAsync(t *testing.T) {
 		"observer.lastvalue//R=V": 10,
 	}, out.Map())
 }
+
+func TestAsyncCallbackPanicRecovery(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	_ = Must(meter).NewInt64ValueObserver("panicking.lastvalue",
+		func(ctx context.Context, result metric.Int64ObserverResult) {
+			panic("callback")
+		},
+	)
+	_ = Must(meter).NewInt64ValueObserver("observer.lastvalue",
+		func(ctx context.Context, result metric.Int64ObserverResult) {
+			result.Observe(10)
+		},
+	)
+
+	require.NotPanics(t, func() { sdk.Collect(ctx) })
+	err := testHandler.Flush()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "recovered panic of asynchronous instrument callback: callback")
+
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"observer.lastvalue//R=V": 10,
+	}, out.Map())
+
+	opentelemetry.SetPanicRecovery(false)
+	defer opentelemetry.SetPanicRecovery(true)
+	require.PanicsWithValue(t, "callback", func() { sdk.Collect(ctx) })
+}
+
+func TestSelfObservability(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(
+		processor,
+		metricsdk.WithResource(testResource),
+		metricsdk.WithSelfObservability(),
+		// Select the lastvalue aggregator for the instruments of
+		// the Accumulator, whose sums are cumulative.
+		metricsdk.WithMetricNameFormatter(func(_, name string) string {
+			return name + ".lastvalue"
+		}),
+	)
+	meter := metric.WrapMeterImpl(accum, "test")
+
+	counter := Must(meter).NewInt64Counter("counter", metric.WithMaxLabelSets(1))
+	_ = Must(meter).NewInt64ValueObserver("panicking",
+		func(ctx context.Context, result metric.Int64ObserverResult) {
+			panic("callback")
+		},
+	)
+	counter.Add(ctx, 1, label.String("A", "1"))
+	counter.Add(ctx, 1, label.String("A", "2"))
+	counter.Add(ctx, 1, label.String("A", "3"))
+
+	collect := func() map[string]float64 {
+		processor.accumulations = nil
+		accum.Collect(ctx)
+		out := processortest.NewOutput(label.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+
+	// The first collection observes the statistics before it.
+	collect()
+	testHandler.Reset()
+	got := collect()
+	require.Greater(t, got["otel.sdk.metric.collection.duration.lastvalue//R=V"], 0.0)
+	delete(got, "otel.sdk.metric.collection.duration.lastvalue//R=V")
+	require.EqualValues(t, map[string]float64{
+		// The 2 counter records and 4 observations of the first
+		// collection.
+		"otel.sdk.metric.collection.data_points.lastvalue//R=V": 6,
+		"otel.sdk.metric.callback.errors.lastvalue//R=V":        1,
+		"otel.sdk.metric.overflow.measurements.lastvalue//R=V":  2,
+	}, got)
+}
+
+func TestMetricNameFormatter(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(
+		processor,
+		metricsdk.WithResource(testResource),
+		metricsdk.WithMetricNameFormatter(func(_, name string) string {
+			return "prefix." + name
+		}),
+	)
+	meter := metric.WrapMeterImpl(accum, "test")
+	other := metric.WrapMeterImpl(accum, "other")
+
+	counter := Must(meter).NewInt64Counter("int64.sum")
+	require.Equal(t, "prefix.int64.sum", counter.SyncImpl().Descriptor().Name())
+	_ = Must(meter).NewInt64SumObserver("int64.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(5)
+	})
+	require.NoError(t, testHandler.Flush())
+
+	// SetInstrumentEnabled uses the unformatted name.
+	accum.SetInstrumentEnabled("test", "int64.sumobserver.sum", false)
+
+	counter.Add(ctx, 1)
+	accum.Collect(ctx)
+	out := processortest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"prefix.int64.sum//R=V": 1,
+	}, out.Map())
+
+	// The same name in another instrumentation library conflicts.
+	_ = Must(other).NewInt64Counter("int64.sum")
+	err := testHandler.Flush()
+	require.True(t, errors.Is(err, metricsdk.ErrMetricNameConflict))
+	require.Contains(t, err.Error(), `"prefix.int64.sum"`)
+}
+
+func TestInstrumentationFilter(t *testing.T) {
+	ctx := context.Background()
+	testHandler.Reset()
+	processor := &correctnessProcessor{
+		t:            t,
+		testSelector: &testSelector{selector: processortest.AggregatorSelector()},
+	}
+	accum := metricsdk.NewAccumulator(
+		processor,
+		metricsdk.WithResource(testResource),
+		metricsdk.WithInstrumentationFilter(func(name string) bool {
+			return name != "noisy"
+		}),
+	)
+	provider := registry.NewProvider(accum)
+	meter := provider.Meter("test")
+	noisy := provider.Meter("noisy")
+
+	called := false
+	_ = Must(noisy).NewInt64ValueObserver("observer.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			called = true
+			result.Observe(1)
+		},
+	)
+	Must(noisy).NewInt64Counter("counter.sum").Add(ctx, 1)
+	Must(meter).NewInt64Counter("counter.sum").Add(ctx, 1)
+
+	// Registering the instrument of a disabled library again returns
+	// the same no-op instrument without a conflict.
+	_, err := noisy.NewInt64Counter("counter.sum")
+	require.NoError(t, err)
+
+	accum.Collect(ctx)
+	require.False(t, called)
+	require.Equal(t, 1, len(processor.accumulations))
+	require.Equal(t, "test", processor.accumulations[0].Descriptor().InstrumentationName())
+	require.NoError(t, testHandler.Flush())
+}
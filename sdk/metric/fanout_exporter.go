@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/api/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// fanoutExporter is an export.Exporter that exports to multiple
+// exporters concurrently.
+type fanoutExporter struct {
+	exporters []export.Exporter
+
+	// timeout, if positive, bounds the export of each exporter.
+	timeout time.Duration
+}
+
+var _ export.Exporter = (*fanoutExporter)(nil)
+
+// NewFanoutExporter returns an Exporter that exports each checkpoint
+// to all of exps concurrently.  This allows a single controller, and
+// therefore a single Accumulator and Processor, to feed several
+// exporters.
+//
+// The returned Exporter's ExportKindFor combines the ExportKind of
+// every exporter, so a Processor configured with it as its
+// ExportKindSelector maintains the state needed to compute both Delta
+// and Cumulative aggregations when the exporters disagree.  Each
+// exporter then receives records of its own preferred ExportKind.
+//
+// Exporters are isolated from each other: an exporter that returns an
+// error or panics does not prevent the others from exporting, and the
+// returned error combines the errors of all exporters that failed,
+// each identified by its position in exps and its type.  Because the
+// checkpoint must not change while any exporter reads it, Export
+// waits for every exporter to return; exporters must honor the
+// deadline of the passed context.
+func NewFanoutExporter(exps ...export.Exporter) export.Exporter {
+	return NewFanoutExporterWithTimeout(0, exps...)
+}
+
+// NewFanoutExporterWithTimeout returns an Exporter like
+// NewFanoutExporter, except that the context passed to each exporter
+// is canceled after timeout, so that one slow exporter cannot hold up
+// the export, e.g., of a forced collection, for longer than timeout.
+// A timeout that is not positive means no timeout other than the
+// deadline of the context passed to Export.
+func NewFanoutExporterWithTimeout(timeout time.Duration, exps ...export.Exporter) export.Exporter {
+	exporters := make([]export.Exporter, 0, len(exps))
+	for _, e := range exps {
+		if e != nil {
+			exporters = append(exporters, e)
+		}
+	}
+	return &fanoutExporter{exporters: exporters, timeout: timeout}
+}
+
+// ExportKindFor implements export.ExportKindSelector.
+func (f *fanoutExporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) export.ExportKind {
+	var ekind export.ExportKind
+	for _, e := range f.exporters {
+		ekind |= e.ExportKindFor(desc, kind)
+	}
+	return ekind
+}
+
+// Export implements export.Exporter.
+func (f *fanoutExporter) Export(ctx context.Context, cs export.CheckpointSet) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(f.exporters))
+	for i, e := range f.exporters {
+		wg.Add(1)
+		go func(i int, e export.Exporter) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("exporter %d (%T) panicked: %v", i, e, r)
+				}
+			}()
+			if err := f.export(ctx, e, cs); err != nil {
+				errs[i] = fmt.Errorf("exporter %d (%T): %w", i, e, err)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	var failed fanoutError
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return failed
+}
+
+// export exports cs to e, applying the timeout of f.
+func (f *fanoutExporter) export(ctx context.Context, e export.Exporter, cs export.CheckpointSet) error {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+	return e.Export(ctx, cs)
+}
+
+// fanoutError combines the errors returned by multiple exporters.
+type fanoutError []error
+
+func (e fanoutError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the combined errors matches target.
+func (e fanoutError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
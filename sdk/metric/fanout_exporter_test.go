@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+)
+
+func TestFanoutExporterExportKinds(t *testing.T) {
+	ctx := context.Background()
+	cumulative := processortest.NewExporter(export.CumulativeExporter, label.DefaultEncoder())
+	delta := processortest.NewExporter(export.DeltaExporter, label.DefaultEncoder())
+	fanout := metricsdk.NewFanoutExporter(cumulative, nil, delta)
+
+	desc := metric.NewDescriptor("counter.sum", metric.CounterKind, metric.Int64NumberKind)
+	require.Equal(t, export.CumulativeExporter|export.DeltaExporter, fanout.ExportKindFor(&desc, aggregation.SumKind))
+
+	processor := basic.New(processortest.AggregatorSelector(), fanout)
+	accum := metricsdk.NewAccumulator(processor, metricsdk.WithResource(testResource))
+	meter := metric.WrapMeterImpl(accum, "test")
+	counter := Must(meter).NewInt64Counter("counter.sum")
+
+	for i := 1; i <= 3; i++ {
+		cumulative.Reset()
+		delta.Reset()
+
+		counter.Add(ctx, 10, label.String("A", "B"))
+		processor.StartCollection()
+		accum.Collect(ctx)
+		require.NoError(t, processor.FinishCollection())
+		require.NoError(t, fanout.Export(ctx, processor.CheckpointSet()))
+
+		require.EqualValues(t, map[string]float64{
+			"counter.sum/A=B/R=V": float64(i * 10),
+		}, cumulative.Values())
+		require.EqualValues(t, map[string]float64{
+			"counter.sum/A=B/R=V": 10,
+		}, delta.Values())
+	}
+}
+
+type panicExporter struct {
+	export.ExportKind
+}
+
+func (panicExporter) Export(context.Context, export.CheckpointSet) error {
+	panic("boom")
+}
+
+func TestFanoutExporterIsolatesFailures(t *testing.T) {
+	errExport := errors.New("export failed")
+	failing := processortest.NewExporter(export.PassThroughExporter, label.DefaultEncoder())
+	failing.InjectErr = func(export.Record) error { return errExport }
+	ok := processortest.NewExporter(export.PassThroughExporter, label.DefaultEncoder())
+	fanout := metricsdk.NewFanoutExporter(failing, panicExporter{export.PassThroughExporter}, ok)
+
+	ctx := context.Background()
+	processor := basic.New(processortest.AggregatorSelector(), fanout)
+	accum := metricsdk.NewAccumulator(processor, metricsdk.WithResource(testResource))
+	counter := Must(metric.WrapMeterImpl(accum, "test")).NewInt64Counter("counter.sum")
+
+	counter.Add(ctx, 10)
+	processor.StartCollection()
+	accum.Collect(ctx)
+	require.NoError(t, processor.FinishCollection())
+
+	err := fanout.Export(ctx, processor.CheckpointSet())
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errExport))
+	require.Contains(t, err.Error(), "exporter 0 (*processortest.Exporter): export failed")
+	require.Contains(t, err.Error(), "exporter 1 (metric_test.panicExporter) panicked: boom")
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 10,
+	}, ok.Values())
+}
+
+type slowExporter struct {
+	export.ExportKind
+}
+
+func (slowExporter) Export(ctx context.Context, _ export.CheckpointSet) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestFanoutExporterTimeout(t *testing.T) {
+	ok := processortest.NewExporter(export.PassThroughExporter, label.DefaultEncoder())
+	fanout := metricsdk.NewFanoutExporterWithTimeout(10*time.Millisecond, slowExporter{export.PassThroughExporter}, ok)
+
+	ctx := context.Background()
+	processor := basic.New(processortest.AggregatorSelector(), fanout)
+	accum := metricsdk.NewAccumulator(processor, metricsdk.WithResource(testResource))
+	counter := Must(metric.WrapMeterImpl(accum, "test")).NewInt64Counter("counter.sum")
+
+	counter.Add(ctx, 10)
+	processor.StartCollection()
+	accum.Collect(ctx)
+	require.NoError(t, processor.FinishCollection())
+
+	// The slow exporter is canceled without affecting the other.
+	err := fanout.Export(ctx, processor.CheckpointSet())
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.Contains(t, err.Error(), "exporter 0 (metric_test.slowExporter)")
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 10,
+	}, ok.Values())
+}
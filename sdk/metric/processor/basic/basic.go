@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/otel/label"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -35,6 +36,13 @@ type (
 		state
 	}
 
+	// exemplarResetter is implemented by Aggregators that sample
+	// exemplars, see ResetExemplars in the sum and histogram
+	// aggregators.
+	exemplarResetter interface {
+		ResetExemplars()
+	}
+
 	stateKey struct {
 		// TODO: This code is organized to support multiple
 		// accumulators which could theoretically produce the
@@ -63,10 +71,26 @@ type (
 		// Process() called by an accumulator.
 		updated int64
 
+		// lastUpdated is the end of the collection interval in
+		// which this value was last updated, used to forget
+		// stale values after Config.StaleDataRetention.
+		lastUpdated time.Time
+
+		// start is the start of the collection interval in
+		// which this label set was first processed.  Sums
+		// accumulated by this processor begin at this time.
+		start time.Time
+
 		// stateful indicates that a cumulative aggregation is
 		// being maintained, taken from the process start time.
 		stateful bool
 
+		// reset is the start of the collection interval in
+		// which a monotonic precomputed sum was last observed
+		// to decrease, i.e., to have been reset.  It is zero if
+		// no reset has been observed.
+		reset time.Time
+
 		// currentOwned indicates that "current" was allocated
 		// by the processor in order to merge results from
 		// multiple Accumulators during a single collection
@@ -128,7 +152,7 @@ var ErrInvalidExporterKind = fmt.Errorf("invalid exporter kind")
 // data, so that this Processor can prepare to compute Delta or
 // Cumulative Aggregations as needed.
 func New(aselector export.AggregatorSelector, eselector export.ExportKindSelector, opts ...Option) *Processor {
-	now := time.Now()
+	now := internal.Now()
 	p := &Processor{
 		AggregatorSelector: aselector,
 		ExportKindSelector: eselector,
@@ -141,6 +165,9 @@ func New(aselector export.AggregatorSelector, eselector export.ExportKindSelecto
 	for _, opt := range opts {
 		opt.ApplyProcessor(&p.config)
 	}
+	if p.config.StartTimeSource != nil {
+		p.processStart = p.config.StartTimeSource()
+	}
 	return p
 }
 
@@ -166,6 +193,7 @@ func (b *Processor) Process(accum export.Accumulation) error {
 			labels:   accum.Labels(),
 			resource: accum.Resource(),
 			updated:  b.state.finishedCollection,
+			start:    b.state.intervalStart,
 			stateful: stateful,
 			current:  agg,
 		}
@@ -265,7 +293,7 @@ func (b *Processor) StartCollection() {
 // collection has finished and that ForEach will be called to access
 // the CheckpointSet.
 func (b *Processor) FinishCollection() error {
-	b.intervalEnd = time.Now()
+	b.intervalEnd = internal.Now()
 	if b.startedCollection != b.finishedCollection+1 {
 		return ErrInconsistentState
 	}
@@ -276,9 +304,25 @@ func (b *Processor) FinishCollection() error {
 		stale := value.updated != b.finishedCollection
 		stateless := !value.stateful
 
+		if !stale {
+			value.lastUpdated = b.intervalEnd
+		} else if b.config.StaleDataRetention > 0 && b.intervalEnd.Sub(value.lastUpdated) >= b.config.StaleDataRetention {
+			delete(b.values, key)
+			continue
+		}
+
 		// The following branch updates stateful aggregators.  Skip
 		// these updates if the aggregator is not stateful or if the
 		// aggregator is stale.
+		if stale && !stateless && mkind.PrecomputedSum() && value.delta != nil {
+			// Nothing was observed in the most recent
+			// interval, so the delta is zero.  The next
+			// observation is compared with the last one
+			// observed.
+			if err := b.zeroDelta(key.descriptor, value); err != nil {
+				return err
+			}
+		}
 		if stale || stateless {
 			// If this processor does not require memeory,
 			// stale, stateless entries can be removed.
@@ -299,6 +343,16 @@ func (b *Processor) FinishCollection() error {
 				// value.delta = currentSubtractor - value.cumulative
 				err = currentSubtractor.Subtract(value.cumulative, value.delta, key.descriptor)
 
+				if err == nil && mkind.Monotonic() && isNegativeSum(value.delta.Aggregation(), key.descriptor) {
+					// A monotonic sum that decreased was
+					// reset, e.g., by a process restart.
+					// The entire current value accumulated
+					// since the reset.
+					// This line is equivalent to:
+					// value.delta = currentSubtractor
+					err = value.delta.Merge(value.cumulative, key.descriptor)
+					value.reset = b.intervalStart
+				}
 				if err == nil {
 					err = value.current.SynchronizedMove(value.cumulative, key.descriptor)
 				}
@@ -306,6 +360,11 @@ func (b *Processor) FinishCollection() error {
 				err = aggregation.ErrNoSubtraction
 			}
 		} else {
+			// Exemplars of earlier intervals are not
+			// carried into the cumulative value.
+			if r, ok := value.cumulative.(exemplarResetter); ok {
+				r.ResetExemplars()
+			}
 			// This line is equivalent to:
 			// value.cumulative = value.cumulative + value.delta
 			err = value.cumulative.Merge(value.current, key.descriptor)
@@ -329,6 +388,7 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 
 		var agg aggregation.Aggregation
 		var start time.Time
+		var delta bool
 
 		// If the processor does not have Config.Memory and it was not updated
 		// in the prior round, do not visit this value.
@@ -343,9 +403,10 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 			agg = value.current.Aggregation()
 
 			if mkind.PrecomputedSum() {
-				start = b.processStart
+				start = value.cumulativeStart(b.processStart)
 			} else {
 				start = b.intervalStart
+				delta = true
 			}
 
 		case export.CumulativeExporter:
@@ -357,7 +418,14 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 			} else {
 				agg = value.current.Aggregation()
 			}
-			start = b.processStart
+			if mkind.PrecomputedSum() {
+				start = value.cumulativeStart(b.processStart)
+			} else {
+				// The cumulative value was computed by this
+				// processor, beginning when the label set was
+				// first seen.
+				start = value.start
+			}
 
 		case export.DeltaExporter:
 			// Precomputed sums are a special case.
@@ -367,11 +435,26 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 				agg = value.current.Aggregation()
 			}
 			start = b.intervalStart
+			delta = true
 
 		default:
 			return fmt.Errorf("%v: %w", ekind, ErrInvalidExporterKind)
 		}
 
+		if delta && b.config.SuppressZeroDeltas && isZeroSum(agg, key.descriptor) {
+			continue
+		}
+
+		// With Config.Memory, a value that was not updated in
+		// the prior round still holds the checkpoint of an
+		// earlier interval, e.g., the last value of a gauge
+		// that is no longer observed.  It does not describe the
+		// delta interval, so do not visit it.  Precomputed sums
+		// are an exception: their delta was zeroed.
+		if delta && !mkind.PrecomputedSum() && value.updated != (b.finishedCollection-1) {
+			continue
+		}
+
 		if err := f(export.NewRecord(
 			key.descriptor,
 			value.labels,
@@ -385,3 +468,52 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 	}
 	return nil
 }
+
+// zeroDelta sets the delta of a precomputed sum to zero.
+func (b *Processor) zeroDelta(desc *metric.Descriptor, value *stateValue) error {
+	cumulativeSubtractor, ok := value.cumulative.(export.Subtractor)
+	if !ok {
+		return aggregation.ErrNoSubtraction
+	}
+	// This line is equivalent to:
+	// value.delta = value.cumulative - value.cumulative
+	return cumulativeSubtractor.Subtract(value.cumulative, value.delta, desc)
+}
+
+// cumulativeStart returns the start time of a cumulative precomputed
+// sum, which is the time of the last observed reset, if any.
+func (v *stateValue) cumulativeStart(processStart time.Time) time.Time {
+	if v.reset.IsZero() {
+		return processStart
+	}
+	return v.reset
+}
+
+// isNegativeSum returns whether agg is a Sum aggregation with a
+// negative value.
+func isNegativeSum(agg aggregation.Aggregation, desc *metric.Descriptor) bool {
+	s, ok := agg.(aggregation.Sum)
+	if !ok {
+		return false
+	}
+	sum, err := s.Sum()
+	return err == nil && sum.IsNegative(desc.NumberKind())
+}
+
+// isZeroSum returns whether agg is a Sum aggregation with a zero
+// value.  Aggregations that carry more than a sum (e.g., Histogram or
+// MinMaxSumCount) are never considered zero.
+func isZeroSum(agg aggregation.Aggregation, desc *metric.Descriptor) bool {
+	if agg.Kind() != aggregation.SumKind {
+		return false
+	}
+	s, ok := agg.(aggregation.Sum)
+	if !ok {
+		return false
+	}
+	sum, err := s.Sum()
+	if err != nil {
+		return false
+	}
+	return sum.IsZero(desc.NumberKind())
+}
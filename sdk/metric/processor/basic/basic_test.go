@@ -25,10 +25,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/label"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/export/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/internal"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 	"go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	processorTest "go.opentelemetry.io/otel/sdk/metric/processor/processortest"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -226,8 +229,21 @@ func testProcessor(
 					}
 				}
 
+				if repetitionAfterEmptyInterval && mkind.PrecomputedSum() &&
+					ekind == export.DeltaExporter && akind != aggregation.LastValueKind {
+					// Nothing was observed in the empty
+					// interval, the delta is zero.
+					multiplier = 0
+				}
+
+				// Delta exporters do not visit the values
+				// not updated in the empty interval, except
+				// precomputed sums, whose delta is zero.
+				staleDelta := repetitionAfterEmptyInterval && !mkind.PrecomputedSum() &&
+					(ekind == export.DeltaExporter || ekind == export.PassThroughExporter)
+
 				exp := map[string]float64{}
-				if hasMemory || !repetitionAfterEmptyInterval {
+				if (hasMemory && !staleDelta) || !repetitionAfterEmptyInterval {
 					exp = map[string]float64{
 						fmt.Sprintf("inst1%s/L1=V/R=V", instSuffix): float64(multiplier * 10), // labels1
 						fmt.Sprintf("inst2%s/L2=V/R=V", instSuffix): float64(multiplier * 10), // labels2
@@ -428,6 +444,132 @@ func TestStatefulNoMemoryDelta(t *testing.T) {
 	}
 }
 
+func TestInstrumentExportKind(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	ekind := export.NewInstrumentExportKindSelector(
+		export.CumulativeExporter,
+		map[string]export.ExportKind{"delta.sum": export.DeltaExporter},
+	)
+
+	cumulative := metric.NewDescriptor("cumulative.sum", metric.CounterKind, metric.Int64NumberKind)
+	delta := metric.NewDescriptor("delta.sum", metric.CounterKind, metric.Int64NumberKind)
+	selector := processorTest.AggregatorSelector()
+
+	processor := basic.New(selector, ekind)
+	checkpointSet := processor.CheckpointSet()
+
+	for i := 1; i < 3; i++ {
+		processor.StartCollection()
+		_ = processor.Process(updateFor(t, &cumulative, selector, res, 10, label.String("A", "B")))
+		_ = processor.Process(updateFor(t, &delta, selector, res, 10, label.String("A", "B")))
+		require.NoError(t, processor.FinishCollection())
+
+		records := processorTest.NewOutput(label.DefaultEncoder())
+		require.NoError(t, checkpointSet.ForEach(ekind, records.AddRecord))
+		require.EqualValues(t, map[string]float64{
+			"cumulative.sum/A=B/R=V": float64(i * 10),
+			"delta.sum/A=B/R=V":      10,
+		}, records.Map())
+	}
+}
+
+func TestPrecomputedSumDelta(t *testing.T) {
+	type step struct {
+		// observed is nil when nothing is observed.
+		observed   *int64
+		delta      int64
+		cumulative int64
+		reset      bool
+	}
+	obs := func(v int64) *int64 { return &v }
+
+	for _, tc := range []struct {
+		name  string
+		mkind metric.Kind
+		steps []step
+	}{
+		{
+			name:  "monotonic",
+			mkind: metric.SumObserverKind,
+			steps: []step{
+				{observed: obs(10), delta: 10, cumulative: 10},
+				{observed: obs(15), delta: 5, cumulative: 15},
+				{observed: obs(15), delta: 0, cumulative: 15},
+				// The sum decreased: it was reset.
+				{observed: obs(4), delta: 4, cumulative: 4, reset: true},
+				// A missing cycle has a zero delta.
+				{delta: 0, cumulative: 4},
+				{observed: obs(9), delta: 5, cumulative: 9},
+				// Oscillating back to a prior value.
+				{observed: obs(9), delta: 0, cumulative: 9},
+				{observed: obs(20), delta: 11, cumulative: 20},
+				// Reset to zero.
+				{observed: obs(0), delta: 0, cumulative: 0, reset: true},
+			},
+		},
+		{
+			name:  "non-monotonic",
+			mkind: metric.UpDownSumObserverKind,
+			steps: []step{
+				{observed: obs(10), delta: 10, cumulative: 10},
+				// A decrease is not a reset.
+				{observed: obs(4), delta: -6, cumulative: 4},
+				{delta: 0, cumulative: 4},
+				{delta: 0, cumulative: 4},
+				{observed: obs(6), delta: 2, cumulative: 6},
+				{observed: obs(10), delta: 4, cumulative: 10},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := resource.New(label.String("R", "V"))
+			desc := metric.NewDescriptor("inst.sum", tc.mkind, metric.Int64NumberKind)
+			selector := processorTest.AggregatorSelector()
+			processor := basic.New(selector, export.CumulativeExporter|export.DeltaExporter, basic.WithMemory(true))
+			checkpointSet := processor.CheckpointSet()
+
+			collect := func(ekind export.ExportKind) export.Record {
+				var records []export.Record
+				require.NoError(t, checkpointSet.ForEach(ekind, func(r export.Record) error {
+					records = append(records, r)
+					return nil
+				}))
+				require.Len(t, records, 1)
+				return records[0]
+			}
+			sumOf := func(r export.Record) int64 {
+				sum, err := r.Aggregation().(aggregation.Sum).Sum()
+				require.NoError(t, err)
+				return sum.AsInt64()
+			}
+
+			var cumulativeStart time.Time
+			for i, s := range tc.steps {
+				processor.StartCollection()
+				if s.observed != nil {
+					require.NoError(t, processor.Process(updateFor(t, &desc, selector, res, *s.observed)))
+				}
+				require.NoError(t, processor.FinishCollection())
+
+				delta := collect(export.DeltaExporter)
+				cumulative := collect(export.CumulativeExporter)
+				require.Equal(t, s.delta, sumOf(delta), "delta at step %d", i)
+				require.Equal(t, s.cumulative, sumOf(cumulative), "cumulative at step %d", i)
+
+				if i == 0 {
+					cumulativeStart = cumulative.StartTime()
+				}
+				if s.reset {
+					// The cumulative sum restarts with
+					// the interval of the reset.
+					cumulativeStart = delta.StartTime()
+				}
+				require.Equal(t, cumulativeStart, cumulative.StartTime(), "cumulative start at step %d", i)
+			}
+		})
+	}
+}
+
 func TestMultiObserverSum(t *testing.T) {
 	for _, ekind := range []export.ExportKind{
 		export.PassThroughExporter,
@@ -465,3 +607,267 @@ func TestMultiObserverSum(t *testing.T) {
 		}
 	}
 }
+
+func TestSuppressZeroDeltas(t *testing.T) {
+	for _, suppress := range []bool{false, true} {
+		res := resource.New(label.String("R", "V"))
+		ekind := export.DeltaExporter
+
+		desc := metric.NewDescriptor("observe.sum", metric.SumObserverKind, metric.Int64NumberKind)
+		selector := processorTest.AggregatorSelector()
+
+		processor := basic.New(selector, ekind, basic.WithSuppressZeroDeltas(suppress))
+		checkpointSet := processor.CheckpointSet()
+
+		for i, cumulative := range []int64{10, 10, 25} {
+			processor.StartCollection()
+			_ = processor.Process(updateFor(t, &desc, selector, res, cumulative, label.String("A", "B")))
+			require.NoError(t, processor.FinishCollection())
+
+			records := processorTest.NewOutput(label.DefaultEncoder())
+			require.NoError(t, checkpointSet.ForEach(ekind, records.AddRecord))
+
+			switch {
+			case i == 1 && suppress:
+				require.EqualValues(t, map[string]float64{}, records.Map())
+			case i == 1:
+				require.EqualValues(t, map[string]float64{
+					"observe.sum/A=B/R=V": 0,
+				}, records.Map())
+			case i == 2:
+				require.EqualValues(t, map[string]float64{
+					"observe.sum/A=B/R=V": 15,
+				}, records.Map())
+			default:
+				require.EqualValues(t, map[string]float64{
+					"observe.sum/A=B/R=V": 10,
+				}, records.Map())
+			}
+
+			// Cumulative exports are never suppressed.
+			records = processorTest.NewOutput(label.DefaultEncoder())
+			require.NoError(t, checkpointSet.ForEach(export.CumulativeExporter, records.AddRecord))
+			require.EqualValues(t, map[string]float64{
+				"observe.sum/A=B/R=V": float64(cumulative),
+			}, records.Map())
+		}
+	}
+}
+
+func TestCumulativeStartTimePerLabelSet(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	ekind := export.CumulativeExporter
+
+	desc := metric.NewDescriptor("inst.sum", metric.CounterKind, metric.Int64NumberKind)
+	selector := processorTest.AggregatorSelector()
+
+	processor := basic.New(selector, ekind)
+	checkpointSet := processor.CheckpointSet()
+
+	starts := func() map[string]time.Time {
+		out := map[string]time.Time{}
+		require.NoError(t, checkpointSet.ForEach(ekind, func(rec export.Record) error {
+			out[rec.Labels().Encoded(label.DefaultEncoder())] = rec.StartTime()
+			return nil
+		}))
+		return out
+	}
+
+	processor.StartCollection()
+	_ = processor.Process(updateFor(t, &desc, selector, res, 10, label.String("A", "B")))
+	require.NoError(t, processor.FinishCollection())
+	first := starts()
+
+	var firstEnd time.Time
+	require.NoError(t, checkpointSet.ForEach(ekind, func(rec export.Record) error {
+		firstEnd = rec.EndTime()
+		return nil
+	}))
+
+	processor.StartCollection()
+	_ = processor.Process(updateFor(t, &desc, selector, res, 10, label.String("A", "B")))
+	_ = processor.Process(updateFor(t, &desc, selector, res, 10, label.String("C", "D")))
+	require.NoError(t, processor.FinishCollection())
+	second := starts()
+
+	// The existing label set keeps its start time, while the new
+	// label set starts when it was first collected.
+	require.Equal(t, first["A=B"], second["A=B"])
+	require.Equal(t, firstEnd, second["C=D"])
+	require.True(t, second["A=B"].Before(second["C=D"]))
+}
+
+func TestCumulativeExemplars(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	ekind := export.CumulativeExporter
+
+	desc := metric.NewDescriptor("inst.sum", metric.CounterKind, metric.Int64NumberKind)
+	selector := processorTest.AggregatorSelector()
+
+	processor := basic.New(selector, ekind)
+	checkpointSet := processor.CheckpointSet()
+
+	collect := func(spanID trace.SpanID) []aggregation.Exemplar {
+		var agg export.Aggregator
+		selector.AggregatorFor(&desc, &agg)
+		ctx := aggregatortest.ContextWithSampledSpan(context.Background(), trace.ID{1}, spanID)
+		require.NoError(t, agg.Update(ctx, metric.NewInt64Number(1), &desc))
+
+		ls := label.NewSet(label.String("A", "B"))
+		processor.StartCollection()
+		require.NoError(t, processor.Process(export.NewAccumulation(&desc, &ls, res, agg)))
+		require.NoError(t, processor.FinishCollection())
+
+		var exemplars []aggregation.Exemplar
+		require.NoError(t, checkpointSet.ForEach(ekind, func(rec export.Record) error {
+			var err error
+			exemplars, err = rec.Aggregation().(aggregation.Exemplars).Exemplars()
+			return err
+		}))
+		return exemplars
+	}
+
+	first := collect(trace.SpanID{1})
+	require.Len(t, first, 1)
+	require.Equal(t, trace.SpanID{1}, first[0].SpanID)
+
+	// The cumulative sum reports only the exemplars of the
+	// latest interval.
+	second := collect(trace.SpanID{2})
+	require.Len(t, second, 1)
+	require.Equal(t, trace.SpanID{2}, second[0].SpanID)
+}
+
+func TestStaleDataRetention(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	ekind := export.CumulativeExporter
+
+	desc := metric.NewDescriptor("inst.sum", metric.CounterKind, metric.Int64NumberKind)
+	selector := processorTest.AggregatorSelector()
+
+	processor := basic.New(selector, ekind, basic.WithMemory(true), basic.WithStaleDataRetention(time.Minute))
+	checkpointSet := processor.CheckpointSet()
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	defer internal.SetClockAnchor(start)()
+	collect := func(at time.Duration, kvs ...label.KeyValue) map[string]float64 {
+		internal.SetClockAnchor(start.Add(at))
+		processor.StartCollection()
+		for _, kv := range kvs {
+			_ = processor.Process(updateFor(t, &desc, selector, res, 10, kv))
+		}
+		require.NoError(t, processor.FinishCollection())
+
+		records := processorTest.NewOutput(label.DefaultEncoder())
+		require.NoError(t, checkpointSet.ForEach(ekind, records.AddRecord))
+		return records.Map()
+	}
+
+	require.EqualValues(t, map[string]float64{
+		"inst.sum/A=B/R=V": 10,
+	}, collect(0, label.String("A", "B")))
+
+	// A=B is retained until it was not updated for a minute.
+	require.EqualValues(t, map[string]float64{
+		"inst.sum/A=B/R=V": 10,
+		"inst.sum/C=D/R=V": 10,
+	}, collect(30*time.Second, label.String("C", "D")))
+
+	require.EqualValues(t, map[string]float64{
+		"inst.sum/C=D/R=V": 20,
+	}, collect(90*time.Second, label.String("C", "D")))
+
+	// A forgotten label set starts over.
+	require.EqualValues(t, map[string]float64{
+		"inst.sum/A=B/R=V": 10,
+		"inst.sum/C=D/R=V": 20,
+	}, collect(100*time.Second, label.String("A", "B")))
+}
+
+func TestStartTimeSource(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	ekind := export.CumulativeExporter
+	// The delta state is needed to detect resets.
+	kinds := export.CumulativeExporter | export.DeltaExporter
+	selector := processorTest.AggregatorSelector()
+
+	observer := metric.NewDescriptor("observer.sum", metric.SumObserverKind, metric.Int64NumberKind)
+	counter := metric.NewDescriptor("counter.sum", metric.CounterKind, metric.Int64NumberKind)
+
+	persisted := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	source := func() time.Time { return persisted }
+
+	starts := func(p *basic.Processor, values ...int64) map[string]time.Time {
+		p.StartCollection()
+		for _, v := range values {
+			require.NoError(t, p.Process(updateFor(t, &observer, selector, res, v)))
+		}
+		require.NoError(t, p.Process(updateFor(t, &counter, selector, res, 1)))
+		require.NoError(t, p.FinishCollection())
+
+		out := map[string]time.Time{}
+		require.NoError(t, p.CheckpointSet().ForEach(ekind, func(rec export.Record) error {
+			out[rec.Descriptor().Name()] = rec.StartTime()
+			return nil
+		}))
+		return out
+	}
+
+	// Processors created at different times share the start time
+	// of observed sums.
+	first := starts(basic.New(selector, kinds, basic.WithStartTimeSource(source)), 10)
+	second := starts(basic.New(selector, kinds, basic.WithStartTimeSource(source)), 10)
+	require.Equal(t, persisted, first["observer.sum"])
+	require.Equal(t, persisted, second["observer.sum"])
+
+	// Sums computed by the processor start when first collected.
+	require.True(t, first["counter.sum"].After(persisted))
+
+	// A reset moves the start time forward.
+	p := basic.New(selector, kinds, basic.WithStartTimeSource(source))
+	require.Equal(t, persisted, starts(p, 10)["observer.sum"])
+	require.True(t, starts(p, 5)["observer.sum"].After(persisted))
+}
+
+func TestDeltaLastValueStaleness(t *testing.T) {
+	res := resource.New(label.String("R", "V"))
+	desc := metric.NewDescriptor("gauge.lastvalue", metric.ValueObserverKind, metric.Int64NumberKind)
+	selector := processorTest.AggregatorSelector()
+	processor := basic.New(selector, export.CumulativeExporter|export.DeltaExporter, basic.WithMemory(true))
+	checkpointSet := processor.CheckpointSet()
+
+	collect := func(ekind export.ExportKind, values ...int64) []export.Record {
+		processor.StartCollection()
+		for _, v := range values {
+			require.NoError(t, processor.Process(updateFor(t, &desc, selector, res, v)))
+		}
+		require.NoError(t, processor.FinishCollection())
+
+		var records []export.Record
+		require.NoError(t, checkpointSet.ForEach(ekind, func(r export.Record) error {
+			records = append(records, r)
+			return nil
+		}))
+		return records
+	}
+
+	first := collect(export.DeltaExporter, 10)
+	require.Len(t, first, 1)
+	second := collect(export.DeltaExporter, 20)
+	require.Len(t, second, 1)
+	// The start time of a delta gauge is the start of its interval.
+	require.Equal(t, first[0].EndTime(), second[0].StartTime())
+
+	// The gauge was not observed: delta exporters drop it, while
+	// cumulative exporters report the last value.
+	require.Empty(t, collect(export.DeltaExporter))
+	var records []export.Record
+	require.NoError(t, checkpointSet.ForEach(export.CumulativeExporter, func(r export.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+	require.Len(t, records, 1)
+	last, _, err := records[0].Aggregation().(aggregation.LastValue).LastValue()
+	require.NoError(t, err)
+	require.Equal(t, int64(20), last.AsInt64())
+}
@@ -14,6 +14,8 @@
 
 package basic // import "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 
+import "time"
+
 // Config contains the options for configuring a basic metric processor.
 type Config struct {
 	// Memory controls whether the processor remembers metric
@@ -21,6 +23,27 @@ type Config struct {
 	// When Memory is true, CheckpointSet.ForEach() will visit
 	// metrics that were not updated in the most recent interval.
 	Memory bool
+
+	// SuppressZeroDeltas controls whether the processor omits
+	// delta Sum aggregations whose value is zero.  When true,
+	// CheckpointSet.ForEach() will not visit label sets of a
+	// delta-exported Sum that did not change in the most recent
+	// interval.
+	SuppressZeroDeltas bool
+
+	// StaleDataRetention is the duration after which label sets
+	// that were not updated are forgotten, including the cumulative
+	// state the processor maintains for them.  When zero, the
+	// default, cumulative state and, with Memory, label sets are
+	// retained forever.
+	StaleDataRetention time.Duration
+
+	// StartTimeSource, if not nil, is called once by New to obtain
+	// the start time of cumulative precomputed sums, i.e., of the
+	// values of SumObserver and UpDownSumObserver instruments that
+	// were not reset.  When nil, the default, the time New is
+	// called is used.
+	StartTimeSource func() time.Time
 }
 
 type Option interface {
@@ -40,3 +63,54 @@ type memoryOption bool
 func (m memoryOption) ApplyProcessor(config *Config) {
 	config.Memory = bool(m)
 }
+
+// WithSuppressZeroDeltas sets whether a Processor omits zero-valued
+// Sum aggregations from delta exports.  This reduces export volume
+// for sparse counters with many label sets.  Only Sum aggregations
+// are affected; cumulative exports are unchanged.
+func WithSuppressZeroDeltas(suppress bool) Option {
+	return suppressZeroDeltasOption(suppress)
+}
+
+type suppressZeroDeltasOption bool
+
+func (s suppressZeroDeltasOption) ApplyProcessor(config *Config) {
+	config.SuppressZeroDeltas = bool(s)
+}
+
+// WithStaleDataRetention sets the duration after which a Processor
+// forgets label sets that were not updated.  This bounds the memory
+// used by cumulative exports and by Memory when label sets come and
+// go, e.g., per request or per client labels.  A label set that is
+// updated again after it was forgotten restarts its cumulative
+// aggregation from zero, with a new start time.
+func WithStaleDataRetention(d time.Duration) Option {
+	return staleDataRetentionOption(d)
+}
+
+type staleDataRetentionOption time.Duration
+
+func (s staleDataRetentionOption) ApplyProcessor(config *Config) {
+	config.StaleDataRetention = time.Duration(s)
+}
+
+// WithStartTimeSource sets the function a Processor calls to obtain
+// the start time of cumulative precomputed sums.  Without it, each
+// Processor uses the time it was created, so processors created at
+// different times, or after a restart of the process, report
+// different start times for the same observed sum, e.g., a counter
+// maintained by the operating system.  A source returning a time
+// shared by all processors, possibly persisted across restarts,
+// keeps this start time stable for backends computing rates from
+// cumulative values.
+//
+// Observed resets of a sum still move its start time forward.
+func WithStartTimeSource(source func() time.Time) Option {
+	return startTimeSourceOption(source)
+}
+
+type startTimeSourceOption func() time.Time
+
+func (s startTimeSourceOption) ApplyProcessor(config *Config) {
+	config.StartTimeSource = s
+}
@@ -33,6 +33,16 @@ type (
 	LabelFilterSelector interface {
 		LabelFilterFor(descriptor *metric.Descriptor) label.Filter
 	}
+
+	// LabelKeysSelector may be implemented by a LabelFilterSelector
+	// whose Filter only keeps an allow-list of label keys.  The
+	// Processor then passes accumulations whose labels all have
+	// allowed keys through unchanged, without filtering their label
+	// set.  The Filter returned by LabelFilterFor must keep exactly
+	// the labels whose key is returned by LabelKeysFor.
+	LabelKeysSelector interface {
+		LabelKeysFor(descriptor *metric.Descriptor) []label.Key
+	}
 )
 
 var _ export.Processor = &Processor{}
@@ -49,6 +59,12 @@ func New(filterSelector LabelFilterSelector, ckpter export.Checkpointer) *Proces
 
 // Process implements export.Processor.
 func (p *Processor) Process(accum export.Accumulation) error {
+	if ks, ok := p.filterSelector.(LabelKeysSelector); ok {
+		if accum.Labels().HasOnlyKeys(ks.LabelKeysFor(accum.Descriptor())...) {
+			return p.Checkpointer.Process(accum)
+		}
+	}
+
 	// Note: the removed labels are returned and ignored here.
 	// Conceivably these inputs could be useful to a sampler.
 	reduced, _ := accum.Labels().Filter(
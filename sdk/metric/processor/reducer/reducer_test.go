@@ -115,3 +115,48 @@ func TestFilterBasicProcessor(t *testing.T) {
 		"observer.sum/A=1,C=3/R=V": 20,
 	}, exporter.Values())
 }
+
+type testKeysFilter struct {
+	filtered *int
+}
+
+func (f testKeysFilter) LabelFilterFor(_ *metric.Descriptor) label.Filter {
+	return func(label label.KeyValue) bool {
+		*f.filtered++
+		return label.Key == "A" || label.Key == "C"
+	}
+}
+
+func (testKeysFilter) LabelKeysFor(_ *metric.Descriptor) []label.Key {
+	return []label.Key{"A", "C"}
+}
+
+func TestLabelKeysSelector(t *testing.T) {
+	testProc := processorTest.NewProcessor(
+		processorTest.AggregatorSelector(),
+		label.DefaultEncoder(),
+	)
+	var filtered int
+	accum := metricsdk.NewAccumulator(
+		reducer.New(testKeysFilter{&filtered}, processorTest.Checkpointer(testProc)),
+		metricsdk.WithResource(
+			resource.New(label.String("R", "V")),
+		),
+	)
+	ctx := context.Background()
+	meter := metric.WrapMeterImpl(accum, "testing")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+
+	// Labels with allowed keys only are not filtered.
+	counter.Add(ctx, 1, label.Int("A", 1), label.Int("C", 3))
+	accum.Collect(ctx)
+	require.Equal(t, 0, filtered)
+
+	counter.Add(ctx, 10, kvs1...)
+	accum.Collect(ctx)
+	require.Equal(t, 3, filtered)
+
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=1,C=3/R=V": 11,
+	}, testProc.Values())
+}
@@ -17,18 +17,23 @@ package metric
 import (
 	"context"
 	"fmt"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/metric"
 	api "go.opentelemetry.io/otel/api/metric"
 	internal "go.opentelemetry.io/otel/api/metric/metrictest"
 	"go.opentelemetry.io/otel/label"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	sdkinternal "go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/unit"
 )
 
 type (
@@ -42,6 +47,12 @@ type (
 	// timer to call Collect() periodically.  Pull-based processors
 	// will call Collect() when a pull request arrives.
 	Accumulator struct {
+		// self holds the statistics reported by the
+		// self-observability instruments.  Its fields are
+		// accessed atomically, so it must be the first field
+		// to be 64-bit aligned on 32-bit platforms.
+		self selfStats
+
 		// current maps `mapkey` to *record.
 		current sync.Map
 
@@ -67,9 +78,83 @@ type (
 
 		// resource is applied to all records in this Accumulator.
 		resource *resource.Resource
+
+		// nonFinitePolicy determines how NaN and Inf
+		// measurements are handled.
+		nonFinitePolicy NonFinitePolicy
+
+		// validateUnits enables the validation of instrument
+		// units when instruments are created.
+		validateUnits bool
+
+		// validateMonotonic enables the validation of the
+		// observations of SumObserver instruments.
+		validateMonotonic bool
+
+		// nameFormatter, if not nil, returns the exported name
+		// of instruments.
+		nameFormatter func(instrumentationName, name string) string
+
+		// instrumentationFilter, if not nil, returns whether
+		// the instruments of an instrumentation library are
+		// enabled.
+		instrumentationFilter func(instrumentationName string) bool
+
+		// formattedNames maps the names returned by
+		// nameFormatter to the instrumentKey they were first
+		// returned for, to detect conflicts.
+		formattedNames sync.Map
+
+		// overflowLabels is the label set of measurements that
+		// exceed the MaxLabelSets of their instrument.
+		overflowLabels *label.Set
+
+		// shutdown is set to 1 by Shutdown.
+		shutdown int32
+
+		// instrumentStates maps an instrumentKey to the
+		// *instrumentState shared by all instruments with
+		// that instrumentation and instrument name.
+		instrumentStates sync.Map
+	}
+
+	// instrumentKey identifies instruments by their
+	// instrumentation name and instrument name.
+	instrumentKey struct {
+		instrumentationName string
+		name                string
+	}
+
+	// instrumentState holds the runtime state of instruments that
+	// is controlled by SetInstrumentEnabled.
+	instrumentState struct {
+		// folded counts the observations folded into the
+		// overflow label set.  It is accessed atomically and
+		// must be the first field to be 64-bit aligned on
+		// 32-bit platforms.
+		folded int64
+
+		// disabled is non-zero when measurements are dropped.
+		// It is accessed atomically.
+		disabled int32
+
+		// unvalidated is non-zero when the observations are
+		// not validated.  It is accessed atomically.
+		unvalidated int32
 	}
 
 	syncInstrument struct {
+		// labelSets counts the records of the instrument in
+		// the Accumulator.current map, excluding the overflow
+		// set.  It is accessed atomically, so it must precede
+		// instrument, as must overflowed, to be 64-bit aligned
+		// on 32-bit platforms.
+		labelSets int64
+		// overflowed counts the measurements folded into the
+		// overflow set since the last collection.  It is
+		// accessed atomically.
+		overflowed int64
+
 		instrument
 	}
 
@@ -125,6 +210,19 @@ type (
 	instrument struct {
 		meter      *Accumulator
 		descriptor metric.Descriptor
+
+		// filter removes labels whose keys are not among the
+		// descriptor's recommended label keys.  It is nil when
+		// all labels are kept.
+		filter label.Filter
+
+		// labels are the descriptor's instrumentation labels,
+		// added to every measurement.
+		labels []label.KeyValue
+
+		// state is shared with the Accumulator, which may
+		// disable the instrument at runtime.
+		state *instrumentState
 	}
 
 	asyncInstrument struct {
@@ -132,12 +230,24 @@ type (
 		// recorders maps ordered labels to the pair of
 		// labelset and recorder
 		recorders map[label.Distinct]*labeledRecorder
+
+		// observedSets counts the distinct label sets observed
+		// in the current collection, excluding the overflow set.
+		observedSets int
+		// overflowed counts the observations folded into the
+		// overflow set in the current collection.
+		overflowed int
 	}
 
 	labeledRecorder struct {
 		observedEpoch int64
 		labels        *label.Set
 		observed      export.Aggregator
+
+		// last is the last observation of the label set,
+		// retained when observations are validated.
+		last    api.Number
+		hasLast bool
 	}
 )
 
@@ -148,6 +258,25 @@ var (
 	_ api.BoundSyncImpl = &record{}
 
 	ErrUninitializedInstrument = fmt.Errorf("use of an uninitialized instrument")
+
+	// ErrShutdown is returned when creating an asynchronous
+	// instrument after the Accumulator was shut down.
+	ErrShutdown = fmt.Errorf("the Accumulator is shut down")
+
+	// ErrDecreasingObservation is reported when an observation of
+	// a SumObserver instrument is lower than the previous one and
+	// Config.ValidateMonotonicObservations is enabled.
+	ErrDecreasingObservation = fmt.Errorf("observation of a monotonic sum decreased")
+
+	// ErrMetricNameConflict is reported when the MetricNameFormatter
+	// gives instruments of different instrumentation or name the
+	// same name.
+	ErrMetricNameConflict = fmt.Errorf("conflicting formatted metric names")
+
+	// DefaultOverflowLabel is the label of measurements that exceed
+	// the MaxLabelSets of their instrument, unless another label is
+	// configured with WithOverflowLabel.
+	DefaultOverflowLabel = label.Bool("otel.metric.overflow", true)
 )
 
 func (inst *instrument) Descriptor() api.Descriptor {
@@ -162,12 +291,117 @@ func (s *syncInstrument) Implementation() interface{} {
 	return s
 }
 
+func newInstrument(m *Accumulator, descriptor api.Descriptor) instrument {
+	if m.validateUnits {
+		if err := unit.Validate(descriptor.Unit()); err != nil {
+			global.Handle(otel.WithSeverity(fmt.Errorf("instrument %q: %w", descriptor.Name(), err), otel.SeverityWarn))
+		}
+	}
+	// The state is shared by instrument name, as passed to
+	// SetInstrumentEnabled, regardless of formatting.
+	state := m.instrumentState(descriptor.InstrumentationName(), descriptor.Name())
+	if m.nameFormatter != nil {
+		descriptor = m.formatName(descriptor)
+	}
+	return instrument{
+		descriptor: descriptor,
+		meter:      m,
+		filter:     newLabelKeysFilter(descriptor.LabelKeys(), descriptor.InstrumentationLabels()),
+		labels:     descriptor.InstrumentationLabels(),
+		state:      state,
+	}
+}
+
+// formatName returns descriptor renamed by the configured
+// MetricNameFormatter, reporting a conflict if instruments of different
+// instrumentation or name are given the same name.
+func (m *Accumulator) formatName(descriptor api.Descriptor) api.Descriptor {
+	key := instrumentKey{
+		instrumentationName: descriptor.InstrumentationName(),
+		name:                descriptor.Name(),
+	}
+	name := m.nameFormatter(key.instrumentationName, key.name)
+	if actual, loaded := m.formattedNames.LoadOrStore(name, key); loaded && actual.(instrumentKey) != key {
+		other := actual.(instrumentKey)
+		global.Handle(fmt.Errorf("%w: %q of %q and %q of %q are both named %q",
+			ErrMetricNameConflict, key.name, key.instrumentationName, other.name, other.instrumentationName, name))
+	}
+	return descriptor.Renamed(name)
+}
+
+// enabled returns false if the instrument was disabled using
+// SetInstrumentEnabled or its Accumulator was shut down.
+func (inst *instrument) enabled() bool {
+	return atomic.LoadInt32(&inst.state.disabled) == 0 && !inst.meter.isShutdown()
+}
+
+// newLabelKeysFilter returns a label.Filter that keeps only the given
+// keys and the keys of the instrumentation labels, or nil when keys is
+// empty.
+func newLabelKeysFilter(keys []label.Key, labels []label.KeyValue) label.Filter {
+	if len(keys) == 0 {
+		return nil
+	}
+	allowed := make(map[label.Key]struct{}, len(keys)+len(labels))
+	for _, k := range keys {
+		allowed[k] = struct{}{}
+	}
+	for _, kv := range labels {
+		allowed[kv.Key] = struct{}{}
+	}
+	return func(kv label.KeyValue) bool {
+		_, ok := allowed[kv.Key]
+		return ok
+	}
+}
+
+// withLabels returns kvs preceded by the instrumentation labels, so
+// that labels in kvs take precedence.
+func (inst *instrument) withLabels(kvs []label.KeyValue) []label.KeyValue {
+	if len(inst.labels) == 0 {
+		return kvs
+	}
+	all := make([]label.KeyValue, 0, len(inst.labels)+len(kvs))
+	all = append(all, inst.labels...)
+	return append(all, kvs...)
+}
+
+// sharesLabels returns true if the instrument records measurements with
+// exactly the labels passed, allowing their label set to be shared in a
+// batch.
+func (inst *instrument) sharesLabels() bool {
+	return inst.filter == nil && len(inst.labels) == 0
+}
+
 func (a *asyncInstrument) observe(number api.Number, labels *label.Set) {
-	if err := aggregator.RangeTest(number, &a.descriptor); err != nil {
+	if !a.enabled() {
+		return
+	}
+	if len(a.labels) != 0 {
+		merged := label.NewSet(a.withLabels(labels.ToSlice())...)
+		labels = &merged
+	}
+	if a.filter != nil {
+		filtered, _ := labels.Filter(a.filter)
+		labels = &filtered
+	}
+	number, err := a.meter.rangeTest(number, &a.descriptor)
+	if err != nil {
 		global.Handle(err)
 		return
 	}
-	recorder := a.getRecorder(labels)
+	validate := a.validatesMonotonic()
+	if validate {
+		if err := a.monotonicTest(number, labels); err != nil {
+			global.Handle(err)
+			return
+		}
+	}
+	overflow := a.overflows(labels)
+	if overflow {
+		labels = a.meter.overflowLabels
+	}
+	recorder := a.getRecorder(labels, overflow)
 	if recorder == nil {
 		// The instrument is disabled according to the
 		// AggregatorSelector.
@@ -177,15 +411,66 @@ func (a *asyncInstrument) observe(number api.Number, labels *label.Set) {
 		global.Handle(err)
 		return
 	}
+	if validate && !overflow {
+		lrec := a.recorders[labels.Equivalent()]
+		lrec.last, lrec.hasLast = number, true
+	}
 }
 
-func (a *asyncInstrument) getRecorder(labels *label.Set) export.Aggregator {
+// validatesMonotonic returns true if the observations of the
+// instrument must not decrease and are validated.
+func (a *asyncInstrument) validatesMonotonic() bool {
+	return a.meter.validateMonotonic &&
+		a.descriptor.MetricKind() == api.SumObserverKind &&
+		atomic.LoadInt32(&a.state.unvalidated) == 0
+}
+
+// monotonicTest returns ErrDecreasingObservation if number is lower
+// than the last observation of labels.
+func (a *asyncInstrument) monotonicTest(number api.Number, labels *label.Set) error {
+	lrec, ok := a.recorders[labels.Equivalent()]
+	if !ok || !lrec.hasLast {
+		return nil
+	}
+	kind := a.descriptor.NumberKind()
+	if number.CompareNumber(kind, lrec.last) >= 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: instrument %q: %s < %s",
+		ErrDecreasingObservation, a.descriptor.Name(), number.Emit(kind), lrec.last.Emit(kind))
+}
+
+// overflows returns true if an observation with labels exceeds the
+// instrument's MaxLabelSets in the current collection, counting the
+// observation towards the limit otherwise.
+func (a *asyncInstrument) overflows(labels *label.Set) bool {
+	max := a.descriptor.MaxLabelSets()
+	if max <= 0 {
+		return false
+	}
+	if lrec, ok := a.recorders[labels.Equivalent()]; ok && lrec.observedEpoch == a.meter.currentEpoch {
+		return false
+	}
+	if a.observedSets < max {
+		a.observedSets++
+		return false
+	}
+	a.overflowed++
+	return true
+}
+
+// getRecorder returns the aggregator for labels in the current
+// collection.  Observations of the same labels replace one another
+// unless accumulate is true, as for the overflow set.
+func (a *asyncInstrument) getRecorder(labels *label.Set, accumulate bool) export.Aggregator {
 	lrec, ok := a.recorders[labels.Equivalent()]
 	if ok {
 		if lrec.observedEpoch == a.meter.currentEpoch {
-			// last value wins for Observers, so if we see the same labels
-			// in the current epoch, we replace the old recorder
-			a.meter.processor.AggregatorFor(&a.descriptor, &lrec.observed)
+			if !accumulate {
+				// last value wins for Observers, so if we see the same labels
+				// in the current epoch, we replace the old recorder
+				a.meter.processor.AggregatorFor(&a.descriptor, &lrec.observed)
+			}
 		} else {
 			lrec.observedEpoch = a.meter.currentEpoch
 		}
@@ -222,7 +507,7 @@ func (s *syncInstrument) acquireHandle(kvs []label.KeyValue, labelPtr *label.Set
 		// needed for the `sortSlice` field, to avoid an
 		// allocation while sorting.
 		rec = &record{}
-		rec.storage = label.NewSetWithSortable(kvs, &rec.sortSlice)
+		rec.storage, _ = label.NewSetWithSortableFiltered(s.withLabels(kvs), &rec.sortSlice, s.filter)
 		rec.labels = &rec.storage
 		equiv = rec.storage.Equivalent()
 	} else {
@@ -247,6 +532,16 @@ func (s *syncInstrument) acquireHandle(kvs []label.KeyValue, labelPtr *label.Set
 		// This entry is no longer mapped, try to add a new entry.
 	}
 
+	// A new record is needed: count it towards the MaxLabelSets
+	// of the instrument, or fold the measurement into the overflow
+	// set.
+	overflow := labelPtr == s.meter.overflowLabels
+	if !overflow && !s.reserveLabelSet() {
+		atomic.AddInt64(&s.overflowed, 1)
+		atomic.AddInt64(&s.state.folded, 1)
+		return s.acquireHandle(nil, s.meter.overflowLabels)
+	}
+
 	if rec == nil {
 		rec = &record{}
 		rec.labels = labelPtr
@@ -266,6 +561,9 @@ func (s *syncInstrument) acquireHandle(kvs []label.KeyValue, labelPtr *label.Set
 			if oldRec.refMapped.ref() {
 				// At this moment it is guaranteed that the entry is in
 				// the map and will not be removed.
+				if !overflow {
+					s.releaseLabelSet()
+				}
 				return oldRec
 			}
 			// This loaded entry is marked as unmapped (so Collect will remove
@@ -286,11 +584,34 @@ func (s *syncInstrument) acquireHandle(kvs []label.KeyValue, labelPtr *label.Set
 	}
 }
 
+// reserveLabelSet counts a new record towards the instrument's
+// MaxLabelSets.  It returns false if the limit is reached.
+func (s *syncInstrument) reserveLabelSet() bool {
+	n := atomic.AddInt64(&s.labelSets, 1)
+	if max := s.descriptor.MaxLabelSets(); max > 0 && n > int64(max) {
+		atomic.AddInt64(&s.labelSets, -1)
+		return false
+	}
+	return true
+}
+
+// releaseLabelSet stops counting a record towards the instrument's
+// MaxLabelSets.
+func (s *syncInstrument) releaseLabelSet() {
+	atomic.AddInt64(&s.labelSets, -1)
+}
+
 func (s *syncInstrument) Bind(kvs []label.KeyValue) api.BoundSyncImpl {
+	if s.meter.isShutdown() {
+		return api.NoopSync{}.Bind(kvs)
+	}
 	return s.acquireHandle(kvs, nil)
 }
 
 func (s *syncInstrument) RecordOne(ctx context.Context, number api.Number, kvs []label.KeyValue) {
+	if !s.enabled() {
+		return
+	}
 	h := s.acquireHandle(kvs, nil)
 	defer h.Unbind()
 	h.RecordOne(ctx, number)
@@ -311,30 +632,71 @@ func NewAccumulator(processor export.Processor, opts ...Option) *Accumulator {
 		opt.Apply(c)
 	}
 
-	return &Accumulator{
-		processor:        processor,
-		asyncInstruments: internal.NewAsyncInstrumentState(),
-		resource:         c.Resource,
+	overflow := c.OverflowLabel
+	if !overflow.Key.Defined() {
+		overflow = DefaultOverflowLabel
+	}
+	overflowLabels := label.NewSet(overflow)
+
+	m := &Accumulator{
+		processor:         processor,
+		resource:          c.Resource,
+		nonFinitePolicy:   c.NonFinitePolicy,
+		validateUnits:     c.ValidateUnits,
+		validateMonotonic: c.ValidateMonotonicObservations,
+		nameFormatter:     c.MetricNameFormatter,
+		overflowLabels:    &overflowLabels,
+
+		instrumentationFilter: c.InstrumentationFilter,
 	}
+	m.asyncInstruments = m.newAsyncInstrumentState()
+	if c.SelfObservability {
+		m.registerSelfObservability()
+	}
+	return m
+}
+
+// newAsyncInstrumentState returns the state of the asynchronous
+// instruments of m.  Panics of their callbacks are recovered unless
+// panic recovery is disabled.
+func (m *Accumulator) newAsyncInstrumentState() *internal.AsyncInstrumentState {
+	a := internal.NewAsyncInstrumentState()
+	a.SetPanicHandler(func(recovered interface{}) {
+		atomic.AddInt64(&m.self.callbackErrors, 1)
+		sdkinternal.HandlePanic(recovered, "asynchronous instrument callback")
+	})
+	return a
 }
 
-// NewSyncInstrument implements api.MetricImpl.
+// NewSyncInstrument implements api.MetricImpl.  Synchronous
+// instruments created after Shutdown are no-ops, as are those of the
+// instrumentation libraries disabled by Config.InstrumentationFilter.
 func (m *Accumulator) NewSyncInstrument(descriptor api.Descriptor) (api.SyncImpl, error) {
+	if m.isShutdown() {
+		return api.NoopSync{}, nil
+	}
+	if m.filtered(descriptor) {
+		return filteredSync{descriptor: descriptor}, nil
+	}
 	return &syncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: newInstrument(m, descriptor),
 	}, nil
 }
 
-// NewAsyncInstrument implements api.MetricImpl.
+// NewAsyncInstrument implements api.MetricImpl.  It returns
+// ErrShutdown after Shutdown, without registering runner.  The
+// instruments of the instrumentation libraries disabled by
+// Config.InstrumentationFilter are no-ops whose runner is never
+// called.
 func (m *Accumulator) NewAsyncInstrument(descriptor api.Descriptor, runner metric.AsyncRunner) (api.AsyncImpl, error) {
+	if m.isShutdown() {
+		return nil, ErrShutdown
+	}
+	if m.filtered(descriptor) {
+		return filteredAsync{descriptor: descriptor}, nil
+	}
 	a := &asyncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: newInstrument(m, descriptor),
 	}
 	m.asyncLock.Lock()
 	defer m.asyncLock.Unlock()
@@ -342,6 +704,71 @@ func (m *Accumulator) NewAsyncInstrument(descriptor api.Descriptor, runner metri
 	return a, nil
 }
 
+// filtered returns whether the instrumentation library of descriptor
+// is disabled by Config.InstrumentationFilter.
+func (m *Accumulator) filtered(descriptor api.Descriptor) bool {
+	return m.instrumentationFilter != nil && !m.instrumentationFilter(descriptor.InstrumentationName())
+}
+
+// filteredSync is the no-op synchronous instrument of a disabled
+// instrumentation library.  Unlike api.NoopSync, it keeps its
+// descriptor so that registering the instrument again is not reported
+// as a conflict.
+type filteredSync struct {
+	api.NoopSync
+	descriptor api.Descriptor
+}
+
+func (f filteredSync) Descriptor() api.Descriptor {
+	return f.descriptor
+}
+
+// filteredAsync is the no-op asynchronous instrument of a disabled
+// instrumentation library, see filteredSync.
+type filteredAsync struct {
+	api.NoopAsync
+	descriptor api.Descriptor
+}
+
+func (f filteredAsync) Descriptor() api.Descriptor {
+	return f.descriptor
+}
+
+// Shutdown shuts the Accumulator down.  Afterwards, measurements of
+// existing instruments are dropped without locking, new synchronous
+// instruments are no-ops, new asynchronous instruments are not created
+// and ErrShutdown is returned instead, and Collect does nothing.  The
+// records and asynchronous instrument callbacks held by the
+// Accumulator are released so that their state can be garbage
+// collected.
+//
+// Shutdown does not collect; controllers collect one last time before
+// shutting their Accumulator down.  Subsequent calls have no effect.
+func (m *Accumulator) Shutdown() {
+	if !atomic.CompareAndSwapInt32(&m.shutdown, 0, 1) {
+		return
+	}
+	m.collectLock.Lock()
+	defer m.collectLock.Unlock()
+
+	m.current.Range(func(key, _ interface{}) bool {
+		m.current.Delete(key)
+		return true
+	})
+	m.instrumentStates.Range(func(key, _ interface{}) bool {
+		m.instrumentStates.Delete(key)
+		return true
+	})
+
+	m.asyncLock.Lock()
+	defer m.asyncLock.Unlock()
+	m.asyncInstruments = m.newAsyncInstrumentState()
+}
+
+func (m *Accumulator) isShutdown() bool {
+	return atomic.LoadInt32(&m.shutdown) != 0
+}
+
 // Collect traverses the list of active records and observers and
 // exports data for each active instrument.  Collect() may not be
 // called concurrently.
@@ -351,14 +778,45 @@ func (m *Accumulator) NewAsyncInstrument(descriptor api.Descriptor, runner metri
 //
 // Returns the number of records that were checkpointed.
 func (m *Accumulator) Collect(ctx context.Context) int {
+	checkpointed, _ := m.CollectWithTimings(ctx)
+	return checkpointed
+}
+
+// CollectTimings is the timing breakdown of a collection of an
+// Accumulator.
+type CollectTimings struct {
+	// Start is the time the collection started.
+	Start time.Time
+	// Callbacks is the time spent running the callbacks of the
+	// asynchronous instruments.
+	Callbacks time.Duration
+	// Aggregation is the time spent checkpointing the aggregators
+	// and passing them to the Processor.
+	Aggregation time.Duration
+}
+
+// CollectWithTimings performs a collection like Collect and also
+// returns its timing breakdown, to diagnose slow collections.
+func (m *Accumulator) CollectWithTimings(ctx context.Context) (int, CollectTimings) {
 	m.collectLock.Lock()
 	defer m.collectLock.Unlock()
 
-	checkpointed := m.observeAsyncInstruments(ctx)
+	timings := CollectTimings{Start: sdkinternal.Now()}
+	if m.isShutdown() {
+		return 0, timings
+	}
+
+	checkpointed, callbacks := m.observeAsyncInstruments(ctx)
 	checkpointed += m.collectSyncInstruments()
 	m.currentEpoch++
 
-	return checkpointed
+	elapsed := sdkinternal.Now().Sub(timings.Start)
+	timings.Callbacks = callbacks
+	timings.Aggregation = elapsed - callbacks
+
+	atomic.StoreInt64(&m.self.collectionNanos, int64(elapsed))
+	atomic.AddInt64(&m.self.dataPoints, int64(checkpointed))
+	return checkpointed, timings
 }
 
 func (m *Accumulator) collectSyncInstruments() int {
@@ -368,6 +826,10 @@ func (m *Accumulator) collectSyncInstruments() int {
 		// Note: always continue to iterate over the entire
 		// map by returning `true` in this function.
 		inuse := value.(*record)
+		overflow := inuse.labels == m.overflowLabels
+		if overflow {
+			m.reportFolded(inuse.inst)
+		}
 
 		mods := atomic.LoadInt64(&inuse.updateCount)
 		coll := inuse.collectedCount
@@ -390,6 +852,9 @@ func (m *Accumulator) collectSyncInstruments() int {
 		// entry in the map, they are busy calling Gosched() awaiting
 		// this deletion:
 		m.current.Delete(inuse.mapkey())
+		if !overflow {
+			inuse.inst.releaseLabelSet()
+		}
 
 		// There's a potential race between `LoadInt64` and
 		// `tryUnmap` in this function.  Since this is the
@@ -415,14 +880,19 @@ func (m *Accumulator) CollectAsync(kv []label.KeyValue, obs ...metric.Observatio
 	}
 }
 
-func (m *Accumulator) observeAsyncInstruments(ctx context.Context) int {
+// observeAsyncInstruments runs the callbacks of the asynchronous
+// instruments and checkpoints their observations.  It returns the
+// number of records checkpointed and the time spent in the callbacks.
+func (m *Accumulator) observeAsyncInstruments(ctx context.Context) (int, time.Duration) {
 	m.asyncLock.Lock()
 	defer m.asyncLock.Unlock()
 
 	asyncCollected := 0
 
 	// TODO: change this to `ctx` (in a separate PR, with tests)
+	start := sdkinternal.Now()
 	m.asyncInstruments.Run(context.Background(), m)
+	callbacks := sdkinternal.Now().Sub(start)
 
 	for _, inst := range m.asyncInstruments.Instruments() {
 		if a := m.fromAsync(inst); a != nil {
@@ -430,7 +900,7 @@ func (m *Accumulator) observeAsyncInstruments(ctx context.Context) int {
 		}
 	}
 
-	return asyncCollected
+	return asyncCollected, callbacks
 }
 
 func (m *Accumulator) checkpointRecord(r *record) int {
@@ -451,7 +921,26 @@ func (m *Accumulator) checkpointRecord(r *record) int {
 	return 1
 }
 
+// reportFolded reports the measurements of a synchronous instrument
+// folded into the overflow set since the last collection.
+func (m *Accumulator) reportFolded(s *syncInstrument) {
+	if n := atomic.SwapInt64(&s.overflowed, 0); n != 0 {
+		atomic.AddInt64(&m.self.folded, n)
+		global.Handle(fmt.Errorf("%s: %d measurements exceeding %d label sets were folded into %s",
+			s.descriptor.Name(), n, s.descriptor.MaxLabelSets(), m.overflowLabels.Encoded(label.DefaultEncoder())))
+	}
+}
+
 func (m *Accumulator) checkpointAsync(a *asyncInstrument) int {
+	if a.overflowed != 0 {
+		atomic.AddInt64(&a.state.folded, int64(a.overflowed))
+		atomic.AddInt64(&m.self.folded, int64(a.overflowed))
+		global.Handle(fmt.Errorf("%s: %d observations exceeding %d label sets were folded into %s",
+			a.descriptor.Name(), a.overflowed, a.descriptor.MaxLabelSets(), m.overflowLabels.Encoded(label.DefaultEncoder())))
+	}
+	a.observedSets = 0
+	a.overflowed = 0
+
 	if len(a.recorders) == 0 {
 		return 0
 	}
@@ -486,17 +975,26 @@ func (m *Accumulator) RecordBatch(ctx context.Context, kvs []label.KeyValue, mea
 	// Labels will be computed the first time acquireHandle is
 	// called.  Subsequent calls to acquireHandle will re-use the
 	// previously computed value instead of recomputing the
-	// ordered labels.
+	// ordered labels.  Instruments that filter or add labels
+	// compute their own label set and do not share it.
 	var labelsPtr *label.Set
-	for i, meas := range measurements {
+	for _, meas := range measurements {
 		s := m.fromSync(meas.SyncImpl())
-		if s == nil {
+		if s == nil || !s.enabled() {
+			continue
+		}
+		if !s.sharesLabels() {
+			h := s.acquireHandle(kvs, nil)
+			defer h.Unbind()
+			h.RecordOne(ctx, meas.Number())
 			continue
 		}
 		h := s.acquireHandle(kvs, labelsPtr)
 
-		// Re-use labels for the next measurement.
-		if i == 0 {
+		// Re-use labels for the next measurement, unless the
+		// measurement was folded into the overflow set of its
+		// instrument.
+		if labelsPtr == nil && h.labels != m.overflowLabels {
 			labelsPtr = h.labels
 		}
 
@@ -511,7 +1009,11 @@ func (r *record) RecordOne(ctx context.Context, number api.Number) {
 		// The instrument is disabled according to the AggregatorSelector.
 		return
 	}
-	if err := aggregator.RangeTest(number, &r.inst.descriptor); err != nil {
+	if !r.inst.enabled() {
+		return
+	}
+	number, err := r.inst.meter.rangeTest(number, &r.inst.descriptor)
+	if err != nil {
 		global.Handle(err)
 		return
 	}
@@ -548,6 +1050,75 @@ func (m *Accumulator) fromSync(sync metric.SyncImpl) *syncInstrument {
 	return nil
 }
 
+// SetInstrumentEnabled enables or disables the instruments with the
+// given instrumentation name and instrument name, including instruments
+// created later.  Measurements of a disabled instrument are dropped at
+// little cost, while measurements already aggregated are still
+// collected.  This allows an operator to silence a costly instrument
+// without restarting the process.  Instruments are enabled by default.
+func (m *Accumulator) SetInstrumentEnabled(instrumentationName, name string, enabled bool) {
+	var disabled int32
+	if !enabled {
+		disabled = 1
+	}
+	atomic.StoreInt32(&m.instrumentState(instrumentationName, name).disabled, disabled)
+}
+
+// SetInstrumentValidation enables or disables the validation of the
+// observations of the instruments with the given instrumentation name
+// and instrument name, including instruments created later, when
+// Config.ValidateMonotonicObservations is enabled.  This allows
+// instruments observing sums that may legitimately reset to opt out.
+// Observations are validated by default.
+func (m *Accumulator) SetInstrumentValidation(instrumentationName, name string, validate bool) {
+	var unvalidated int32
+	if !validate {
+		unvalidated = 1
+	}
+	atomic.StoreInt32(&m.instrumentState(instrumentationName, name).unvalidated, unvalidated)
+}
+
+// FoldedObservations returns the number of measurements of the
+// instruments with the given instrumentation name and instrument name
+// that were folded into the overflow label set because they exceeded
+// the MaxLabelSets of the instrument, since the Accumulator was
+// created.
+func (m *Accumulator) FoldedObservations(instrumentationName, name string) int64 {
+	return atomic.LoadInt64(&m.instrumentState(instrumentationName, name).folded)
+}
+
+// instrumentState returns the state of the instruments with the given
+// instrumentation name and instrument name.
+func (m *Accumulator) instrumentState(instrumentationName, name string) *instrumentState {
+	key := instrumentKey{instrumentationName: instrumentationName, name: name}
+	if state, ok := m.instrumentStates.Load(key); ok {
+		return state.(*instrumentState)
+	}
+	state, _ := m.instrumentStates.LoadOrStore(key, &instrumentState{})
+	return state.(*instrumentState)
+}
+
+// rangeTest validates a measurement before it is aggregated, applying
+// the configured NonFinitePolicy.  It returns the number to aggregate.
+func (m *Accumulator) rangeTest(number api.Number, descriptor *api.Descriptor) (api.Number, error) {
+	if descriptor.NumberKind() == api.Float64NumberKind {
+		value := number.AsFloat64()
+		switch m.nonFinitePolicy {
+		case ClampNonFinite:
+			if math.IsInf(value, +1) {
+				number = api.NewFloat64Number(math.MaxFloat64)
+			} else if math.IsInf(value, -1) {
+				number = api.NewFloat64Number(-math.MaxFloat64)
+			}
+		case RecordNonFinite:
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return number, aggregator.SignTest(number, descriptor)
+			}
+		}
+	}
+	return number, aggregator.RangeTest(number, descriptor)
+}
+
 // fromSync gets an async implementation object, checking for
 // uninitialized instruments and instruments created by another SDK.
 func (m *Accumulator) fromAsync(async metric.AsyncImpl) *asyncInstrument {
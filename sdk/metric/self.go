@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/api/metric"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// SelfObservabilityInstrumentationName is the instrumentation name of
+// the instruments through which an Accumulator configured with
+// WithSelfObservability reports its own health.
+const SelfObservabilityInstrumentationName = "otel.sdk.metric"
+
+// selfStats holds the statistics of an Accumulator reported by its
+// self-observability instruments.  Its fields are accessed atomically.
+type selfStats struct {
+	// collectionNanos is the duration of the last collection.
+	collectionNanos int64
+	// dataPoints counts the records checkpointed by collections.
+	dataPoints int64
+	// callbackErrors counts the asynchronous instrument callbacks
+	// that panicked.
+	callbackErrors int64
+	// folded counts the measurements and observations folded into
+	// the overflow label set because their instrument exceeded its
+	// MaxLabelSets.
+	folded int64
+}
+
+// registerSelfObservability registers the asynchronous instruments
+// reporting the statistics of m.  The instruments are observed during
+// collections, so the duration observed is that of the previous
+// collection.
+func (m *Accumulator) registerSelfObservability() {
+	meter := metric.WrapMeterImpl(m, SelfObservabilityInstrumentationName)
+	var duration metric.Float64ValueObserver
+	var dataPoints, callbackErrors, folded metric.Int64SumObserver
+	batch := metric.Must(meter).NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		result.Observe(nil,
+			duration.Observation(time.Duration(atomic.LoadInt64(&m.self.collectionNanos)).Seconds()),
+			dataPoints.Observation(atomic.LoadInt64(&m.self.dataPoints)),
+			callbackErrors.Observation(atomic.LoadInt64(&m.self.callbackErrors)),
+			folded.Observation(atomic.LoadInt64(&m.self.folded)),
+		)
+	})
+	duration = batch.NewFloat64ValueObserver("otel.sdk.metric.collection.duration",
+		metric.WithDescription("Duration of the previous collection"),
+		metric.WithUnit(unit.Seconds))
+	dataPoints = batch.NewInt64SumObserver("otel.sdk.metric.collection.data_points",
+		metric.WithDescription("Data points collected"),
+		metric.WithUnit(unit.Dimensionless))
+	callbackErrors = batch.NewInt64SumObserver("otel.sdk.metric.callback.errors",
+		metric.WithDescription("Asynchronous instrument callbacks that panicked"),
+		metric.WithUnit(unit.Errors))
+	folded = batch.NewInt64SumObserver("otel.sdk.metric.overflow.measurements",
+		metric.WithDescription("Measurements folded into the overflow label set because their instrument exceeded its label set limit"),
+		metric.WithUnit(unit.Dimensionless))
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry // import "go.opentelemetry.io/otel/sdk"
+
+import "sync/atomic"
+
+// panicRecovery is non-zero when panics of user code are recovered.
+var panicRecovery int32 = 1
+
+// SetPanicRecovery sets whether the SDK recovers panics of the user code
+// it calls: Samplers, SpanProcessors, span exporters, asynchronous
+// metric instrument callbacks and metric exporters called by a push
+// controller.  A recovered panic is reported to the global error
+// handler with its stack, and the SDK continues as if the call failed,
+// e.g., a panicking Sampler drops the span.
+//
+// Recovery is enabled by default.  Disable it to fail fast, e.g., in
+// tests: the panics are then not recovered and crash the program.
+func SetPanicRecovery(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&panicRecovery, v)
+}
+
+// PanicRecovery returns whether the SDK recovers panics of the user
+// code it calls, see SetPanicRecovery.
+func PanicRecovery() bool {
+	return atomic.LoadInt32(&panicRecovery) != 0
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+// defaultPodInfoDir is the default mount path of the downward API volume
+// read by FromKubernetes.
+const defaultPodInfoDir = "/etc/podinfo"
+
+// serviceAccountNamespaceFile contains the namespace of the pod in every
+// container that mounts a service account token.
+var serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// kubernetesSources maps resource keys to the environment variable and
+// the file name in the downward API volume they are read from.
+var kubernetesSources = []struct {
+	key    label.Key
+	envVar string
+	file   string
+}{
+	{semconv.K8SPodNameKey, "K8S_POD_NAME", "pod_name"},
+	{semconv.K8SPodUIDKey, "K8S_POD_UID", "pod_uid"},
+	{semconv.K8SNamespaceNameKey, "K8S_NAMESPACE_NAME", "namespace_name"},
+	{semconv.K8SNodeNameKey, "K8S_NODE_NAME", "node_name"},
+}
+
+// FromKubernetes is a detector that implements the Detector and collects
+// resources describing the Kubernetes pod the process runs in.
+//
+// Each of the k8s.pod.name, k8s.pod.uid, k8s.namespace.name and
+// k8s.node.name attributes is read from the first of these sources
+// that has a value:
+//
+//   - the K8S_POD_NAME, K8S_POD_UID, K8S_NAMESPACE_NAME and
+//     K8S_NODE_NAME environment variables, which can be set using the
+//     downward API,
+//   - the pod_name, pod_uid, namespace_name and node_name files of a
+//     downward API volume mounted at PodInfoDir, and
+//   - for the namespace only, the namespace file of the mounted
+//     service account.
+//
+// Attributes without a source are omitted.  No error is returned when
+// the process does not run in Kubernetes.
+type FromKubernetes struct {
+	// PodInfoDir is the directory a downward API volume is
+	// mounted at.  If empty, "/etc/podinfo" is used.
+	PodInfoDir string
+}
+
+// compile time assertion that FromKubernetes implements Detector interface
+var _ Detector = (*FromKubernetes)(nil)
+
+// Detect collects resources from the Kubernetes downward API.
+func (d *FromKubernetes) Detect(context.Context) (*Resource, error) {
+	dir := d.PodInfoDir
+	if dir == "" {
+		dir = defaultPodInfoDir
+	}
+
+	var labels []label.KeyValue
+	var invalid []string
+	for _, src := range kubernetesSources {
+		value := strings.TrimSpace(os.Getenv(src.envVar))
+		if value == "" {
+			v, err := readPodInfoFile(filepath.Join(dir, src.file))
+			if err != nil {
+				invalid = append(invalid, err.Error())
+			}
+			value = v
+		}
+		if value == "" && src.key == semconv.K8SNamespaceNameKey {
+			v, err := readPodInfoFile(serviceAccountNamespaceFile)
+			if err != nil {
+				invalid = append(invalid, err.Error())
+			}
+			value = v
+		}
+		if value != "" {
+			labels = append(labels, src.key.String(value))
+		}
+	}
+
+	var err error
+	if len(invalid) > 0 {
+		err = fmt.Errorf("%w: %v", ErrPartialResource, invalid)
+	}
+	return New(labels...), err
+}
+
+// readPodInfoFile returns the trimmed content of the file at path, or
+// an empty string if it does not exist.
+func readPodInfoFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/semconv"
+)
+
+func TestDetectKubernetes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podinfo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pod_name"), []byte("from-file\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "pod_uid"), []byte("uid-1234\n"), 0600))
+	nsFile := filepath.Join(dir, "serviceaccount-namespace")
+	require.NoError(t, ioutil.WriteFile(nsFile, []byte("default"), 0600))
+
+	orig := serviceAccountNamespaceFile
+	serviceAccountNamespaceFile = nsFile
+	defer func() { serviceAccountNamespaceFile = orig }()
+
+	// The environment takes precedence over files.
+	os.Setenv("K8S_POD_NAME", "from-env")
+	defer os.Unsetenv("K8S_POD_NAME")
+
+	detector := &FromKubernetes{PodInfoDir: dir}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, New(
+		semconv.K8SPodNameKey.String("from-env"),
+		semconv.K8SPodUIDKey.String("uid-1234"),
+		semconv.K8SNamespaceNameKey.String("default"),
+	), res)
+}
+
+func TestDetectKubernetesNotInPod(t *testing.T) {
+	orig := serviceAccountNamespaceFile
+	serviceAccountNamespaceFile = filepath.Join(os.TempDir(), "does-not-exist")
+	defer func() { serviceAccountNamespaceFile = orig }()
+
+	detector := &FromKubernetes{PodInfoDir: filepath.Join(os.TempDir(), "does-not-exist")}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, New(), res)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+)
+
+// lazyDetector is a Detector that defers detection to the first use
+// of the detected Resource.
+type lazyDetector struct {
+	detector Detector
+	timeout  time.Duration
+}
+
+var _ Detector = lazyDetector{}
+
+// Lazy returns a Detector that defers the detection of d until the
+// attributes of the Resource it detects are first used, typically when
+// telemetry is first exported.  This avoids blocking the setup of a
+// process on detectors that are slow or whose information is not
+// available yet, e.g. cloud instance metadata.
+//
+// The detection is done once and its result is cached.  It is bounded
+// by timeout, if it is positive, even if d does not honor the deadline
+// of its context; the context passed to Detect is not used as it may
+// be done by then.  Errors are reported to the global error handler.
+// As with Detect, the Resource of an ErrPartialResource error is used
+// while any other error results in an empty Resource.
+//
+// The returned Detector never fails and Merging its Resource with
+// others results in a Resource that is itself lazily evaluated.
+func Lazy(d Detector, timeout time.Duration) Detector {
+	return lazyDetector{detector: d, timeout: timeout}
+}
+
+// Detect returns a Resource whose attributes are detected on first use.
+func (l lazyDetector) Detect(context.Context) (*Resource, error) {
+	return newLazy(l.detect), nil
+}
+
+func (l lazyDetector) detect() *Resource {
+	if l.timeout <= 0 {
+		return l.result(l.detector.Detect(context.Background()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	type detected struct {
+		res *Resource
+		err error
+	}
+	// The channel is buffered so that the goroutine of a detector
+	// that does not honor ctx does not leak once it returns.
+	done := make(chan detected, 1)
+	go func() {
+		res, err := l.detector.Detect(ctx)
+		done <- detected{res, err}
+	}()
+	select {
+	case d := <-done:
+		return l.result(d.res, d.err)
+	case <-ctx.Done():
+		return l.result(nil, ctx.Err())
+	}
+}
+
+// result returns the Resource to use given the result of a detection.
+func (l lazyDetector) result(res *Resource, err error) *Resource {
+	if err != nil {
+		global.Handle(fmt.Errorf("lazily detecting resource: %w", err))
+		if !errors.Is(err, ErrPartialResource) {
+			return Empty()
+		}
+	}
+	if res == nil {
+		return Empty()
+	}
+	return res
+}
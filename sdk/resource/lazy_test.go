@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (f detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return f(ctx)
+}
+
+func TestLazyDetectsOnFirstUse(t *testing.T) {
+	var calls int32
+	d := resource.Lazy(detectorFunc(func(context.Context) (*resource.Resource, error) {
+		atomic.AddInt32(&calls, 1)
+		return resource.New(kv21), nil
+	}), 0)
+
+	res, err := resource.Detect(context.Background(), d, detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.New(kv11), nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "detected before first use")
+
+	assert.Equal(t, []label.KeyValue{kv11, kv21}, res.Attributes())
+	assert.Equal(t, 2, res.Len())
+	assert.True(t, res.Equal(resource.New(kv11, kv21)))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "detection not cached")
+}
+
+func TestLazyMergePrecedence(t *testing.T) {
+	lazy, err := resource.Lazy(detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.New(kv12, kv31), nil
+	}), 0).Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []label.KeyValue{kv11, kv31}, resource.Merge(resource.New(kv11), lazy).Attributes())
+	assert.Equal(t, []label.KeyValue{kv12, kv31}, resource.Merge(lazy, resource.New(kv11)).Attributes())
+}
+
+func TestLazyErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		detector resource.Detector
+		timeout  time.Duration
+		expected []label.KeyValue
+	}{
+		{
+			name: "error",
+			detector: detectorFunc(func(context.Context) (*resource.Resource, error) {
+				return resource.New(kv11), errors.New("unavailable")
+			}),
+			expected: nil,
+		},
+		{
+			name: "partial",
+			detector: detectorFunc(func(context.Context) (*resource.Resource, error) {
+				return resource.New(kv11), fmt.Errorf("%w: missing k2", resource.ErrPartialResource)
+			}),
+			expected: []label.KeyValue{kv11},
+		},
+		{
+			name: "timeout",
+			detector: detectorFunc(func(context.Context) (*resource.Resource, error) {
+				// Does not honor the context.
+				time.Sleep(time.Second)
+				return resource.New(kv11), nil
+			}),
+			timeout:  10 * time.Millisecond,
+			expected: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := resource.Lazy(tc.detector, tc.timeout).Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res.Attributes())
+		})
+	}
+}
+
+func TestLazyConcurrentUse(t *testing.T) {
+	var calls int32
+	res, err := resource.Lazy(detectorFunc(func(context.Context) (*resource.Resource, error) {
+		atomic.AddInt32(&calls, 1)
+		return resource.New(kv11), nil
+	}), 0).Detect(context.Background())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			assert.Equal(t, resource.New(kv11).Equivalent(), res.Equivalent())
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -15,6 +15,8 @@
 package resource
 
 import (
+	"sync"
+
 	"go.opentelemetry.io/otel/label"
 )
 
@@ -25,8 +27,47 @@ import (
 // Resources should be passed and stored as pointers
 // (`*resource.Resource`).  The `nil` value is equivalent to an empty
 // Resource.
+//
+// The attributes of a Resource returned by a Lazy Detector, or merged
+// from one, are computed the first time they are used and do not
+// change afterwards.
 type Resource struct {
 	labels label.Set
+
+	// lazy, if not nil, computes the attributes of the Resource
+	// instead of labels.
+	lazy *lazyResource
+}
+
+// lazyResource computes a Resource once, when it is first needed.
+type lazyResource struct {
+	once    sync.Once
+	resolve func() *Resource
+	res     *Resource
+}
+
+func newLazy(resolve func() *Resource) *Resource {
+	return &Resource{lazy: &lazyResource{resolve: resolve}}
+}
+
+// resource returns the computed Resource, computing it if needed.
+func (l *lazyResource) resource() *Resource {
+	l.once.Do(func() {
+		l.res = l.resolve()
+		l.resolve = nil
+	})
+	return l.res
+}
+
+// set returns the attributes of r, computing them if needed.
+func (r *Resource) set() *label.Set {
+	if r == nil {
+		return &emptyResource.labels
+	}
+	if r.lazy != nil {
+		return r.lazy.resource().set()
+	}
+	return &r.labels
 }
 
 var emptyResource Resource
@@ -49,25 +90,19 @@ func (r *Resource) String() string {
 	if r == nil {
 		return ""
 	}
-	return r.labels.Encoded(label.DefaultEncoder())
+	return r.set().Encoded(label.DefaultEncoder())
 }
 
 // Attributes returns a copy of attributes from the resource in a sorted order.
 // To avoid allocating a new slice, use an iterator.
 func (r *Resource) Attributes() []label.KeyValue {
-	if r == nil {
-		r = Empty()
-	}
-	return r.labels.ToSlice()
+	return r.set().ToSlice()
 }
 
 // Iter returns an interator of the Resource attributes.
 // This is ideal to use if you do not want a copy of the attributes.
 func (r *Resource) Iter() label.Iterator {
-	if r == nil {
-		r = Empty()
-	}
-	return r.labels.Iter()
+	return r.set().Iter()
 }
 
 // Equal returns true when a Resource is equivalent to this Resource.
@@ -95,7 +130,15 @@ func Merge(a, b *Resource) *Resource {
 	if b == nil {
 		return a
 	}
+	if a.lazy != nil || b.lazy != nil {
+		return newLazy(func() *Resource { return merge(a, b) })
+	}
+	return merge(a, b)
+}
 
+// merge combines the attributes of resources a and b, neither of which
+// is nil, with the attributes of a taking precedence.
+func merge(a, b *Resource) *Resource {
 	// Note: 'a' labels will overwrite 'b' with last-value-wins in label.Key()
 	// Meaning this is equivalent to: append(b.Attributes(), a.Attributes()...)
 	mi := label.NewMergeIterator(a.LabelSet(), b.LabelSet())
@@ -121,27 +164,19 @@ func (r *Resource) Equivalent() label.Distinct {
 
 // LabelSet returns the equivalent *label.Set.
 func (r *Resource) LabelSet() *label.Set {
-	if r == nil {
-		r = Empty()
-	}
-	return &r.labels
+	return r.set()
 }
 
-// MarshalJSON encodes labels as a JSON list of { "Key": "...", "Value": ... }
-// pairs in order sorted by key.
+// MarshalJSON returns the JSON encoding of the Resource, the same as
+// that of its label Set: a list of labels sorted by key using the
+// structure of OTLP KeyValues.
 func (r *Resource) MarshalJSON() ([]byte, error) {
-	if r == nil {
-		r = Empty()
-	}
-	return r.labels.MarshalJSON()
+	return r.set().MarshalJSON()
 }
 
 // Len returns the number of unique key-values in this Resource.
 func (r *Resource) Len() int {
-	if r == nil {
-		return 0
-	}
-	return r.labels.Len()
+	return r.set().Len()
 }
 
 // Encoded returns an encoded representation of the resource by
@@ -151,5 +186,5 @@ func (r *Resource) Encoded(enc label.Encoder) string {
 	if r == nil {
 		return ""
 	}
-	return r.labels.Encoded(enc)
+	return r.set().Encoded(enc)
 }
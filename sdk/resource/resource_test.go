@@ -218,6 +218,6 @@ func TestMarshalJSON(t *testing.T) {
 	data, err := json.Marshal(r)
 	require.NoError(t, err)
 	require.Equal(t,
-		`[{"Key":"A","Value":{"Type":"INT64","Value":1}},{"Key":"C","Value":{"Type":"STRING","Value":"D"}}]`,
+		`[{"key":"A","value":{"intValue":"1"}},{"key":"C","value":{"stringValue":"D"}}]`,
 		string(data))
 }
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+var (
+	// processIDOnce guards the generation of processID.
+	processIDOnce sync.Once
+	processID     string
+	processIDErr  error
+)
+
+// NewServiceResource returns a Resource identifying a service with the
+// service.name, service.version and service.instance.id attributes.
+// An empty version is omitted.
+//
+// If instanceID is empty, a random UUID generated once per process is
+// used, so that all the Resources returned in a process identify the
+// same instance.
+//
+// The attributes of the OTEL_RESOURCE_ATTRIBUTES environment variable
+// take precedence, so that operators can override the identity set in
+// code.  If the variable contains invalid values, a Resource is
+// returned without them along with an error wrapping
+// ErrPartialResource.
+func NewServiceResource(name, version, instanceID string) (*Resource, error) {
+	if instanceID == "" {
+		var err error
+		if instanceID, err = processInstanceID(); err != nil {
+			return nil, fmt.Errorf("generating service.instance.id: %w", err)
+		}
+	}
+	kvs := []label.KeyValue{
+		semconv.ServiceNameKey.String(name),
+		semconv.ServiceInstanceIDKey.String(instanceID),
+	}
+	if version != "" {
+		kvs = append(kvs, semconv.ServiceVersionKey.String(version))
+	}
+
+	env, err := (&FromEnv{}).Detect(context.Background())
+	return Merge(env, New(kvs...)), err
+}
+
+// processInstanceID returns the random UUID identifying the instance
+// of the service running in this process.
+func processInstanceID() (string, error) {
+	processIDOnce.Do(func() {
+		processID, processIDErr = newUUID()
+	})
+	return processID, processIDErr
+}
+
+// newUUID returns a random (version 4) UUID as defined in RFC 4122.
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // Version 4.
+	u[8] = (u[8] & 0x3f) | 0x80 // Variant RFC 4122.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/semconv"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewServiceResource(t *testing.T) {
+	os.Setenv(envVar, "")
+
+	res, err := NewServiceResource("checkout", "1.2.3", "instance-1")
+	require.NoError(t, err)
+	assert.Equal(t, New(
+		semconv.ServiceNameKey.String("checkout"),
+		semconv.ServiceVersionKey.String("1.2.3"),
+		semconv.ServiceInstanceIDKey.String("instance-1"),
+	), res)
+}
+
+func TestNewServiceResourceGeneratedInstanceID(t *testing.T) {
+	os.Setenv(envVar, "")
+
+	res, err := NewServiceResource("checkout", "", "")
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Len())
+
+	id, ok := res.LabelSet().Value(semconv.ServiceInstanceIDKey)
+	require.True(t, ok)
+	assert.Regexp(t, uuidPattern, id.AsString())
+
+	// The generated ID is the same for the whole process.
+	other, err := NewServiceResource("other", "", "")
+	require.NoError(t, err)
+	otherID, _ := other.LabelSet().Value(semconv.ServiceInstanceIDKey)
+	assert.Equal(t, id, otherID)
+}
+
+func TestNewServiceResourceEnvOverride(t *testing.T) {
+	os.Setenv(envVar, "service.name=from-env,deployment.environment=prod,invalid")
+	defer os.Setenv(envVar, "")
+
+	res, err := NewServiceResource("checkout", "1.2.3", "instance-1")
+	require.True(t, errors.Is(err, ErrPartialResource))
+	assert.Equal(t, New(
+		semconv.ServiceNameKey.String("from-env"),
+		semconv.ServiceVersionKey.String("1.2.3"),
+		semconv.ServiceInstanceIDKey.String("instance-1"),
+		label.String("deployment.environment", "prod"),
+	), res)
+}
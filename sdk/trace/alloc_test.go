@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/label"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TestAllocationBudget guards the number of allocations of the span hot
+// path against regressions.  Lower the budgets when an optimization
+// reduces the number of allocations; raising them needs a reason.
+func TestAllocationBudget(t *testing.T) {
+	tests := []struct {
+		name string
+		// budget is the maximum number of allocations for the
+		// AlwaysSample and NeverSample samplers, respectively.
+		budget [2]float64
+		run    func(ctx context.Context, tr apitrace.Tracer)
+	}{
+		{
+			name:   "StartEnd",
+			budget: [2]float64{10, 3},
+			run: func(ctx context.Context, tr apitrace.Tracer) {
+				_, span := tr.Start(ctx, "/foo")
+				span.End()
+			},
+		},
+		{
+			name:   "StartEndWithAttributes",
+			budget: [2]float64{20, 4},
+			run: func(ctx context.Context, tr apitrace.Tracer) {
+				_, span := tr.Start(ctx, "/foo")
+				span.SetAttributes(
+					label.Bool("key1", false),
+					label.String("key2", "hello"),
+					label.Uint64("key3", 123),
+					label.Float64("key4", 123.456),
+				)
+				span.End()
+			},
+		},
+		{
+			name:   "StartEndWithEvent",
+			budget: [2]float64{13, 4},
+			run: func(ctx context.Context, tr apitrace.Tracer) {
+				_, span := tr.Start(ctx, "/foo")
+				span.AddEvent(ctx, "event", label.String("key1", "value1"))
+				span.End()
+			},
+		},
+	}
+
+	samplers := []sdktrace.Sampler{sdktrace.AlwaysSample(), sdktrace.NeverSample()}
+	for _, test := range tests {
+		for i, sampler := range samplers {
+			test, budget, sampler := test, test.budget[i], sampler
+			t.Run(test.name+"/"+sampler.Description(), func(t *testing.T) {
+				tp := sdktrace.NewProvider(sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sampler}))
+				tr := tp.Tracer("AllocationBudget")
+
+				ctx, parent := tr.Start(context.Background(), "/parent")
+				defer parent.End()
+				for _, c := range []context.Context{context.Background(), ctx} {
+					allocs := testing.AllocsPerRun(100, func() { test.run(c, tr) })
+					if allocs > budget {
+						t.Errorf("got %v allocations, budget is %v", allocs, budget)
+					}
+				}
+			})
+		}
+	}
+}
@@ -16,13 +16,18 @@ package trace
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"google.golang.org/grpc/codes"
+
 	"go.opentelemetry.io/otel/api/global"
 	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/internal"
 )
 
 const (
@@ -55,6 +60,24 @@ type BatchSpanProcessorOptions struct {
 	// Blocking option should be used carefully as it can severely affect the performance of an
 	// application.
 	BlockOnQueueFull bool
+
+	// ExportTimeout is the maximum duration of a single call to the
+	// exporter.  When the timeout is exceeded, the export context is
+	// canceled and the error is reported wrapped with
+	// export.ErrExportTimeout.
+	// The default value of ExportTimeout is 0, meaning no timeout.
+	ExportTimeout time.Duration
+
+	// PriorityLane enables a separate queue of MaxQueueSize spans
+	// for the spans with a status other than OK and the spans for
+	// which PriorityPredicate, if not nil, returns true.  Under
+	// queue pressure, the other spans are dropped while these
+	// spans are retained until their own queue is full.
+	PriorityLane bool
+
+	// PriorityPredicate, if not nil, selects the spans with an OK
+	// status to queue in the priority lane.
+	PriorityPredicate func(sd *export.SpanData) bool
 }
 
 // BatchSpanProcessor is a SpanProcessor that batches asynchronously received
@@ -66,12 +89,24 @@ type BatchSpanProcessor struct {
 	queue   chan *export.SpanData
 	dropped uint32
 
+	// priorityQueue is the queue of the priority lane, nil if
+	// PriorityLane is not enabled.
+	priorityQueue chan *export.SpanData
+
 	batch      []*export.SpanData
 	batchMutex sync.Mutex
 	timer      *time.Timer
 	stopWait   sync.WaitGroup
 	stopOnce   sync.Once
 	stopCh     chan struct{}
+
+	// abortCtx is the parent of all export contexts.  It is canceled
+	// by abort when a shutdown runs out of time.
+	abortCtx context.Context
+	abort    context.CancelFunc
+	// unexported counts the spans dropped without an export attempt
+	// because of an aborted shutdown.  It is protected by batchMutex.
+	unexported int
 }
 
 var _ SpanProcessor = (*BatchSpanProcessor)(nil)
@@ -100,12 +135,17 @@ func NewBatchSpanProcessor(exporter export.SpanExporter, options ...BatchSpanPro
 		queue:  make(chan *export.SpanData, o.MaxQueueSize),
 		stopCh: make(chan struct{}),
 	}
+	if o.PriorityLane {
+		bsp.priorityQueue = make(chan *export.SpanData, o.MaxQueueSize)
+	}
+	bsp.abortCtx, bsp.abort = context.WithCancel(context.Background())
 
 	bsp.stopWait.Add(1)
 	go func() {
 		defer bsp.stopWait.Done()
 		bsp.processQueue()
 		bsp.drainQueue()
+		bsp.reportUnexported()
 	}()
 
 	return bsp
@@ -125,13 +165,41 @@ func (bsp *BatchSpanProcessor) OnEnd(sd *export.SpanData) {
 
 // Shutdown flushes the queue and waits until all spans are processed.
 // It only executes once. Subsequent call does nothing.
+//
+// Shutdown may block for as long as the exporter does.  Use
+// ShutdownContext to bound the time spent shutting down, e.g. when a
+// collector is unreachable while the process exits.
 func (bsp *BatchSpanProcessor) Shutdown() {
 	bsp.stopOnce.Do(func() {
 		close(bsp.stopCh)
 		bsp.stopWait.Wait()
+		bsp.abort()
 	})
 }
 
+// ShutdownContext shuts the processor down as Shutdown does, but
+// returns ctx.Err() as soon as ctx is done.  In that case the export in
+// progress is canceled and the spans that remain queued are dropped
+// without calling the exporter.  The number of dropped spans is
+// reported to the global error handler.  Exporters that do not honor
+// the cancellation of their context may still be running when
+// ShutdownContext returns.
+func (bsp *BatchSpanProcessor) ShutdownContext(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		bsp.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		bsp.abort()
+		return ctx.Err()
+	}
+}
+
 // ForceFlush exports all ended spans that have not yet been exported.
 func (bsp *BatchSpanProcessor) ForceFlush() {
 	bsp.exportSpans()
@@ -161,6 +229,28 @@ func WithBlocking() BatchSpanProcessorOption {
 	}
 }
 
+// WithExportTimeout limits the duration of each call to the exporter to
+// timeout.  The default, 0, sets no timeout.  The export is also
+// canceled when the context passed to ShutdownContext is done,
+// whichever comes first.  It is unrelated to the Timeout of the push
+// metric controller, which bounds metric collections and exports.  See
+// BatchSpanProcessorOptions.ExportTimeout.
+func WithExportTimeout(timeout time.Duration) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.ExportTimeout = timeout
+	}
+}
+
+// WithPriorityLane enables the priority lane for the spans with a status
+// other than OK and the spans for which predicate, if not nil, returns
+// true.  See BatchSpanProcessorOptions.PriorityLane.
+func WithPriorityLane(predicate func(sd *export.SpanData) bool) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.PriorityLane = true
+		o.PriorityPredicate = predicate
+	}
+}
+
 // exportSpans is a subroutine of processing and draining the queue.
 func (bsp *BatchSpanProcessor) exportSpans() {
 	bsp.timer.Reset(bsp.o.BatchTimeout)
@@ -169,13 +259,55 @@ func (bsp *BatchSpanProcessor) exportSpans() {
 	defer bsp.batchMutex.Unlock()
 
 	if len(bsp.batch) > 0 {
-		if err := bsp.e.ExportSpans(context.Background(), bsp.batch); err != nil {
-			global.Handle(err)
+		ctx := bsp.abortCtx
+		if ctx.Err() != nil {
+			bsp.unexported += len(bsp.batch)
+			bsp.batch = bsp.batch[:0]
+			return
+		}
+		if bsp.o.ExportTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, bsp.o.ExportTimeout)
+			defer cancel()
+		}
+		if err := safeExportSpans(ctx, bsp.e, bsp.batch); err != nil {
+			global.Handle(exportError(ctx, err))
 		}
 		bsp.batch = bsp.batch[:0]
 	}
 }
 
+// reportUnexported reports the spans dropped because of an aborted
+// shutdown to the global error handler.
+func (bsp *BatchSpanProcessor) reportUnexported() {
+	bsp.batchMutex.Lock()
+	defer bsp.batchMutex.Unlock()
+	if bsp.unexported > 0 {
+		global.Handle(fmt.Errorf("shutdown of BatchSpanProcessor aborted: %d spans not exported", bsp.unexported))
+	}
+}
+
+// exportError wraps err with export.ErrExportTimeout if the deadline of
+// ctx was exceeded.
+func exportError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && !errors.Is(err, export.ErrExportTimeout) {
+		return fmt.Errorf("%w: %v", export.ErrExportTimeout, err)
+	}
+	return err
+}
+
+// safeExportSpans exports batch with e, recovering a panic of e so
+// that it does not stop the processing of the queue.  A recovered
+// panic is returned as an error.
+func safeExportSpans(ctx context.Context, e export.SpanExporter, batch []*export.SpanData) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = internal.PanicError(r, "SpanExporter.ExportSpans")
+		}
+	}()
+	return e.ExportSpans(ctx, batch)
+}
+
 // processQueue removes spans from the `queue` channel until processor
 // is shut down. It calls the exporter in batches of up to MaxExportBatchSize
 // waiting up to BatchTimeout to form a batch.
@@ -188,25 +320,51 @@ func (bsp *BatchSpanProcessor) processQueue() {
 			return
 		case <-bsp.timer.C:
 			bsp.exportSpans()
+		case sd := <-bsp.priorityQueue:
+			bsp.processSpan(sd)
 		case sd := <-bsp.queue:
-			bsp.batchMutex.Lock()
-			bsp.batch = append(bsp.batch, sd)
-			shouldExport := len(bsp.batch) == bsp.o.MaxExportBatchSize
-			bsp.batchMutex.Unlock()
-			if shouldExport {
-				if !bsp.timer.Stop() {
-					<-bsp.timer.C
-				}
-				bsp.exportSpans()
-			}
+			bsp.processSpan(sd)
 		}
 	}
 }
 
+// processSpan adds sd to the batch, and exports the batch if it is
+// full.
+func (bsp *BatchSpanProcessor) processSpan(sd *export.SpanData) {
+	if bsp.appendToBatch(sd) {
+		if !bsp.timer.Stop() {
+			<-bsp.timer.C
+		}
+		bsp.exportSpans()
+	}
+}
+
+// appendToBatch adds sd to the batch and returns whether the batch is
+// full.
+func (bsp *BatchSpanProcessor) appendToBatch(sd *export.SpanData) bool {
+	bsp.batchMutex.Lock()
+	defer bsp.batchMutex.Unlock()
+	bsp.batch = append(bsp.batch, sd)
+	return len(bsp.batch) == bsp.o.MaxExportBatchSize
+}
+
 // drainQueue awaits the any caller that had added to bsp.stopWait
 // to finish the enqueue, then exports the final batch.
 func (bsp *BatchSpanProcessor) drainQueue() {
+	priority := bsp.priorityQueue
 	for {
+		// The spans of the priority lane are drained first.
+		select {
+		case sd, ok := <-priority:
+			if !ok {
+				priority = nil
+			} else if bsp.appendToBatch(sd) {
+				bsp.exportSpans()
+			}
+			continue
+		default:
+		}
+
 		select {
 		case sd := <-bsp.queue:
 			if sd == nil {
@@ -214,15 +372,13 @@ func (bsp *BatchSpanProcessor) drainQueue() {
 				return
 			}
 
-			bsp.batchMutex.Lock()
-			bsp.batch = append(bsp.batch, sd)
-			shouldExport := len(bsp.batch) == bsp.o.MaxExportBatchSize
-			bsp.batchMutex.Unlock()
-
-			if shouldExport {
+			if bsp.appendToBatch(sd) {
 				bsp.exportSpans()
 			}
 		default:
+			if priority != nil {
+				close(bsp.priorityQueue)
+			}
 			close(bsp.queue)
 		}
 	}
@@ -254,14 +410,27 @@ func (bsp *BatchSpanProcessor) enqueue(sd *export.SpanData) {
 	default:
 	}
 
+	queue := bsp.queue
+	if bsp.priorityQueue != nil && bsp.isPriority(sd) {
+		queue = bsp.priorityQueue
+	}
+
 	if bsp.o.BlockOnQueueFull {
-		bsp.queue <- sd
+		queue <- sd
 		return
 	}
 
 	select {
-	case bsp.queue <- sd:
+	case queue <- sd:
 	default:
 		atomic.AddUint32(&bsp.dropped, 1)
 	}
 }
+
+// isPriority returns whether sd is queued in the priority lane.
+func (bsp *BatchSpanProcessor) isPriority(sd *export.SpanData) bool {
+	if sd.StatusCode != codes.OK {
+		return true
+	}
+	return bsp.o.PriorityPredicate != nil && bsp.o.PriorityPredicate(sd)
+}
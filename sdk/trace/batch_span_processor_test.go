@@ -17,11 +17,16 @@ package trace_test
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+
 	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
 	export "go.opentelemetry.io/otel/sdk/export/trace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
@@ -227,3 +232,159 @@ func TestBatchSpanProcessorShutdown(t *testing.T) {
 	// Multiple call to Shutdown() should not panic.
 	bsp.Shutdown()
 }
+
+type deadlineExporter struct {
+	deadlines chan bool
+}
+
+func (e *deadlineExporter) ExportSpans(ctx context.Context, _ []*export.SpanData) error {
+	_, ok := ctx.Deadline()
+	e.deadlines <- ok
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *deadlineExporter) Shutdown(context.Context) error { return nil }
+
+func TestBatchSpanProcessorExportTimeout(t *testing.T) {
+	exp := &deadlineExporter{deadlines: make(chan bool, 1)}
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithExportTimeout(time.Millisecond))
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+
+	_, span := tp.Tracer("ExportTimeout").Start(context.Background(), "span")
+	span.End()
+
+	done := make(chan struct{})
+	go func() {
+		// Unregistering shuts the processor down, exporting the queued span.
+		tp.UnregisterSpanProcessor(bsp)
+		close(done)
+	}()
+
+	select {
+	case hasDeadline := <-exp.deadlines:
+		if !hasDeadline {
+			t.Error("export context has no deadline")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for export")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("export was not canceled by the export timeout")
+	}
+}
+
+type hungExporter struct {
+	calls int32
+}
+
+func (e *hungExporter) ExportSpans(ctx context.Context, _ []*export.SpanData) error {
+	atomic.AddInt32(&e.calls, 1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *hungExporter) Shutdown(context.Context) error { return nil }
+
+func TestBatchSpanProcessorShutdownContext(t *testing.T) {
+	exp := &hungExporter{}
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithMaxExportBatchSize(1))
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+
+	tr := tp.Tracer("ShutdownContext")
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := bsp.ShutdownContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ShutdownContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ShutdownContext took %v", elapsed)
+	}
+
+	// Shutdown waits for the aborted shutdown to complete.  The
+	// export in progress was canceled and the remaining spans were
+	// dropped without calling the exporter.
+	bsp.Shutdown()
+	if calls := atomic.LoadInt32(&exp.calls); calls != 1 {
+		t.Errorf("exporter called %d times, want 1", calls)
+	}
+
+	// Shutting down a processor that is already shut down succeeds.
+	if err := bsp.ShutdownContext(context.Background()); err != nil {
+		t.Errorf("ShutdownContext() = %v, want nil", err)
+	}
+}
+
+// blockingExporter blocks the first export until release is closed.
+type blockingExporter struct {
+	testBatchExporter
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (e *blockingExporter) ExportSpans(ctx context.Context, sds []*export.SpanData) error {
+	e.once.Do(func() {
+		close(e.started)
+		<-e.release
+	})
+	return e.testBatchExporter.ExportSpans(ctx, sds)
+}
+
+func TestBatchSpanProcessorPriorityLane(t *testing.T) {
+	exp := &blockingExporter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxQueueSize(2),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithPriorityLane(func(sd *export.SpanData) bool {
+			return sd.Name == "important"
+		}),
+	)
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("PriorityLane")
+
+	// The first span blocks the processor in its export.
+	_, span := tr.Start(context.Background(), "first")
+	span.End()
+	<-exp.started
+
+	// The queue keeps 2 of the low-priority spans, while the
+	// high-priority spans are queued in the priority lane.
+	for i := 0; i < 4; i++ {
+		_, span := tr.Start(context.Background(), "low")
+		span.End()
+	}
+	_, span = tr.Start(context.Background(), "failed")
+	span.SetStatus(codes.Unknown, "failed")
+	span.End()
+	_, span = tr.Start(context.Background(), "important")
+	span.End()
+
+	close(exp.release)
+	bsp.Shutdown()
+
+	got := map[string]int{}
+	for _, sd := range exp.spans {
+		got[sd.Name]++
+	}
+	want := map[string]int{"first": 1, "low": 2, "failed": 1, "important": 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("exported spans (-want +got):\n%s", diff)
+	}
+}
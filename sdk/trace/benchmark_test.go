@@ -16,10 +16,13 @@ package trace_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	apitrace "go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/propagators"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
@@ -132,6 +135,70 @@ func BenchmarkSpanWithAttributes_all_2x(b *testing.B) {
 	})
 }
 
+func BenchmarkStartEndSpanWithLocalParent(b *testing.B) {
+	traceBenchmark(b, "Benchmark StartEndSpan With Local Parent", func(b *testing.B, t apitrace.Tracer) {
+		ctx, parent := t.Start(context.Background(), "/parent")
+		defer parent.End()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, span := t.Start(ctx, "/foo")
+			span.End()
+		}
+	})
+}
+
+func BenchmarkStartEndSpanWithRemoteParent(b *testing.B) {
+	traceBenchmark(b, "Benchmark StartEndSpan With Remote Parent", func(b *testing.B, t apitrace.Tracer) {
+		tc := propagators.TraceContext{}
+		header := http.Header{}
+		header.Set("traceparent", "00-0000000000000001000000000000002a-000000000000002a-01")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ctx := tc.Extract(context.Background(), header)
+			_, span := t.Start(ctx, "/foo")
+			span.End()
+		}
+	})
+}
+
+func BenchmarkSpanWithEvents_4(b *testing.B) {
+	traceBenchmark(b, "Benchmark Start With 4 Events", func(b *testing.B, t apitrace.Tracer) {
+		ctx := context.Background()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			_, span := t.Start(ctx, "/foo")
+			for j := 0; j < 4; j++ {
+				span.AddEvent(ctx, "event", label.Int("index", j))
+			}
+			span.End()
+		}
+	})
+}
+
+func BenchmarkBatchSpanProcessorExport(b *testing.B) {
+	bsp := sdktrace.NewBatchSpanProcessor(discardExporter{}, sdktrace.WithBlocking())
+	tp := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSpanProcessor(bsp),
+	)
+	defer tp.UnregisterSpanProcessor(bsp)
+	t := tp.Tracer("Benchmark BatchSpanProcessor Export")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, span := t.Start(ctx, "/foo")
+		span.End()
+	}
+}
+
+type discardExporter struct{}
+
+func (discardExporter) ExportSpans(context.Context, []*export.SpanData) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                        { return nil }
+
 func BenchmarkTraceID_DotString(b *testing.B) {
 	t, _ := apitrace.IDFromHex("0000000000000001000000000000002a")
 	sc := apitrace.SpanContext{TraceID: t}
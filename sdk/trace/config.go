@@ -20,6 +20,14 @@ import (
 )
 
 // Config represents the global tracing configuration.
+//
+// A Provider holds its Config as an immutable snapshot that can be
+// replaced at runtime with ApplyConfig.  Every setting is bound when a
+// span is started: the span is sampled, identified, limited and
+// associated with a Resource according to the snapshot current at that
+// time, and keeps using it until it ends.  A changed Config therefore
+// applies to spans started after ApplyConfig returns, never to spans
+// already in flight.
 type Config struct {
 	// DefaultSampler is the default sampler used when creating new spans.
 	DefaultSampler Sampler
@@ -36,6 +44,11 @@ type Config struct {
 	// MaxLinksPerSpan is max number of links per span
 	MaxLinksPerSpan int
 
+	// MaxAttributesPerLink is max number of attributes per link.
+	// Attributes beyond the limit are dropped and counted in the
+	// DroppedAttributeCount of the exported link.
+	MaxAttributesPerLink int
+
 	// Resource contains attributes representing an entity that produces telemetry.
 	Resource *resource.Resource
 }
@@ -49,4 +62,7 @@ const (
 
 	// DefaultMaxLinksPerSpan is default max number of links per span
 	DefaultMaxLinksPerSpan = 32
+
+	// DefaultMaxAttributesPerLink is default max number of attributes per link
+	DefaultMaxAttributesPerLink = 32
 )
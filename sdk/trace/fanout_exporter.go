@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+// fanoutExporter is a SpanExporter that exports to multiple exporters
+// concurrently.
+type fanoutExporter struct {
+	exporters []export.SpanExporter
+}
+
+var _ export.SpanExporter = (*fanoutExporter)(nil)
+
+// NewFanoutExporter returns a SpanExporter that exports each batch of
+// spans to all of exps concurrently.  This allows a single
+// SpanProcessor, and therefore a single batching queue, to feed
+// several exporters.
+//
+// Exporters are isolated from each other: an exporter that returns an
+// error or panics does not prevent the others from exporting, and the
+// returned error combines the errors of all exporters that failed.
+// ExportSpans and Shutdown return no later than the deadline of the
+// passed context, even if an exporter does not honor it.
+//
+// The same SpanData are passed to every exporter, which therefore must
+// not modify them.
+func NewFanoutExporter(exps ...export.SpanExporter) export.SpanExporter {
+	exporters := make([]export.SpanExporter, 0, len(exps))
+	for _, e := range exps {
+		if e != nil {
+			exporters = append(exporters, e)
+		}
+	}
+	return &fanoutExporter{exporters: exporters}
+}
+
+// ExportSpans exports spanData to all exporters.
+func (f *fanoutExporter) ExportSpans(ctx context.Context, spanData []*export.SpanData) error {
+	// Exporters that do not honor ctx may still read the spans after
+	// ExportSpans returned, when the caller reuses spanData, e.g.,
+	// the BatchSpanProcessor its batch.  They are given a copy.
+	spans := make([]*export.SpanData, len(spanData))
+	copy(spans, spanData)
+	return f.each(ctx, func(e export.SpanExporter) error {
+		return e.ExportSpans(ctx, spans)
+	})
+}
+
+// Shutdown shuts down all exporters.
+func (f *fanoutExporter) Shutdown(ctx context.Context) error {
+	return f.each(ctx, func(e export.SpanExporter) error {
+		return e.Shutdown(ctx)
+	})
+}
+
+// each calls fn for every exporter in its own goroutine and waits for
+// them to return or for ctx to be done, whichever happens first.
+func (f *fanoutExporter) each(ctx context.Context, fn func(export.SpanExporter) error) error {
+	// The channel is buffered so that goroutines of exporters that
+	// do not honor ctx do not leak once they return.
+	results := make(chan error, len(f.exporters))
+	for _, e := range f.exporters {
+		go func(e export.SpanExporter) {
+			defer func() {
+				if r := recover(); r != nil {
+					results <- fmt.Errorf("%T panicked: %v", e, r)
+				}
+			}()
+			results <- fn(e)
+		}(e)
+	}
+
+	var errs fanoutError
+	for pending := len(f.exporters); pending > 0; pending-- {
+		select {
+		case err := <-results:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("%d exporters did not return: %w", pending, ctx.Err()))
+			return errs
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// fanoutError combines the errors returned by multiple exporters.
+type fanoutError []error
+
+func (e fanoutError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the combined errors matches target.
+func (e fanoutError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
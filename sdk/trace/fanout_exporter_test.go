@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type fanoutTestExporter struct {
+	mu       sync.Mutex
+	spans    []*export.SpanData
+	shutdown bool
+	err      error
+	panics   bool
+	block    chan struct{}
+}
+
+func (e *fanoutTestExporter) ExportSpans(_ context.Context, spans []*export.SpanData) error {
+	if e.panics {
+		panic("boom")
+	}
+	if e.block != nil {
+		<-e.block
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return e.err
+}
+
+func (e *fanoutTestExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *fanoutTestExporter) exported() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.spans)
+}
+
+func TestFanoutExporter(t *testing.T) {
+	a, b := new(fanoutTestExporter), new(fanoutTestExporter)
+	exp := sdktrace.NewFanoutExporter(a, nil, b)
+
+	spans := []*export.SpanData{{Name: "a"}, {Name: "b"}}
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, 2, a.exported())
+	assert.Equal(t, 2, b.exported())
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.True(t, a.shutdown)
+	assert.True(t, b.shutdown)
+}
+
+func TestFanoutExporterIsolatesFailures(t *testing.T) {
+	errA := errors.New("a failed")
+	failing := &fanoutTestExporter{err: errA}
+	panicking := &fanoutTestExporter{panics: true}
+	ok := new(fanoutTestExporter)
+	exp := sdktrace.NewFanoutExporter(failing, panicking, ok)
+
+	err := exp.ExportSpans(context.Background(), []*export.SpanData{{Name: "a"}})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errA))
+	assert.Contains(t, err.Error(), "panicked: boom")
+	assert.Equal(t, 1, ok.exported())
+}
+
+func TestFanoutExporterHonorsDeadline(t *testing.T) {
+	blocked := &fanoutTestExporter{block: make(chan struct{})}
+	ok := new(fanoutTestExporter)
+	exp := sdktrace.NewFanoutExporter(blocked, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	spans := []*export.SpanData{{Name: "a"}}
+	err := exp.ExportSpans(ctx, spans)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, ok.exported())
+
+	// The exporter that did not return reads its own copy of the
+	// spans, which the caller may reuse.
+	spans[0] = nil
+	close(blocked.block)
+	require.Eventually(t, func() bool { return blocked.exported() == 1 }, time.Second, time.Millisecond)
+	blocked.mu.Lock()
+	defer blocked.mu.Unlock()
+	assert.Equal(t, "a", blocked.spans[0].Name)
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/internal"
+)
+
+const (
+	// maxLeakStackDepth is the maximum number of frames recorded
+	// for the stack that started a span.
+	maxLeakStackDepth = 32
+
+	// sdkFuncPrefix prefixes the names of the functions of this
+	// package, which are omitted from recorded stacks.
+	sdkFuncPrefix = "go.opentelemetry.io/otel/sdk/trace."
+
+	// DefaultLeakThreshold is the threshold of a LeakDetector
+	// created with a threshold that is not positive.
+	DefaultLeakThreshold = time.Minute
+)
+
+// LeakDetectorOption configures a LeakDetector.
+type LeakDetectorOption func(o *LeakDetectorOptions)
+
+// LeakDetectorOptions are the options of a LeakDetector.
+type LeakDetectorOptions struct {
+	// CheckInterval is the interval at which spans are checked.
+	// The default, also used if it is not positive, is the
+	// threshold of the LeakDetector.
+	CheckInterval time.Duration
+}
+
+// WithLeakCheckInterval sets the interval at which a LeakDetector
+// checks for spans that have not been ended.
+func WithLeakCheckInterval(d time.Duration) LeakDetectorOption {
+	return func(o *LeakDetectorOptions) {
+		o.CheckInterval = d
+	}
+}
+
+// LeakedSpan describes a span that was not ended within the threshold
+// of a LeakDetector.
+type LeakedSpan struct {
+	Name        string
+	SpanContext apitrace.SpanContext
+	StartTime   time.Time
+	// Stack is the formatted stack of the call that started the
+	// span, without the frames of the SDK.
+	Stack string
+}
+
+// LeakDetector is a SpanProcessor that tracks spans that are not
+// ended within a threshold after they were started, which usually
+// indicates a missing `defer span.End()`.  Each such span is reported
+// once to the global error handler, with its name and the stack of
+// the call that started it, and is listed by LeakedSpans until it is
+// ended.
+//
+// A LeakDetector records a stack for every span started, so it is
+// meant for debugging rather than for production use.
+type LeakDetector struct {
+	threshold time.Duration
+
+	mu    sync.Mutex
+	spans map[apitrace.SpanID]*liveSpan
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	stopWait sync.WaitGroup
+}
+
+var _ SpanProcessor = (*LeakDetector)(nil)
+
+// liveSpan is a span tracked by a LeakDetector.
+type liveSpan struct {
+	// span holds the name, SpanContext and start time of the span
+	// when it was started.
+	span     LeakedSpan
+	started  time.Time
+	stack    []uintptr
+	reported bool
+}
+
+// NewLeakDetector returns a LeakDetector reporting spans that are not
+// ended within threshold after they were started, or within
+// DefaultLeakThreshold if threshold is not positive.  It checks spans
+// in the background until it is shut down.
+func NewLeakDetector(threshold time.Duration, opts ...LeakDetectorOption) *LeakDetector {
+	if threshold <= 0 {
+		threshold = DefaultLeakThreshold
+	}
+	o := LeakDetectorOptions{CheckInterval: threshold}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.CheckInterval <= 0 {
+		o.CheckInterval = threshold
+	}
+
+	d := &LeakDetector{
+		threshold: threshold,
+		spans:     make(map[apitrace.SpanID]*liveSpan),
+		stopCh:    make(chan struct{}),
+	}
+	d.stopWait.Add(1)
+	go func() {
+		defer d.stopWait.Done()
+		ticker := time.NewTicker(o.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.check()
+			case <-d.stopCh:
+				return
+			}
+		}
+	}()
+	return d
+}
+
+// OnStart starts tracking the span of sd.
+func (d *LeakDetector) OnStart(sd *export.SpanData) {
+	stack := make([]uintptr, maxLeakStackDepth)
+	stack = stack[:runtime.Callers(2, stack)]
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.spans[sd.SpanContext.SpanID] = &liveSpan{
+		span: LeakedSpan{
+			Name:        sd.Name,
+			SpanContext: sd.SpanContext,
+			StartTime:   sd.StartTime,
+		},
+		started: internal.Now(),
+		stack:   stack,
+	}
+}
+
+// OnEnd stops tracking the span of sd.
+func (d *LeakDetector) OnEnd(sd *export.SpanData) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.spans, sd.SpanContext.SpanID)
+}
+
+// Shutdown stops checking spans.
+func (d *LeakDetector) Shutdown() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+		d.stopWait.Wait()
+	})
+}
+
+// ForceFlush does nothing.
+func (d *LeakDetector) ForceFlush() {}
+
+// LeakedSpans returns the spans that are not ended within the
+// threshold, including those not yet reported.  It may be used to
+// serve the leaked spans on a debug endpoint.
+func (d *LeakDetector) LeakedSpans() []LeakedSpan {
+	now := internal.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var leaked []LeakedSpan
+	for _, s := range d.spans {
+		if now.Sub(s.started) >= d.threshold {
+			leaked = append(leaked, s.leaked())
+		}
+	}
+	return leaked
+}
+
+// check reports the spans that are not ended within the threshold
+// and have not been reported yet.
+func (d *LeakDetector) check() {
+	now := internal.Now()
+
+	var leaked []LeakedSpan
+	d.mu.Lock()
+	for _, s := range d.spans {
+		if !s.reported && now.Sub(s.started) >= d.threshold {
+			s.reported = true
+			leaked = append(leaked, s.leaked())
+		}
+	}
+	d.mu.Unlock()
+
+	// The error handler is called without holding the lock, as it
+	// may start spans.
+	for _, l := range leaked {
+		global.Handle(fmt.Errorf("span %q not ended %v after it was started at:\n%s", l.Name, d.threshold, l.Stack))
+	}
+}
+
+func (s *liveSpan) leaked() LeakedSpan {
+	l := s.span
+	l.Stack = formatStack(s.stack)
+	return l
+}
+
+// formatStack formats the frames of stack, skipping the leading frames
+// of this package.
+func formatStack(stack []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(stack)
+	sdk := true
+	for {
+		f, more := frames.Next()
+		if sdk && strings.HasPrefix(f.Function, sdkFuncPrefix) {
+			if !more {
+				break
+			}
+			continue
+		}
+		sdk = false
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLeakDetector(t *testing.T) {
+	ld := sdktrace.NewLeakDetector(20*time.Millisecond, sdktrace.WithLeakCheckInterval(5*time.Millisecond))
+	defer ld.Shutdown()
+	tp := sdktrace.NewProvider(sdktrace.WithSpanProcessor(ld))
+	tr := tp.Tracer("leak")
+
+	_, leaked := tr.Start(context.Background(), "leaked")
+	_, ended := tr.Start(context.Background(), "ended")
+	ended.End()
+	assert.Empty(t, ld.LeakedSpans())
+
+	require.Eventually(t, func() bool {
+		return len(ld.LeakedSpans()) > 0
+	}, time.Second, 5*time.Millisecond)
+	spans := ld.LeakedSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "leaked", spans[0].Name)
+	assert.Equal(t, leaked.SpanContext(), spans[0].SpanContext)
+	assert.True(t, strings.HasPrefix(spans[0].Stack, "go.opentelemetry.io/otel/sdk/trace_test.TestLeakDetector\n"), spans[0].Stack)
+
+	leaked.End()
+	assert.Empty(t, ld.LeakedSpans())
+}
+
+func TestLeakDetectorNonPositiveDurations(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		// A non-positive threshold or interval must not make the
+		// background check panic.
+		sdktrace.NewLeakDetector(d).Shutdown()
+		sdktrace.NewLeakDetector(time.Second, sdktrace.WithLeakCheckInterval(d)).Shutdown()
+	}
+}
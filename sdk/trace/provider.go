@@ -15,6 +15,9 @@
 package trace
 
 import (
+	"context"
+	"fmt"
+	"path"
 	"sync"
 	"sync/atomic"
 
@@ -22,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 
+	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/trace"
 	apitrace "go.opentelemetry.io/otel/api/trace"
 )
@@ -35,17 +39,39 @@ const (
 
 // ProviderOptions
 type ProviderOptions struct {
-	processors []SpanProcessor
-	config     Config
+	processors        []SpanProcessor
+	config            Config
+	validateSpanKinds bool
+	spanNameFormatter SpanNameFormatter
+	samplingCallback  SamplingDecisionCallback
+	disabledScopes    []string
+	maxLiveSpans      int64
 }
 
 type ProviderOption func(*ProviderOptions)
 
 type Provider struct {
+	// liveSpans is the number of recording spans started and not yet
+	// ended, counted only if maxLiveSpans is positive.  It is
+	// accessed atomically and must be the first field of the struct,
+	// along with maxLiveSpans, to be 64-bit aligned on 32-bit
+	// platforms.
+	liveSpans    int64
+	maxLiveSpans int64
+
 	mu             sync.Mutex
 	namedTracer    map[instrumentation.Library]*tracer
 	spanProcessors atomic.Value
 	config         atomic.Value // access atomically
+
+	validateSpanKinds bool
+	spanNameFormatter SpanNameFormatter
+	samplingCallback  SamplingDecisionCallback
+	disabledScopes    []string
+
+	// liveSpansLimited is 1 while spans are dropped because of
+	// maxLiveSpans.  It is accessed atomically.
+	liveSpansLimited int32
 }
 
 var _ apitrace.Provider = &Provider{}
@@ -61,7 +87,18 @@ func NewProvider(opts ...ProviderOption) *Provider {
 	}
 
 	tp := &Provider{
-		namedTracer: make(map[instrumentation.Library]*tracer),
+		namedTracer:       make(map[instrumentation.Library]*tracer),
+		validateSpanKinds: o.validateSpanKinds,
+		spanNameFormatter: o.spanNameFormatter,
+		samplingCallback:  o.samplingCallback,
+		maxLiveSpans:      o.maxLiveSpans,
+	}
+	for _, pattern := range o.disabledScopes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			global.Handle(fmt.Errorf("disabled scope %q: %w", pattern, err))
+			continue
+		}
+		tp.disabledScopes = append(tp.disabledScopes, pattern)
 	}
 	tp.config.Store(&Config{
 		DefaultSampler:       ParentBased(AlwaysSample()),
@@ -69,6 +106,7 @@ func NewProvider(opts ...ProviderOption) *Provider {
 		MaxAttributesPerSpan: DefaultMaxAttributesPerSpan,
 		MaxEventsPerSpan:     DefaultMaxEventsPerSpan,
 		MaxLinksPerSpan:      DefaultMaxLinksPerSpan,
+		MaxAttributesPerLink: DefaultMaxAttributesPerLink,
 	})
 
 	for _, sp := range o.processors {
@@ -90,6 +128,9 @@ func (p *Provider) Tracer(name string, opts ...apitrace.TracerOption) apitrace.T
 	if name == "" {
 		name = defaultTracerName
 	}
+	if p.scopeDisabled(name) {
+		return disabledTracer{}
+	}
 	il := instrumentation.Library{
 		Name:    name,
 		Version: c.InstrumentationVersion,
@@ -105,6 +146,33 @@ func (p *Provider) Tracer(name string, opts ...apitrace.TracerOption) apitrace.T
 	return t
 }
 
+// scopeDisabled reports whether tracers named name are disabled.
+func (p *Provider) scopeDisabled(name string) bool {
+	for _, pattern := range p.disabledScopes {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// disabledTracer is the Tracer of a disabled instrumentation scope.
+// Its spans do nothing and are not added to the context, so that
+// spans started by other instrumentation within a disabled span
+// become children of the disabled span's parent.
+type disabledTracer struct{}
+
+var _ apitrace.EnabledTracer = disabledTracer{}
+
+func (disabledTracer) Start(ctx context.Context, name string, opts ...apitrace.SpanOption) (context.Context, apitrace.Span) {
+	_, span := apitrace.NoopProvider().Tracer("").Start(ctx, name, opts...)
+	return ctx, span
+}
+
+func (disabledTracer) Enabled(context.Context, ...apitrace.SpanOption) bool {
+	return false
+}
+
 // RegisterSpanProcessor adds the given SpanProcessor to the list of SpanProcessors
 func (p *Provider) RegisterSpanProcessor(s SpanProcessor) {
 	p.mu.Lock()
@@ -140,6 +208,10 @@ func (p *Provider) UnregisterSpanProcessor(s SpanProcessor) {
 
 // ApplyConfig changes the configuration of the provider.
 // If a field in the configuration is empty or nil then its original value is preserved.
+//
+// It is safe to call ApplyConfig concurrently with starting spans, e.g.
+// to temporarily raise MaxEventsPerSpan while debugging.  The new
+// configuration applies to spans started after ApplyConfig returns.
 func (p *Provider) ApplyConfig(cfg Config) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -159,12 +231,20 @@ func (p *Provider) ApplyConfig(cfg Config) {
 	if cfg.MaxLinksPerSpan > 0 {
 		c.MaxLinksPerSpan = cfg.MaxLinksPerSpan
 	}
+	if cfg.MaxAttributesPerLink > 0 {
+		c.MaxAttributesPerLink = cfg.MaxAttributesPerLink
+	}
 	if cfg.Resource != nil {
 		c.Resource = cfg.Resource
 	}
 	p.config.Store(&c)
 }
 
+// Config returns a copy of the current configuration of the provider.
+func (p *Provider) Config() Config {
+	return *p.config.Load().(*Config)
+}
+
 // WithSyncer registers the exporter with the Provider using a
 // SimpleSpanProcessor.
 func WithSyncer(e export.SpanExporter) ProviderOption {
@@ -198,3 +278,121 @@ func WithResource(r *resource.Resource) ProviderOption {
 		opts.config.Resource = r
 	}
 }
+
+// WithSpanKindValidation option enables validation of the kind of spans
+// started by the provider.  A span started with SpanKindServer as the
+// child of a local span that is itself a server span is reported to the
+// global error handler and started with SpanKindInternal instead, as a
+// single process is expected to handle an inbound request only once.
+func WithSpanKindValidation() ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.validateSpanKinds = true
+	}
+}
+
+// SpanNameFormatter returns the name a span is given for the name it
+// was started or renamed with.
+type SpanNameFormatter func(name string) string
+
+// WithSpanNameFormatter option sets a function that rewrites the names
+// of spans when they are started or renamed with SetName, before they
+// are sampled.  This can be used to normalize names that would
+// otherwise have a high cardinality, e.g. by removing identifiers from
+// names derived from URL paths.
+func WithSpanNameFormatter(f SpanNameFormatter) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.spanNameFormatter = f
+	}
+}
+
+// SamplingDecisionCallback is called with the parameters and the result
+// of every invocation of the Sampler of a Provider.
+type SamplingDecisionCallback func(SamplingParameters, SamplingResult)
+
+// WithSamplingDecisionCallback option sets a function that is called
+// every time the Sampler is consulted when a span is started or
+// renamed.  Spans that are children of a local span inherit its
+// sampling decision without consulting the Sampler and are not
+// reported.  The callback is called synchronously and must be fast;
+// see SamplingDecisionRecorder for a ready-made implementation.
+func WithSamplingDecisionCallback(f SamplingDecisionCallback) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.samplingCallback = f
+	}
+}
+
+// formatSpanName returns name rewritten by the provider's
+// SpanNameFormatter, if any.
+func (p *Provider) formatSpanName(name string) string {
+	if p.spanNameFormatter == nil {
+		return name
+	}
+	return p.spanNameFormatter(name)
+}
+
+// WithDisabledScopes option disables the tracers of the instrumentation
+// libraries whose name matches one of patterns.  Patterns use the
+// syntax of path.Match, e.g. "github.com/noisy/library/*".  The
+// tracers of disabled libraries start spans that do nothing and cost
+// nearly nothing, which is cheaper and more targeted than sampling to
+// silence a misbehaving instrumentation.  Malformed patterns are
+// reported to the global error handler and ignored.
+func WithDisabledScopes(patterns ...string) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.disabledScopes = append(opts.disabledScopes, patterns...)
+	}
+}
+
+// WithMaxLiveSpans option limits the number of recording spans started
+// by the provider that have not yet ended to max.  Spans started while
+// the limit is reached do not record, as if they were not sampled, so
+// that spans that are never ended cannot exhaust memory.  The first
+// span dropped after the limit is reached is reported to the global
+// error handler.  A max of zero or less means no limit, the default.
+//
+// The number of live spans is available from LiveSpans.  It is only
+// counted if it is limited, so that spans are not accounted for
+// otherwise.
+func WithMaxLiveSpans(max int) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.maxLiveSpans = int64(max)
+	}
+}
+
+// LiveSpans returns the number of recording spans started by the
+// provider that have not yet ended, or zero if their number is not
+// limited by WithMaxLiveSpans.  It may be observed with an
+// Int64ValueObserver to monitor leaked spans.
+func (p *Provider) LiveSpans() int64 {
+	return atomic.LoadInt64(&p.liveSpans)
+}
+
+// acquireLiveSpan accounts for a new recording span.  It returns
+// false if the span must not record because the number of live spans
+// is limited.
+func (p *Provider) acquireLiveSpan() bool {
+	if p.maxLiveSpans <= 0 {
+		return true
+	}
+	if n := atomic.AddInt64(&p.liveSpans, 1); n <= p.maxLiveSpans {
+		// Avoid writing to the flag, shared by all spans, unless
+		// it changes.
+		if atomic.LoadInt32(&p.liveSpansLimited) != 0 {
+			atomic.StoreInt32(&p.liveSpansLimited, 0)
+		}
+		return true
+	}
+	atomic.AddInt64(&p.liveSpans, -1)
+	if atomic.CompareAndSwapInt32(&p.liveSpansLimited, 0, 1) {
+		global.Handle(fmt.Errorf("limit of %d live spans reached, dropping spans until spans are ended", p.maxLiveSpans))
+	}
+	return false
+}
+
+// releaseLiveSpan accounts for an ended recording span.
+func (p *Provider) releaseLiveSpan() {
+	if p.maxLiveSpans <= 0 {
+		return
+	}
+	atomic.AddInt64(&p.liveSpans, -1)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+// ResourceAttributesProcessorOption configures a
+// ResourceAttributesProcessor.
+type ResourceAttributesProcessorOption func(o *ResourceAttributesProcessorOptions)
+
+// ResourceAttributesProcessorOptions are the options of a
+// ResourceAttributesProcessor.
+type ResourceAttributesProcessorOptions struct {
+	// Keys are the keys of the resource attributes copied to spans.
+	// If empty, all resource attributes are copied.
+	Keys []label.Key
+}
+
+// WithCopiedResourceKeys selects the resource attributes copied to
+// spans.  By default, all resource attributes are copied.
+func WithCopiedResourceKeys(keys ...label.Key) ResourceAttributesProcessorOption {
+	return func(o *ResourceAttributesProcessorOptions) {
+		o.Keys = append(o.Keys, keys...)
+	}
+}
+
+// ResourceAttributesProcessor is a SpanProcessor that copies resource
+// attributes onto the attributes of ended spans before passing them to
+// another SpanProcessor.  This helps backends that cannot join spans
+// with their resource.
+//
+// Spans are enriched only for the wrapped SpanProcessor, so that
+// different pipelines, e.g. a BatchSpanProcessor per exporter, can be
+// configured independently: the SpanData passed to other
+// SpanProcessors are not modified.  Attributes of the span take
+// precedence over resource attributes with the same key.
+type ResourceAttributesProcessor struct {
+	next SpanProcessor
+	keys []label.Key
+}
+
+var _ SpanProcessor = (*ResourceAttributesProcessor)(nil)
+
+// NewResourceAttributesProcessor returns a ResourceAttributesProcessor
+// that passes spans enriched with resource attributes to next.
+func NewResourceAttributesProcessor(next SpanProcessor, opts ...ResourceAttributesProcessorOption) *ResourceAttributesProcessor {
+	var o ResourceAttributesProcessorOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &ResourceAttributesProcessor{
+		next: next,
+		keys: o.Keys,
+	}
+}
+
+// OnStart passes sd to the wrapped SpanProcessor unchanged.
+func (p *ResourceAttributesProcessor) OnStart(sd *export.SpanData) {
+	p.next.OnStart(sd)
+}
+
+// OnEnd passes a copy of sd enriched with resource attributes to the
+// wrapped SpanProcessor.
+func (p *ResourceAttributesProcessor) OnEnd(sd *export.SpanData) {
+	p.next.OnEnd(p.enrich(sd))
+}
+
+// Shutdown shuts the wrapped SpanProcessor down.
+func (p *ResourceAttributesProcessor) Shutdown() {
+	p.next.Shutdown()
+}
+
+// ForceFlush flushes the wrapped SpanProcessor.
+func (p *ResourceAttributesProcessor) ForceFlush() {
+	p.next.ForceFlush()
+}
+
+// enrich returns a copy of sd with the selected resource attributes
+// that sd does not have, or sd itself if there are none.
+func (p *ResourceAttributesProcessor) enrich(sd *export.SpanData) *export.SpanData {
+	if sd.Resource.Len() == 0 {
+		return sd
+	}
+
+	present := make(map[label.Key]struct{}, len(sd.Attributes))
+	for _, kv := range sd.Attributes {
+		present[kv.Key] = struct{}{}
+	}
+	var added []label.KeyValue
+	add := func(kv label.KeyValue) {
+		if _, ok := present[kv.Key]; !ok {
+			added = append(added, kv)
+		}
+	}
+	if len(p.keys) == 0 {
+		for iter := sd.Resource.Iter(); iter.Next(); {
+			add(iter.Label())
+		}
+	} else {
+		set := sd.Resource.LabelSet()
+		for _, k := range p.keys {
+			if v, ok := set.Value(k); ok {
+				add(label.KeyValue{Key: k, Value: v})
+			}
+		}
+	}
+	if len(added) == 0 {
+		return sd
+	}
+
+	cp := *sd
+	cp.Attributes = make([]label.KeyValue, 0, len(sd.Attributes)+len(added))
+	cp.Attributes = append(cp.Attributes, sd.Attributes...)
+	cp.Attributes = append(cp.Attributes, added...)
+	return &cp
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type recordingSpanProcessor struct {
+	ended []*export.SpanData
+}
+
+func (r *recordingSpanProcessor) OnStart(*export.SpanData) {}
+func (r *recordingSpanProcessor) OnEnd(sd *export.SpanData) {
+	r.ended = append(r.ended, sd)
+}
+func (r *recordingSpanProcessor) Shutdown()   {}
+func (r *recordingSpanProcessor) ForceFlush() {}
+
+func TestResourceAttributesProcessor(t *testing.T) {
+	res := resource.New(
+		label.String("service.name", "checkout"),
+		label.String("host.name", "host-1"),
+		label.String("region", "eu"),
+	)
+	newSpanData := func() *export.SpanData {
+		return &export.SpanData{
+			Name:       "span",
+			Attributes: []label.KeyValue{label.String("region", "us")},
+			Resource:   res,
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts []ResourceAttributesProcessorOption
+		want []label.KeyValue
+	}{
+		{
+			name: "all keys",
+			want: []label.KeyValue{
+				label.String("region", "us"),
+				label.String("host.name", "host-1"),
+				label.String("service.name", "checkout"),
+			},
+		},
+		{
+			name: "selected keys",
+			opts: []ResourceAttributesProcessorOption{
+				WithCopiedResourceKeys("service.name", "missing"),
+			},
+			want: []label.KeyValue{
+				label.String("region", "us"),
+				label.String("service.name", "checkout"),
+			},
+		},
+		{
+			name: "span attributes take precedence",
+			opts: []ResourceAttributesProcessorOption{
+				WithCopiedResourceKeys("region"),
+			},
+			want: []label.KeyValue{
+				label.String("region", "us"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := new(recordingSpanProcessor)
+			p := NewResourceAttributesProcessor(rec, tc.opts...)
+			sd := newSpanData()
+			p.OnEnd(sd)
+
+			require.Len(t, rec.ended, 1)
+			assert.Equal(t, tc.want, rec.ended[0].Attributes)
+			assert.Equal(t, newSpanData(), sd, "original SpanData modified")
+		})
+	}
+}
+
+func TestResourceAttributesProcessorPipelines(t *testing.T) {
+	enriched := new(recordingSpanProcessor)
+	plain := new(recordingSpanProcessor)
+	tp := NewProvider(
+		WithResource(resource.New(label.String("service.name", "checkout"))),
+		WithSpanProcessor(NewResourceAttributesProcessor(enriched)),
+		WithSpanProcessor(plain),
+	)
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	require.Len(t, enriched.ended, 1)
+	require.Len(t, plain.ended, 1)
+	assert.Equal(t, []label.KeyValue{label.String("service.name", "checkout")}, enriched.ended[0].Attributes)
+	assert.Empty(t, plain.ended[0].Attributes)
+}
@@ -20,6 +20,7 @@ import (
 
 	api "go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/internal"
 )
 
 // Sampler decides whether a trace should be sampled and exported.
@@ -28,6 +29,18 @@ type Sampler interface {
 	Description() string
 }
 
+// shouldSample consults sampler, recovering a panic of sampler.  The
+// span is dropped if sampler panics.
+func shouldSample(sampler Sampler, params SamplingParameters) (result SamplingResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			internal.HandlePanic(r, "Sampler "+sampler.Description())
+			result = SamplingResult{Decision: Drop}
+		}
+	}()
+	return sampler.ShouldSample(params)
+}
+
 // SamplingParameters contains the values passed to a Sampler.
 type SamplingParameters struct {
 	ParentContext   api.SpanContext
@@ -56,20 +69,44 @@ const (
 	RecordAndSample
 )
 
+// String returns the name of the SamplingDecision.
+func (d SamplingDecision) String() string {
+	switch d {
+	case Drop:
+		return "Drop"
+	case RecordOnly:
+		return "RecordOnly"
+	case RecordAndSample:
+		return "RecordAndSample"
+	}
+	return fmt.Sprintf("SamplingDecision(%d)", uint8(d))
+}
+
+// MarshalText encodes the SamplingDecision as its name.
+func (d SamplingDecision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
 // SamplingResult conveys a SamplingDecision and a set of Attributes.
 type SamplingResult struct {
 	Decision   SamplingDecision
 	Attributes []label.KeyValue
 }
 
+// randomnessBits is the number of bits of the randomness of a trace ID:
+// its rightmost 7 bytes, which W3C Trace Context Level 2 requires to be
+// random.
+const randomnessBits = 56
+
 type traceIDRatioSampler struct {
-	traceIDUpperBound uint64
-	description       string
+	// threshold is the lowest randomness of the sampled trace IDs.
+	threshold   uint64
+	description string
 }
 
 func (ts traceIDRatioSampler) ShouldSample(p SamplingParameters) SamplingResult {
-	x := binary.BigEndian.Uint64(p.TraceID[0:8]) >> 1
-	if x < ts.traceIDUpperBound {
+	randomness := binary.BigEndian.Uint64(p.TraceID[8:16]) & (1<<randomnessBits - 1)
+	if randomness >= ts.threshold {
 		return SamplingResult{Decision: RecordAndSample}
 	}
 	return SamplingResult{Decision: Drop}
@@ -80,9 +117,16 @@ func (ts traceIDRatioSampler) Description() string {
 }
 
 // TraceIDRatioBased samples a given fraction of traces. Fractions >= 1 will
-// always sample. Fractions < 0 are treated as zero. To respect the
-// parent trace's `SampledFlag`, the `TraceIDRatioBased` sampler should be used
-// as a delegate of a `Parent` sampler.
+// always sample. Fractions < 0 are treated as zero.
+//
+// The decision depends only on the rightmost 7 bytes of the trace ID,
+// which W3C Trace Context Level 2 requires to be random: a trace is
+// sampled if they are at least (1 - fraction) * 2^56.  Thus samplers
+// with a lower fraction sample a subset of the traces sampled with a
+// higher fraction, in this and any process applying the same rule, so
+// that fractions can decrease along a trace without breaking it up.  To
+// respect the parent trace's `SampledFlag`, the `TraceIDRatioBased`
+// sampler should be used as a delegate of a `Parent` sampler.
 //nolint:golint // golint complains about stutter of `trace.TraceIDRatioBased`
 func TraceIDRatioBased(fraction float64) Sampler {
 	if fraction >= 1 {
@@ -94,8 +138,8 @@ func TraceIDRatioBased(fraction float64) Sampler {
 	}
 
 	return &traceIDRatioSampler{
-		traceIDUpperBound: uint64(fraction * (1 << 63)),
-		description:       fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
+		threshold:   uint64((1 - fraction) * (1 << randomnessBits)),
+		description: fmt.Sprintf("TraceIDRatioBased{%g}", fraction),
 	}
 }
 
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/internal"
+)
+
+// SamplingDecisionRecord describes an invocation of a Sampler.
+type SamplingDecisionRecord struct {
+	Time            time.Time
+	Name            string
+	TraceID         apitrace.ID
+	ParentSpanID    apitrace.SpanID
+	ParentSampled   bool
+	HasRemoteParent bool
+	Kind            string
+	Attributes      []label.KeyValue
+	Decision        SamplingDecision
+}
+
+// SamplingDecisionRecorder keeps the most recent sampling decisions
+// of a Provider in a ring buffer.  Pass its Record method to
+// WithSamplingDecisionCallback:
+//
+//	rec := trace.NewSamplingDecisionRecorder(1000)
+//	tp := trace.NewProvider(trace.WithSamplingDecisionCallback(rec.Record))
+//	http.Handle("/debug/sampling", rec)
+//
+// It implements http.Handler to serve the recorded decisions as JSON,
+// which helps to find out why a request was not sampled.
+type SamplingDecisionRecorder struct {
+	mu      sync.Mutex
+	records []SamplingDecisionRecord
+	next    int
+	full    bool
+}
+
+var _ http.Handler = (*SamplingDecisionRecorder)(nil)
+
+// NewSamplingDecisionRecorder returns a SamplingDecisionRecorder that
+// keeps the last size decisions.  A size less than one is treated as
+// one.
+func NewSamplingDecisionRecorder(size int) *SamplingDecisionRecorder {
+	if size < 1 {
+		size = 1
+	}
+	return &SamplingDecisionRecorder{
+		records: make([]SamplingDecisionRecord, size),
+	}
+}
+
+// Record records a sampling decision, evicting the oldest one if the
+// recorder is full.  It is a SamplingDecisionCallback.
+func (r *SamplingDecisionRecorder) Record(p SamplingParameters, res SamplingResult) {
+	rec := SamplingDecisionRecord{
+		Time:            internal.Now(),
+		Name:            p.Name,
+		TraceID:         p.TraceID,
+		ParentSpanID:    p.ParentContext.SpanID,
+		ParentSampled:   p.ParentContext.IsSampled(),
+		HasRemoteParent: p.HasRemoteParent,
+		Kind:            apitrace.ValidateSpanKind(p.Kind).String(),
+		Decision:        res.Decision,
+	}
+	if len(p.Attributes) > 0 {
+		rec.Attributes = append([]label.KeyValue(nil), p.Attributes...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[r.next] = rec
+	r.next++
+	if r.next == len(r.records) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Records returns the recorded decisions, oldest first.
+func (r *SamplingDecisionRecorder) Records() []SamplingDecisionRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]SamplingDecisionRecord(nil), r.records[:r.next]...)
+	}
+	out := make([]SamplingDecisionRecord, 0, len(r.records))
+	out = append(out, r.records[r.next:]...)
+	return append(out, r.records[:r.next]...)
+}
+
+// ServeHTTP serves the recorded decisions as a JSON array, oldest
+// first.  The optional "decision" and "name" query parameters restrict
+// the response to decisions with the given value, e.g.
+// "?decision=Drop&name=HTTP%20GET".
+func (r *SamplingDecisionRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	decision, name := query.Get("decision"), query.Get("name")
+
+	records := r.Records()
+	matching := make([]SamplingDecisionRecord, 0, len(records))
+	for _, rec := range records {
+		if decision != "" && rec.Decision.String() != decision {
+			continue
+		}
+		if name != "" && rec.Name != name {
+			continue
+		}
+		matching = append(matching, rec)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(matching); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/label"
+)
+
+// nameSampler samples spans named "sampled".
+type nameSampler struct{}
+
+func (nameSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	if p.Name == "sampled" {
+		return SamplingResult{Decision: RecordAndSample}
+	}
+	return SamplingResult{Decision: Drop}
+}
+
+func (nameSampler) Description() string { return "nameSampler" }
+
+func TestSamplingDecisionCallback(t *testing.T) {
+	var got []SamplingDecision
+	tp := NewProvider(
+		WithConfig(Config{DefaultSampler: nameSampler{}}),
+		WithSyncer(NewTestExporter()),
+		WithSamplingDecisionCallback(func(p SamplingParameters, r SamplingResult) {
+			got = append(got, r.Decision)
+		}),
+	)
+	tr := tp.Tracer("SamplingDecisionCallback")
+
+	ctx, parent := tr.Start(context.Background(), "sampled")
+	_, child := tr.Start(ctx, "dropped")
+	_, root := tr.Start(context.Background(), "dropped")
+	assert.True(t, child.SpanContext().IsSampled(), "child of a local sampled span")
+	assert.False(t, root.SpanContext().IsSampled())
+
+	// The child inherits the decision of its local parent without
+	// consulting the Sampler.
+	assert.Equal(t, []SamplingDecision{RecordAndSample, Drop}, got)
+
+	// Enabled does not report decisions.
+	tr.(api.EnabledTracer).Enabled(context.Background())
+	assert.Len(t, got, 2)
+	parent.End()
+}
+
+func TestSamplingDecisionRecorder(t *testing.T) {
+	rec := NewSamplingDecisionRecorder(2)
+	assert.Empty(t, rec.Records())
+
+	params := func(name string) SamplingParameters {
+		return SamplingParameters{
+			Name:       name,
+			TraceID:    api.ID{1},
+			Kind:       api.SpanKindServer,
+			Attributes: []label.KeyValue{label.String("k", "v")},
+		}
+	}
+	rec.Record(params("a"), SamplingResult{Decision: Drop})
+	rec.Record(params("b"), SamplingResult{Decision: RecordAndSample})
+	rec.Record(params("c"), SamplingResult{Decision: Drop})
+
+	records := rec.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "b", records[0].Name)
+	assert.Equal(t, "c", records[1].Name)
+	assert.Equal(t, "server", records[1].Kind)
+	assert.Equal(t, api.ID{1}, records[1].TraceID)
+	assert.Equal(t, []label.KeyValue{label.String("k", "v")}, records[1].Attributes)
+	assert.Equal(t, Drop, records[1].Decision)
+}
+
+func TestSamplingDecisionRecorderServeHTTP(t *testing.T) {
+	rec := NewSamplingDecisionRecorder(10)
+	tp := NewProvider(
+		WithConfig(Config{DefaultSampler: nameSampler{}}),
+		WithSamplingDecisionCallback(rec.Record),
+	)
+	tr := tp.Tracer("SamplingDecisionRecorder")
+	tr.Start(context.Background(), "sampled")
+	tr.Start(context.Background(), "dropped")
+
+	for _, tc := range []struct {
+		query    string
+		expected []string
+	}{
+		{query: "", expected: []string{"sampled", "dropped"}},
+		{query: "?decision=Drop", expected: []string{"dropped"}},
+		{query: "?name=sampled", expected: []string{"sampled"}},
+		{query: "?decision=RecordOnly", expected: []string{}},
+	} {
+		w := httptest.NewRecorder()
+		rec.ServeHTTP(w, httptest.NewRequest("GET", "/debug/sampling"+tc.query, nil))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var decoded []struct {
+			Name     string
+			Decision string
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded))
+		names := []string{}
+		for _, d := range decoded {
+			names = append(names, d.Name)
+			if d.Name == "sampled" {
+				assert.Equal(t, "RecordAndSample", d.Decision)
+			}
+		}
+		assert.Equal(t, tc.expected, names, tc.query)
+	}
+}
+
+func TestSamplingDecisionString(t *testing.T) {
+	assert.Equal(t, "Drop", Drop.String())
+	assert.Equal(t, "RecordOnly", RecordOnly.String())
+	assert.Equal(t, "RecordAndSample", RecordAndSample.String())
+	assert.Equal(t, "SamplingDecision(7)", SamplingDecision(7).String())
+}
@@ -188,3 +188,24 @@ func TestTraceIdRatioSamplesInclusively(t *testing.T) {
 		}
 	}
 }
+
+func TestTraceIdRatioUsesRandomness(t *testing.T) {
+	sampler := TraceIDRatioBased(0.5)
+	for _, tc := range []struct {
+		traceID api.ID
+		sampled bool
+	}{
+		// The leftmost byte is not random and ignored.
+		{api.ID{0xff, 8: 0x00, 9: 0x7f, 15: 0xff}, false},
+		{api.ID{0x00, 8: 0xff, 9: 0x7f, 15: 0xff}, false},
+		{api.ID{0xff, 9: 0x80}, true},
+		{api.ID{0x00, 8: 0x00, 9: 0x80}, true},
+	} {
+		decision := sampler.ShouldSample(SamplingParameters{TraceID: tc.traceID}).Decision
+		require.Equal(t, tc.sampled, decision == RecordAndSample, "%s", tc.traceID)
+	}
+
+	require.Equal(t, Drop, TraceIDRatioBased(0).ShouldSample(SamplingParameters{
+		TraceID: api.ID{8: 0xff, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff},
+	}).Decision)
+}
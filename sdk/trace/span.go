@@ -57,12 +57,27 @@ type span struct {
 	// links are stored in FIFO queue capped by configured limit.
 	links *evictedQueue
 
+	// parentLinks is the number of links added for the parent
+	// context ignored by a new root span.
+	parentLinks int
+
+	// maxAttributesPerLink is the configured limit of attributes
+	// of each link.
+	maxAttributesPerLink int
+
 	// spanStore is the spanStore this span belongs to, if any, otherwise it is nil.
 	//*spanStore
 	endOnce sync.Once
 
 	executionTracerTaskEnd func()  // ends the execution tracer span
 	tracer                 *tracer // tracer used to create span.
+
+	// monoStart is a reading of the monotonic clock taken when the
+	// start time was read from the SDK clock, or zero if it was
+	// passed with WithTimestamp.  The end time is measured from it,
+	// so that the duration of the span is the elapsed time even if
+	// the SDK clock is re-anchored while the span is live.
+	monoStart time.Time
 }
 
 var _ apitrace.Span = &span{}
@@ -124,7 +139,7 @@ func (s *span) End(options ...apitrace.SpanOption) {
 		// Record but don't stop the panic.
 		defer panic(recovered)
 		s.addEventWithTimestamp(
-			time.Now(),
+			internal.Now(),
 			errorEventName,
 			errorTypeKey.String(typeStr(recovered)),
 			errorMessageKey.String(fmt.Sprint(recovered)),
@@ -139,17 +154,21 @@ func (s *span) End(options ...apitrace.SpanOption) {
 	}
 	config := apitrace.NewSpanConfig(options...)
 	s.endOnce.Do(func() {
+		s.tracer.provider.releaseLiveSpan()
 		sps, _ := s.tracer.provider.spanProcessors.Load().(spanProcessorMap)
 		mustExportOrProcess := len(sps) > 0
 		if mustExportOrProcess {
 			sd := s.makeSpanData()
-			if config.Timestamp.IsZero() {
-				sd.EndTime = internal.MonotonicEndTime(sd.StartTime)
-			} else {
+			switch {
+			case !config.Timestamp.IsZero():
 				sd.EndTime = config.Timestamp
+			case !s.monoStart.IsZero():
+				sd.EndTime = sd.StartTime.Add(time.Since(s.monoStart))
+			default:
+				sd.EndTime = internal.MonotonicEndTime(sd.StartTime)
 			}
 			for sp := range sps {
-				sp.OnEnd(sd)
+				spanProcessorOnEnd(sp, sd)
 			}
 		}
 	})
@@ -171,7 +190,7 @@ func (s *span) RecordError(ctx context.Context, err error, opts ...apitrace.Erro
 	}
 
 	if cfg.Timestamp.IsZero() {
-		cfg.Timestamp = time.Now()
+		cfg.Timestamp = internal.Now()
 	}
 
 	if cfg.StatusCode != codes.OK {
@@ -201,7 +220,7 @@ func (s *span) AddEvent(ctx context.Context, name string, attrs ...label.KeyValu
 	if !s.IsRecording() {
 		return
 	}
-	s.addEventWithTimestamp(time.Now(), name, attrs...)
+	s.addEventWithTimestamp(internal.Now(), name, attrs...)
 }
 
 func (s *span) AddEventWithTimestamp(ctx context.Context, timestamp time.Time, name string, attrs ...label.KeyValue) {
@@ -231,6 +250,9 @@ func (s *span) SetName(name string) {
 		global.Handle(errUninitializedSpan)
 		return
 	}
+	if s.tracer != nil {
+		name = s.tracer.provider.formatSpanName(name)
+	}
 	s.data.Name = name
 	// SAMPLING
 	noParent := !s.data.ParentSpanID.IsValid()
@@ -250,8 +272,9 @@ func (s *span) SetName(name string) {
 		cfg:          s.tracer.provider.config.Load().(*Config),
 		span:         s,
 		attributes:   s.data.Attributes,
-		links:        s.data.Links,
+		links:        apiLinks(s.data.Links),
 		kind:         s.data.SpanKind,
+		callback:     s.tracer.provider.samplingCallback,
 	}
 	sampled := makeSamplingDecision(data)
 
@@ -266,9 +289,27 @@ func (s *span) addLink(link apitrace.Link) {
 	if !s.IsRecording() {
 		return
 	}
+	l := export.Link{Link: link}
+	if n := len(link.Attributes); n > s.maxAttributesPerLink {
+		l.DroppedAttributeCount = n - s.maxAttributesPerLink
+		l.Attributes = append([]label.KeyValue(nil), link.Attributes[:s.maxAttributesPerLink]...)
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.links.add(link)
+	s.links.add(l)
+}
+
+// addParentLink adds a link to the parent context ignored by a new
+// root span, counting it apart from the links passed when the span was
+// started.
+func (s *span) addParentLink(link apitrace.Link) {
+	if !s.IsRecording() {
+		return
+	}
+	s.addLink(link)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.parentLinks++
 }
 
 // makeSpanData produces a SpanData representing the current state of the span.
@@ -288,18 +329,36 @@ func (s *span) makeSpanData() *export.SpanData {
 	if len(s.links.queue) > 0 {
 		sd.Links = s.interfaceArrayToLinksArray()
 		sd.DroppedLinkCount = s.links.droppedCount
+		// The parent links are added last, so they are the last to
+		// be evicted.
+		sd.ParentLinkCount = s.parentLinks
+		if n := len(s.links.queue); sd.ParentLinkCount > n {
+			sd.ParentLinkCount = n
+		}
 	}
 	return &sd
 }
 
-func (s *span) interfaceArrayToLinksArray() []apitrace.Link {
-	linkArr := make([]apitrace.Link, 0)
+func (s *span) interfaceArrayToLinksArray() []export.Link {
+	linkArr := make([]export.Link, 0)
 	for _, value := range s.links.queue {
-		linkArr = append(linkArr, value.(apitrace.Link))
+		linkArr = append(linkArr, value.(export.Link))
 	}
 	return linkArr
 }
 
+// apiLinks returns the API links of the passed SDK links.
+func apiLinks(links []export.Link) []apitrace.Link {
+	if links == nil {
+		return nil
+	}
+	apiLinks := make([]apitrace.Link, 0, len(links))
+	for _, l := range links {
+		apiLinks = append(apiLinks, l.Link)
+	}
+	return apiLinks
+}
+
 func (s *span) interfaceArrayToMessageEventArray() []export.Event {
 	messageEventArr := make([]export.Event, 0)
 	for _, value := range s.messageEvents.queue {
@@ -349,6 +408,7 @@ func startSpanInternal(tr *tracer, name string, parent apitrace.SpanContext, rem
 		attributes:   o.Attributes,
 		links:        o.Links,
 		kind:         o.SpanKind,
+		callback:     tr.provider.samplingCallback,
 	}
 	sampled := makeSamplingDecision(data)
 
@@ -357,10 +417,17 @@ func startSpanInternal(tr *tracer, name string, parent apitrace.SpanContext, rem
 	if !span.spanContext.IsSampled() && !o.Record {
 		return span
 	}
+	if !tr.provider.acquireLiveSpan() {
+		// The span is not recorded, so its descendants must not
+		// be sampled either.
+		span.spanContext.TraceFlags &^= apitrace.FlagsSampled
+		return span
+	}
 
 	startTime := o.Timestamp
 	if startTime.IsZero() {
-		startTime = time.Now()
+		startTime = internal.Now()
+		span.monoStart = time.Now()
 	}
 	span.data = &export.SpanData{
 		SpanContext:            span.spanContext,
@@ -374,6 +441,7 @@ func startSpanInternal(tr *tracer, name string, parent apitrace.SpanContext, rem
 	span.attributes = newAttributesMap(cfg.MaxAttributesPerSpan)
 	span.messageEvents = newEvictedQueue(cfg.MaxEventsPerSpan)
 	span.links = newEvictedQueue(cfg.MaxLinksPerSpan)
+	span.maxAttributesPerLink = cfg.MaxAttributesPerLink
 
 	span.SetAttributes(sampled.Attributes...)
 
@@ -402,6 +470,7 @@ type samplingData struct {
 	attributes   []label.KeyValue
 	links        []apitrace.Link
 	kind         apitrace.SpanKind
+	callback     SamplingDecisionCallback
 }
 
 func makeSamplingDecision(data samplingData) SamplingResult {
@@ -417,7 +486,7 @@ func makeSamplingDecision(data samplingData) SamplingResult {
 		//	sampler = o.Sampler
 		//}
 		spanContext := &data.span.spanContext
-		sampled := sampler.ShouldSample(SamplingParameters{
+		params := SamplingParameters{
 			ParentContext:   data.parent,
 			TraceID:         spanContext.TraceID,
 			Name:            data.name,
@@ -425,7 +494,11 @@ func makeSamplingDecision(data samplingData) SamplingResult {
 			Kind:            data.kind,
 			Attributes:      data.attributes,
 			Links:           data.links,
-		})
+		}
+		sampled := shouldSample(sampler, params)
+		if data.callback != nil {
+			data.callback(params, sampled)
+		}
 		if sampled.Decision == RecordAndSample {
 			spanContext.TraceFlags |= apitrace.FlagsSampled
 		} else {
@@ -18,6 +18,7 @@ import (
 	"sync"
 
 	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/internal"
 )
 
 // SpanProcessor is interface to add hooks to start and end method invocations.
@@ -44,3 +45,17 @@ type SpanProcessor interface {
 }
 
 type spanProcessorMap map[SpanProcessor]*sync.Once
+
+// spanProcessorOnStart calls sp.OnStart, recovering a panic of sp so
+// that it does not prevent the other SpanProcessors from being called.
+func spanProcessorOnStart(sp SpanProcessor, sd *export.SpanData) {
+	defer internal.RecoverPanic("SpanProcessor.OnStart")
+	sp.OnStart(sd)
+}
+
+// spanProcessorOnEnd calls sp.OnEnd, recovering a panic of sp so that
+// it does not prevent the other SpanProcessors from being called.
+func spanProcessorOnEnd(sp SpanProcessor, sd *export.SpanData) {
+	defer internal.RecoverPanic("SpanProcessor.OnEnd")
+	sp.OnEnd(sd)
+}
@@ -18,7 +18,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	opentelemetry "go.opentelemetry.io/otel/sdk"
 	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type testSpanProcesor struct {
@@ -159,6 +163,63 @@ func TestMultipleUnregisterSpanProcessorCalls(t *testing.T) {
 	}
 }
 
+type panickingSpanProcessor struct {
+	testSpanProcesor
+}
+
+func (p *panickingSpanProcessor) OnStart(s *export.SpanData) {
+	panic("OnStart")
+}
+
+func (p *panickingSpanProcessor) OnEnd(s *export.SpanData) {
+	panic("OnEnd")
+}
+
+type panickingSampler struct{}
+
+func (panickingSampler) ShouldSample(sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	panic("ShouldSample")
+}
+
+func (panickingSampler) Description() string {
+	return "panickingSampler"
+}
+
+func TestSpanProcessorPanicRecovery(t *testing.T) {
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(&panickingSpanProcessor{})
+	sp := NewTestSpanProcessor()
+	tp.RegisterSpanProcessor(sp)
+
+	tr := tp.Tracer("SpanProcessor")
+	assert.NotPanics(t, func() {
+		_, span := tr.Start(context.Background(), "OnStart")
+		span.End()
+	})
+	assert.Len(t, sp.spansStarted, 1)
+	assert.Len(t, sp.spansEnded, 1)
+
+	opentelemetry.SetPanicRecovery(false)
+	defer opentelemetry.SetPanicRecovery(true)
+	assert.PanicsWithValue(t, "OnStart", func() {
+		tr.Start(context.Background(), "OnStart")
+	})
+}
+
+func TestSamplerPanicRecovery(t *testing.T) {
+	tp := sdktrace.NewProvider(sdktrace.WithConfig(sdktrace.Config{DefaultSampler: panickingSampler{}}))
+	sp := NewTestSpanProcessor()
+	tp.RegisterSpanProcessor(sp)
+
+	tr := tp.Tracer("Sampler")
+	var span interface{ IsRecording() bool }
+	assert.NotPanics(t, func() {
+		_, span = tr.Start(context.Background(), "span")
+	})
+	assert.False(t, span.IsRecording(), "a span must be dropped when its Sampler panics")
+	assert.Empty(t, sp.spansStarted)
+}
+
 func NewTestSpanProcessor() *testSpanProcesor {
 	return &testSpanProcesor{}
 }
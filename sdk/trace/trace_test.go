@@ -19,11 +19,14 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+	"unsafe"
 
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/label"
@@ -40,6 +43,7 @@ import (
 	ottest "go.opentelemetry.io/otel/internal/testing"
 	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -48,15 +52,49 @@ var (
 	sid apitrace.SpanID
 )
 
-type discardHandler struct{}
+// testErrorHandler is the global error handler of the tests.  It
+// discards errors, except while a test records them with recordErrors.
+type testErrorHandler struct {
+	mu        sync.Mutex
+	recording bool
+	errs      []error
+}
 
-func (*discardHandler) Handle(_ error) {}
+func (h *testErrorHandler) Handle(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.recording {
+		h.errs = append(h.errs, err)
+	}
+}
+
+var testHandler = new(testErrorHandler)
+
+// recordErrors records the errors handled until t completes.  The
+// returned function returns the errors recorded so far.
+func recordErrors(t *testing.T) func() []error {
+	testHandler.mu.Lock()
+	testHandler.recording = true
+	testHandler.errs = nil
+	testHandler.mu.Unlock()
+	t.Cleanup(func() {
+		testHandler.mu.Lock()
+		testHandler.recording = false
+		testHandler.errs = nil
+		testHandler.mu.Unlock()
+	})
+	return func() []error {
+		testHandler.mu.Lock()
+		defer testHandler.mu.Unlock()
+		return append([]error(nil), testHandler.errs...)
+	}
+}
 
 func init() {
 	tid, _ = apitrace.IDFromHex("01020304050607080102040810203040")
 	sid, _ = apitrace.SpanIDFromHex("0102040810203040")
 
-	global.SetErrorHandler(new(discardHandler))
+	global.SetErrorHandler(testHandler)
 }
 
 func TestTracerFollowsExpectedAPIBehaviour(t *testing.T) {
@@ -214,6 +252,155 @@ func TestRecordingIsOn(t *testing.T) {
 	}
 }
 
+// recordOnlySampler records every span without sampling it.
+type recordOnlySampler struct{}
+
+func (recordOnlySampler) ShouldSample(SamplingParameters) SamplingResult {
+	return SamplingResult{Decision: RecordOnly}
+}
+
+func (recordOnlySampler) Description() string { return "recordOnlySampler" }
+
+func TestTracerEnabled(t *testing.T) {
+	ctx := context.Background()
+	psc := apitrace.SpanContext{
+		TraceID: apitrace.ID{1},
+		SpanID:  apitrace.SpanID{1},
+	}
+	sampledCtx := apitrace.ContextWithRemoteSpanContext(ctx, apitrace.SpanContext{
+		TraceID:    psc.TraceID,
+		SpanID:     psc.SpanID,
+		TraceFlags: apitrace.FlagsSampled,
+	})
+	unsampledCtx := apitrace.ContextWithRemoteSpanContext(ctx, psc)
+
+	for name, tc := range map[string]struct {
+		sampler    Sampler
+		noExporter bool
+		// random is true if the sampling decision of a started span
+		// depends on its random trace ID.
+		random bool
+		ctx    context.Context
+		opts   []apitrace.SpanOption
+		expect bool
+	}{
+		"NoSpanProcessor":          {sampler: AlwaysSample(), noExporter: true, ctx: ctx, expect: false},
+		"AlwaysSample":             {sampler: AlwaysSample(), ctx: ctx, expect: true},
+		"NeverSample":              {sampler: NeverSample(), ctx: ctx, expect: false},
+		"NeverSampleWithRecord":    {sampler: NeverSample(), ctx: ctx, opts: []apitrace.SpanOption{apitrace.WithRecord()}, expect: true},
+		"TraceIDRatioBased_.50":    {sampler: TraceIDRatioBased(0.5), random: true, ctx: ctx, expect: true},
+		"TraceIDRatioBased_0":      {sampler: TraceIDRatioBased(0), ctx: ctx, expect: false},
+		"ParentBasedSampledParent": {sampler: ParentBased(NeverSample()), ctx: sampledCtx, expect: true},
+		"ParentBasedUnsampled":     {sampler: ParentBased(AlwaysSample()), ctx: unsampledCtx, expect: false},
+		"ParentBasedNewRoot":       {sampler: ParentBased(AlwaysSample()), ctx: unsampledCtx, opts: []apitrace.SpanOption{apitrace.WithNewRoot()}, expect: true},
+		"RecordOnly":               {sampler: recordOnlySampler{}, ctx: ctx, expect: false},
+		"RecordOnlyWithRecord":     {sampler: recordOnlySampler{}, ctx: ctx, opts: []apitrace.SpanOption{apitrace.WithRecord()}, expect: true},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			opts := []ProviderOption{WithConfig(Config{DefaultSampler: tc.sampler})}
+			if !tc.noExporter {
+				opts = append(opts, WithSyncer(&testExporter{}))
+			}
+			tr := NewProvider(opts...).Tracer("test")
+
+			got := tr.(apitrace.EnabledTracer).Enabled(tc.ctx, tc.opts...)
+			if got != tc.expect {
+				t.Errorf("Enabled() = %t, expected %t", got, tc.expect)
+			}
+
+			// Enabled must agree with the recording state of a
+			// span started with the same arguments.
+			if !tc.noExporter && !tc.random {
+				_, span := tr.Start(tc.ctx, "span", tc.opts...)
+				if span.IsRecording() != got {
+					t.Errorf("IsRecording() = %t, Enabled() = %t", span.IsRecording(), got)
+				}
+			}
+		})
+	}
+}
+
+func TestWithDisabledScopes(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(
+		WithSyncer(te),
+		WithDisabledScopes("noisy/*", "exact", "[malformed"),
+	)
+
+	ctx, parent := tp.Tracer("app").Start(context.Background(), "parent")
+	for _, name := range []string{"noisy/library", "exact"} {
+		tr := tp.Tracer(name)
+		assert.False(t, tr.(apitrace.EnabledTracer).Enabled(ctx), name)
+
+		dctx, span := tr.Start(ctx, "disabled")
+		assert.False(t, span.IsRecording(), name)
+		assert.Equal(t, parent, apitrace.SpanFromContext(dctx), "context of a disabled span must keep its parent")
+		span.End()
+	}
+
+	// Patterns match whole names; "*" does not match "/".
+	for _, name := range []string{"noisy/library/sub", "exact/sub", "app"} {
+		_, span := tp.Tracer(name).Start(ctx, "enabled")
+		assert.True(t, span.IsRecording(), name)
+		span.End()
+	}
+	parent.End()
+
+	assert.Equal(t, 4, te.Len())
+	_, ok := te.GetSpan("disabled")
+	assert.False(t, ok)
+}
+
+func TestWithMaxLiveSpans(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithSyncer(te), WithMaxLiveSpans(2))
+	tr := tp.Tracer("app")
+
+	_, s1 := tr.Start(context.Background(), "s1")
+	_, s2 := tr.Start(context.Background(), "s2")
+	_, dropped := tr.Start(context.Background(), "dropped")
+	assert.True(t, s1.IsRecording())
+	assert.True(t, s2.IsRecording())
+	assert.False(t, dropped.IsRecording())
+	assert.False(t, dropped.SpanContext().IsSampled())
+	assert.Equal(t, int64(2), tp.LiveSpans())
+
+	dropped.End()
+	s1.End()
+	s1.End()
+	assert.Equal(t, int64(1), tp.LiveSpans())
+
+	_, s3 := tr.Start(context.Background(), "s3")
+	assert.True(t, s3.IsRecording())
+	s2.End()
+	s3.End()
+	assert.Equal(t, int64(0), tp.LiveSpans())
+
+	assert.Equal(t, 3, te.Len())
+	_, ok := te.GetSpan("dropped")
+	assert.False(t, ok)
+}
+
+func TestProviderAlignment(t *testing.T) {
+	fields := []ottest.FieldOffset{
+		{Name: "Provider.liveSpans", Offset: unsafe.Offsetof(Provider{}.liveSpans)},
+		{Name: "Provider.maxLiveSpans", Offset: unsafe.Offsetof(Provider{}.maxLiveSpans)},
+	}
+	if !ottest.Aligned8Byte(fields, os.Stderr) {
+		t.Fail()
+	}
+}
+
+func TestLiveSpansNotCountedWithoutLimit(t *testing.T) {
+	tp := NewProvider(WithSyncer(NewTestExporter()))
+	_, span := tp.Tracer("app").Start(context.Background(), "span")
+	assert.True(t, span.IsRecording())
+	assert.Equal(t, int64(0), tp.LiveSpans())
+	span.End()
+	assert.Equal(t, int64(0), tp.LiveSpans())
+}
+
 func TestSampling(t *testing.T) {
 	idg := defIDGenerator()
 	const total = 10000
@@ -536,6 +723,68 @@ func TestEventsOverLimit(t *testing.T) {
 	}
 }
 
+func TestApplyConfigLimits(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithConfig(Config{MaxEventsPerSpan: 1}), WithSyncer(te))
+
+	before := startSpan(tp, "ApplyConfigLimits")
+	tp.ApplyConfig(Config{MaxEventsPerSpan: 3})
+	after := startSpan(tp, "ApplyConfigLimits")
+
+	if got := tp.Config().MaxEventsPerSpan; got != 3 {
+		t.Errorf("MaxEventsPerSpan: got %d, want 3", got)
+	}
+	if got := tp.Config().MaxLinksPerSpan; got != DefaultMaxLinksPerSpan {
+		t.Errorf("MaxLinksPerSpan: got %d, want %d", got, DefaultMaxLinksPerSpan)
+	}
+
+	for _, span := range []apitrace.Span{before, after} {
+		for i := 0; i < 3; i++ {
+			span.AddEvent(context.Background(), fmt.Sprint("event", i))
+		}
+		span.End()
+	}
+
+	spans := te.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d exported spans, want 2", len(spans))
+	}
+	// The span started before ApplyConfig keeps its original limit.
+	if got := len(spans[0].MessageEvents); got != 1 {
+		t.Errorf("span started before ApplyConfig: got %d events, want 1", got)
+	}
+	if got := len(spans[1].MessageEvents); got != 3 {
+		t.Errorf("span started after ApplyConfig: got %d events, want 3", got)
+	}
+}
+
+func TestApplyConfigConcurrentStart(t *testing.T) {
+	tp := NewProvider(WithSyncer(NewTestExporter()))
+	tr := tp.Tracer("ApplyConfigConcurrentStart")
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				tp.ApplyConfig(Config{MaxAttributesPerSpan: i%8 + 1})
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.SetAttributes(label.Int("i", i))
+		span.End()
+	}
+	close(stop)
+	wg.Wait()
+}
+
 func TestLinks(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewProvider(WithSyncer(te))
@@ -563,10 +812,13 @@ func TestLinks(t *testing.T) {
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		},
-		ParentSpanID:           sid,
-		Name:                   "span0",
-		HasRemoteParent:        true,
-		Links:                  links,
+		ParentSpanID:    sid,
+		Name:            "span0",
+		HasRemoteParent: true,
+		Links: []export.Link{
+			{Link: links[0]},
+			{Link: links[1]},
+		},
 		SpanKind:               apitrace.SpanKindInternal,
 		InstrumentationLibrary: instrumentation.Library{Name: "Links"},
 	}
@@ -608,9 +860,9 @@ func TestLinksOverLimit(t *testing.T) {
 		},
 		ParentSpanID: sid,
 		Name:         "span0",
-		Links: []apitrace.Link{
-			{SpanContext: sc2, Attributes: []label.KeyValue{k2v2}},
-			{SpanContext: sc3, Attributes: []label.KeyValue{k3v3}},
+		Links: []export.Link{
+			{Link: apitrace.Link{SpanContext: sc2, Attributes: []label.KeyValue{k2v2}}},
+			{Link: apitrace.Link{SpanContext: sc3, Attributes: []label.KeyValue{k3v3}}},
 		},
 		DroppedLinkCount:       1,
 		HasRemoteParent:        true,
@@ -622,6 +874,89 @@ func TestLinksOverLimit(t *testing.T) {
 	}
 }
 
+func TestLinkAttributesOverLimit(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithConfig(Config{MaxAttributesPerLink: 2}), WithSyncer(te))
+
+	sc := apitrace.SpanContext{TraceID: apitrace.ID([16]byte{1, 1}), SpanID: apitrace.SpanID{3}}
+	attrs := []label.KeyValue{
+		label.String("key1", "value1"),
+		label.String("key2", "value2"),
+		label.String("key3", "value3"),
+	}
+	span := startSpan(tp, "LinkAttributesOverLimit",
+		apitrace.WithLinks(
+			apitrace.Link{SpanContext: sc, Attributes: attrs},
+			apitrace.Link{SpanContext: sc, Attributes: attrs[:1]},
+		),
+	)
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []export.Link{
+		{Link: apitrace.Link{SpanContext: sc, Attributes: attrs[:2]}, DroppedAttributeCount: 1},
+		{Link: apitrace.Link{SpanContext: sc, Attributes: attrs[:1]}},
+	}
+	if diff := cmpDiff(got.Links, want); diff != "" {
+		t.Errorf("Link attributes over limit: -got +want %s", diff)
+	}
+	assert.Len(t, attrs, 3, "attributes passed with WithLinks modified")
+}
+
+func TestNewRootLinkOverLimit(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithConfig(Config{MaxLinksPerSpan: 1}), WithSyncer(te))
+	tr := tp.Tracer("NewRootLink")
+
+	ctx, parent := tr.Start(context.Background(), "parent")
+	sc := apitrace.SpanContext{TraceID: apitrace.ID([16]byte{1, 1}), SpanID: apitrace.SpanID{3}}
+	_, child := tr.Start(ctx, "child",
+		apitrace.WithNewRoot(),
+		apitrace.WithLinks(apitrace.Link{SpanContext: sc}),
+	)
+	child.End()
+	parent.End()
+
+	got, ok := te.GetSpan("child")
+	require.True(t, ok)
+	assert.NotEqual(t, parent.SpanContext().TraceID, got.SpanContext.TraceID)
+	assert.Equal(t, []export.Link{{Link: apitrace.Link{
+		SpanContext: parent.SpanContext(),
+		Attributes:  []label.KeyValue{label.String("ignored-on-demand", "current")},
+	}}}, got.Links)
+	assert.Equal(t, 1, got.DroppedLinkCount)
+	assert.Equal(t, 1, got.ParentLinkCount)
+}
+
+func TestNewRootLinkCount(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithSyncer(te))
+	tr := tp.Tracer("NewRootLink")
+
+	sc := apitrace.SpanContext{TraceID: apitrace.ID([16]byte{1, 1}), SpanID: apitrace.SpanID{3}}
+	remote := apitrace.ContextWithRemoteSpanContext(context.Background(), apitrace.SpanContext{
+		TraceID: apitrace.ID([16]byte{2, 2}), SpanID: apitrace.SpanID{4}, TraceFlags: apitrace.FlagsSampled,
+	})
+	ctx, parent := tr.Start(remote, "parent")
+	_, child := tr.Start(ctx, "child",
+		apitrace.WithNewRoot(),
+		apitrace.WithLinks(apitrace.Link{SpanContext: sc}),
+	)
+	child.End()
+	parent.End()
+
+	got, ok := te.GetSpan("child")
+	require.True(t, ok)
+	assert.Len(t, got.Links, 3)
+	assert.Equal(t, 2, got.ParentLinkCount)
+
+	got, ok = te.GetSpan("parent")
+	require.True(t, ok)
+	assert.Equal(t, 0, got.ParentLinkCount)
+}
+
 func TestSetSpanName(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewProvider(WithSyncer(te))
@@ -644,6 +979,30 @@ func TestSetSpanName(t *testing.T) {
 	}
 }
 
+func TestSpanNameFormatter(t *testing.T) {
+	te := NewTestExporter()
+	digits := regexp.MustCompile(`/[0-9]+`)
+	tp := NewProvider(
+		WithSyncer(te),
+		WithConfig(Config{DefaultSampler: AlwaysSample()}),
+		WithSpanNameFormatter(func(name string) string {
+			return digits.ReplaceAllString(name, "/{id}")
+		}),
+	)
+	tr := tp.Tracer("SpanNameFormatter")
+
+	_, started := tr.Start(context.Background(), "GET /users/1234")
+	started.End()
+	_, renamed := tr.Start(context.Background(), "GET")
+	renamed.SetName("GET /orders/42/items/7")
+	renamed.End()
+
+	spans := te.Spans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /users/{id}", spans[0].Name)
+	assert.Equal(t, "GET /orders/{id}/items/{id}", spans[1].Name)
+}
+
 func TestSetSpanStatus(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewProvider(WithSyncer(te))
@@ -1096,6 +1455,44 @@ func TestRecordErrorNil(t *testing.T) {
 	}
 }
 
+func TestSpanTimestampsUseSDKClock(t *testing.T) {
+	anchor := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	defer internal.SetClockAnchor(anchor)()
+
+	te := NewTestExporter()
+	tp := NewProvider(WithSyncer(te))
+	_, span := tp.Tracer("SDKClock").Start(context.Background(), "span")
+	span.AddEvent(context.Background(), "event")
+	span.End()
+	require.Equal(t, 1, te.Len())
+	got := te.Spans()[0]
+
+	for _, ts := range []time.Time{got.StartTime, got.MessageEvents[0].Time, got.EndTime} {
+		assert.False(t, ts.Before(anchor), ts)
+		assert.Less(t, int64(ts.Sub(anchor)), int64(time.Second), ts)
+	}
+	assert.False(t, got.EndTime.Before(got.StartTime))
+}
+
+func TestSpanDurationIgnoresClockReanchor(t *testing.T) {
+	anchor := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	defer internal.SetClockAnchor(anchor)()
+
+	te := NewTestExporter()
+	tp := NewProvider(WithSyncer(te))
+	_, span := tp.Tracer("SDKClock").Start(context.Background(), "span")
+	// The SDK clock is re-anchored an hour earlier while the span
+	// is live.
+	restore := internal.SetClockAnchor(anchor.Add(-time.Hour))
+	span.End()
+	restore()
+
+	require.Equal(t, 1, te.Len())
+	got := te.Spans()[0]
+	assert.False(t, got.EndTime.Before(got.StartTime))
+	assert.Less(t, int64(got.EndTime.Sub(got.StartTime)), int64(time.Second))
+}
+
 func TestWithSpanKind(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewProvider(WithSyncer(te), WithConfig(Config{DefaultSampler: AlwaysSample()}))
@@ -1223,3 +1620,105 @@ func TestSpanCapturesPanic(t *testing.T) {
 		errorMessageKey.String("error message"),
 	})
 }
+
+type panicExporter struct{}
+
+func (panicExporter) ExportSpans(context.Context, []*export.SpanData) error {
+	panic("export")
+}
+
+func (panicExporter) Shutdown(context.Context) error { return nil }
+
+func TestSafeExportSpansReturnsPanic(t *testing.T) {
+	err := safeExportSpans(context.Background(), panicExporter{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recovered panic of SpanExporter.ExportSpans: export")
+}
+
+func TestLeakDetectorReportsOnce(t *testing.T) {
+	errs := recordErrors(t)
+	// The background check is not run during the test, which calls
+	// check itself.
+	ld := NewLeakDetector(time.Millisecond, WithLeakCheckInterval(time.Hour))
+	defer ld.Shutdown()
+	tp := NewProvider(WithSpanProcessor(ld))
+
+	reports := func() int {
+		var n int
+		for _, err := range errs() {
+			if strings.HasPrefix(err.Error(), `span "leaked" not ended`) {
+				n++
+			}
+		}
+		return n
+	}
+
+	_, leaked := tp.Tracer("leak").Start(context.Background(), "leaked")
+	time.Sleep(5 * time.Millisecond)
+	ld.check()
+	ld.check()
+	assert.Equal(t, 1, reports(), "leaked span reports")
+
+	// Ending the span does not report it again.
+	leaked.End()
+	ld.check()
+	assert.Equal(t, 1, reports(), "leaked span reports")
+	assert.Empty(t, ld.LeakedSpans())
+}
+
+func TestSpanKindFromContext(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewProvider(WithSyncer(te), WithConfig(Config{DefaultSampler: AlwaysSample()}))
+	tr := tp.Tracer("spanKindFromContext")
+
+	ctx := apitrace.ContextWithSpanKind(context.Background(), apitrace.SpanKindServer)
+	ctx, server := tr.Start(ctx, "server")
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	server.End()
+
+	got, ok := te.GetSpan("server")
+	require.True(t, ok)
+	assert.Equal(t, apitrace.SpanKindServer, got.SpanKind)
+
+	// The marker only applies to spans without a local parent.
+	got, ok = te.GetSpan("child")
+	require.True(t, ok)
+	assert.Equal(t, apitrace.SpanKindInternal, got.SpanKind)
+}
+
+func TestSpanKindValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate bool
+		want     apitrace.SpanKind
+	}{
+		{name: "disabled", validate: false, want: apitrace.SpanKindServer},
+		{name: "enabled", validate: true, want: apitrace.SpanKindInternal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			te := NewTestExporter()
+			opts := []ProviderOption{WithSyncer(te), WithConfig(Config{DefaultSampler: AlwaysSample()})}
+			if test.validate {
+				opts = append(opts, WithSpanKindValidation())
+			}
+			tr := NewProvider(opts...).Tracer("spanKindValidation")
+
+			ctx, outer := tr.Start(context.Background(), "outer", apitrace.WithSpanKind(apitrace.SpanKindServer))
+			_, inner := tr.Start(ctx, "inner", apitrace.WithSpanKind(apitrace.SpanKindServer))
+			_, client := tr.Start(ctx, "client", apitrace.WithSpanKind(apitrace.SpanKindClient))
+			client.End()
+			inner.End()
+			outer.End()
+
+			got, ok := te.GetSpan("inner")
+			require.True(t, ok)
+			assert.Equal(t, test.want, got.SpanKind)
+
+			got, ok = te.GetSpan("client")
+			require.True(t, ok)
+			assert.Equal(t, apitrace.SpanKindClient, got.SpanKind)
+		})
+	}
+}
@@ -16,7 +16,10 @@ package trace
 
 import (
 	"context"
+	"fmt"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/api/global"
 	apitrace "go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/internal/trace/parent"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -27,7 +30,7 @@ type tracer struct {
 	instrumentationLibrary instrumentation.Library
 }
 
-var _ apitrace.Tracer = &tracer{}
+var _ apitrace.EnabledTracer = &tracer{}
 
 // Start starts a Span and returns it along with a context containing it.
 //
@@ -37,22 +40,36 @@ var _ apitrace.Tracer = &tracer{}
 // passed will be used as the start time of the Span's life-cycle.
 func (tr *tracer) Start(ctx context.Context, name string, options ...apitrace.SpanOption) (context.Context, apitrace.Span) {
 	config := apitrace.NewSpanConfig(options...)
+	name = tr.provider.formatSpanName(name)
 
 	parentSpanContext, remoteParent, links := parent.GetSpanContextAndLinks(ctx, config.NewRoot)
 
+	var localParent *span
 	if p := apitrace.SpanFromContext(ctx); p != nil {
 		if sdkSpan, ok := p.(*span); ok {
 			sdkSpan.addChild()
+			if !remoteParent && sdkSpan.spanContext.SpanID == parentSpanContext.SpanID {
+				localParent = sdkSpan
+			}
 		}
 	}
 
-	span := startSpanInternal(tr, name, parentSpanContext, remoteParent, config)
-	for _, l := range links {
-		span.addLink(l)
+	if config.SpanKind == apitrace.SpanKindUnspecified && localParent == nil {
+		config.SpanKind = apitrace.SpanKindFromContext(ctx)
 	}
+	if tr.provider.validateSpanKinds {
+		config.SpanKind = validateSpanKind(name, config.SpanKind, localParent)
+	}
+
+	span := startSpanInternal(tr, name, parentSpanContext, remoteParent, config)
 	for _, l := range config.Links {
 		span.addLink(l)
 	}
+	// Links to the ignored parent context are added last so that they
+	// are the last to be evicted when MaxLinksPerSpan is exceeded.
+	for _, l := range links {
+		span.addParentLink(l)
+	}
 	span.SetAttributes(config.Attributes...)
 
 	span.tracer = tr
@@ -60,7 +77,7 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...apitrace.Sp
 	if span.IsRecording() {
 		sps, _ := tr.provider.spanProcessors.Load().(spanProcessorMap)
 		for sp := range sps {
-			sp.OnStart(span.data)
+			spanProcessorOnStart(sp, span.data)
 		}
 	}
 
@@ -68,3 +85,64 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...apitrace.Sp
 	span.executionTracerTaskEnd = end
 	return apitrace.ContextWithSpan(ctx, span), span
 }
+
+// maxTraceID is the trace ID Enabled consults the Sampler with for root
+// spans, whose trace ID is not known yet.
+var maxTraceID = apitrace.ID{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// Enabled reports whether a span started with ctx and options could be
+// recording.  It returns false if no SpanProcessor is registered or if
+// the span would not be sampled and WithRecord is not passed, as such
+// spans are not recorded.
+//
+// The span name and, for root spans, the trace ID are not known yet, so
+// the configured Sampler is consulted with an empty name and, for root
+// spans, a trace ID whose bytes are all ones.  Samplers that depend on
+// either should be prepared for this; the TraceIDRatioBased sampler
+// samples this trace ID for any positive fraction.
+func (tr *tracer) Enabled(ctx context.Context, options ...apitrace.SpanOption) bool {
+	if sps, _ := tr.provider.spanProcessors.Load().(spanProcessorMap); len(sps) == 0 {
+		return false
+	}
+	config := apitrace.NewSpanConfig(options...)
+	if config.Record {
+		return true
+	}
+
+	parentSpanContext, remoteParent, _ := parent.GetSpanContextAndLinks(ctx, config.NewRoot)
+	if config.SpanKind == apitrace.SpanKindUnspecified {
+		config.SpanKind = apitrace.SpanKindFromContext(ctx)
+	}
+	spanContext := parentSpanContext
+	if !spanContext.HasTraceID() {
+		spanContext.TraceID = maxTraceID
+	}
+	sampled := makeSamplingDecision(samplingData{
+		noParent:     parentSpanContext == apitrace.EmptySpanContext(),
+		remoteParent: remoteParent,
+		parent:       parentSpanContext,
+		cfg:          tr.provider.config.Load().(*Config),
+		span:         &span{spanContext: spanContext},
+		attributes:   config.Attributes,
+		links:        config.Links,
+		kind:         config.SpanKind,
+	})
+	return sampled.Decision == RecordAndSample
+}
+
+// validateSpanKind returns the kind a span named name should be started
+// with given its requested kind and its local parent, if any.  Invalid
+// combinations are reported to the global error handler.
+func validateSpanKind(name string, kind apitrace.SpanKind, localParent *span) apitrace.SpanKind {
+	if kind != apitrace.SpanKindServer || localParent == nil || localParent.data == nil {
+		return kind
+	}
+	if localParent.data.SpanKind == apitrace.SpanKindServer {
+		global.Handle(otel.WithSeverity(fmt.Errorf("span %q: server span started as the child of local server span %s, using %s", name, localParent.spanContext.SpanID, apitrace.SpanKindInternal), otel.SeverityWarn))
+		return apitrace.SpanKindInternal
+	}
+	return kind
+}
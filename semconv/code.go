@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/label"
+)
+
+// codeAttributes caches the attributes of the program counters
+// resolved by CodeAttributesFromPC.
+var codeAttributes sync.Map // uintptr -> []label.KeyValue
+
+// CodeAttributesFromCaller generates attributes of the code namespace
+// as specified by the OpenTelemetry specification for the caller of
+// the function calling CodeAttributesFromCaller, skipping skip
+// additional stack frames.  It returns nil if the caller cannot be
+// determined.
+func CodeAttributesFromCaller(skip int) []label.KeyValue {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+3, pcs[:]) == 0 {
+		return nil
+	}
+	return CodeAttributesFromPC(pcs[0])
+}
+
+// CodeAttributesFromPC generates attributes of the code namespace as
+// specified by the OpenTelemetry specification for the return program
+// counter pc, as returned by runtime.Callers.  Program counters are
+// resolved once and cached, so that repeated calls from the same call
+// site are cheap.  The returned slice must not be modified.
+func CodeAttributesFromPC(pc uintptr) []label.KeyValue {
+	if attrs, ok := codeAttributes.Load(pc); ok {
+		return attrs.([]label.KeyValue)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.Function == "" {
+		return nil
+	}
+	namespace, function := splitFunctionName(frame.Function)
+	attrs := []label.KeyValue{
+		CodeFunctionKey.String(function),
+		CodeNamespaceKey.String(namespace),
+		CodeFilepathKey.String(frame.File),
+		CodeLineNumberKey.Int(frame.Line),
+	}
+	codeAttributes.Store(pc, attrs)
+	return attrs
+}
+
+// splitFunctionName splits a fully qualified function name, as
+// reported by the runtime, into its package path and the name of the
+// function within the package, e.g., "example.com/pkg.(*T).Method"
+// into "example.com/pkg" and "(*T).Method".
+func splitFunctionName(name string) (namespace, function string) {
+	// Package paths may contain dots, but not after the last slash.
+	i := strings.LastIndexByte(name, '/')
+	j := strings.IndexByte(name[i+1:], '.')
+	if j < 0 {
+		return "", name
+	}
+	j += i + 1
+	return name[:j], name[j+1:]
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/label"
+)
+
+type codeTester struct{}
+
+func (*codeTester) caller() []label.KeyValue {
+	return CodeAttributesFromCaller(0)
+}
+
+func TestCodeAttributesFromCaller(t *testing.T) {
+	attrs := (&codeTester{}).caller()
+	_, file, line, ok := runtime.Caller(0)
+	require.True(t, ok)
+
+	assert.Equal(t, []label.KeyValue{
+		CodeFunctionKey.String("TestCodeAttributesFromCaller"),
+		CodeNamespaceKey.String("go.opentelemetry.io/otel/semconv"),
+		CodeFilepathKey.String(file),
+		CodeLineNumberKey.Int(line - 1),
+	}, attrs)
+}
+
+func TestCodeAttributesFromPCCached(t *testing.T) {
+	var attrs [][]label.KeyValue
+	for i := 0; i < 2; i++ {
+		attrs = append(attrs, (&codeTester{}).caller())
+	}
+	require.NotEmpty(t, attrs[0])
+	// The same call site resolves to the same cached slice.
+	assert.Equal(t, &attrs[0][0], &attrs[1][0])
+}
+
+func TestSplitFunctionName(t *testing.T) {
+	for _, tc := range []struct {
+		name, namespace, function string
+	}{
+		{"main.main", "main", "main"},
+		{"example.com/pkg.F", "example.com/pkg", "F"},
+		{"example.com/pkg.F.func1", "example.com/pkg", "F.func1"},
+		{"example.com/pkg.(*T).Method", "example.com/pkg", "(*T).Method"},
+		{"example.com/pkg.v1/sub.T.Method", "example.com/pkg.v1/sub", "T.Method"},
+		{"nodot", "", "nodot"},
+	} {
+		namespace, function := splitFunctionName(tc.name)
+		assert.Equal(t, tc.namespace, namespace, tc.name)
+		assert.Equal(t, tc.function, function, tc.name)
+	}
+}
@@ -128,6 +128,9 @@ const (
 	// GKE clusters have a name which can be used for this label.
 	K8SClusterNameKey = label.Key("k8s.cluster.name")
 
+	// The name of the Node the pod is running on.
+	K8SNodeNameKey = label.Key("k8s.node.name")
+
 	// The name of the namespace that the pod is running in.
 	K8SNamespaceNameKey = label.Key("k8s.namespace.name")
 
@@ -338,3 +338,23 @@ var (
 	FaaSDocumentOperationEdit   = FaaSDocumentOperationKey.String("edit")
 	FaaSDocumentOperationDelete = FaaSDocumentOperationKey.String("delete")
 )
+
+// Semantic conventions for attribute keys describing the source code
+// of the operation a span represents.
+const (
+	// The method or function name, or equivalent.  For Go, the
+	// name of the function within its package, including the
+	// receiver type of a method.
+	CodeFunctionKey = label.Key("code.function")
+
+	// The namespace within which the function is defined.  For Go,
+	// the package path.
+	CodeNamespaceKey = label.Key("code.namespace")
+
+	// The source code file name that identifies the code unit as
+	// uniquely as possible.
+	CodeFilepathKey = label.Key("code.filepath")
+
+	// The line number in the source code file.
+	CodeLineNumberKey = label.Key("code.lineno")
+)
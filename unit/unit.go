@@ -14,10 +14,46 @@
 
 package unit
 
+// Unit is a unit of measure in the Unified Code for Units of Measure
+// (UCUM) case sensitive syntax, see https://ucum.org/ucum.html.
 type Unit string
 
 const (
 	Dimensionless Unit = "1"
 	Bytes         Unit = "By"
 	Milliseconds  Unit = "ms"
+	Seconds       Unit = "s"
+	Microseconds  Unit = "us"
+	Nanoseconds   Unit = "ns"
+)
+
+// Common units of measure.
+const (
+	Bits           Unit = "bit"
+	Kilobytes      Unit = "kBy"
+	Megabytes      Unit = "MBy"
+	Gigabytes      Unit = "GBy"
+	Kibibytes      Unit = "KiBy"
+	Mebibytes      Unit = "MiBy"
+	Gibibytes      Unit = "GiBy"
+	BytesPerSec    Unit = "By/s"
+	BitsPerSec     Unit = "bit/s"
+	Minutes        Unit = "min"
+	Hours          Unit = "h"
+	Days           Unit = "d"
+	Hertz          Unit = "Hz"
+	PerSecond      Unit = "/s"
+	Percent        Unit = "%"
+	Meters         Unit = "m"
+	Celsius        Unit = "Cel"
+	Joules         Unit = "J"
+	Watts          Unit = "W"
+	Volts          Unit = "V"
+	Amperes        Unit = "A"
+	Requests       Unit = "{request}"
+	Errors         Unit = "{error}"
+	Connections    Unit = "{connection}"
+	Threads        Unit = "{thread}"
+	Packets        Unit = "{packet}"
+	RequestsPerSec Unit = "{request}/s"
 )
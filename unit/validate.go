@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidUnit is returned by Validate for a unit that is not a
+// syntactically valid UCUM unit.
+var ErrInvalidUnit = errors.New("invalid UCUM unit")
+
+// Validate returns an error wrapping ErrInvalidUnit if u is not a
+// syntactically valid UCUM unit in the case sensitive syntax.  The
+// empty unit is valid.
+//
+// Only the syntax is checked: atoms such as "By" or "s" are not
+// looked up in the UCUM table of units, so that any atom made of the
+// characters UCUM allows is accepted.  Examples of valid units are
+// "1", "ms", "By/s", "kBy", "m2", "s-1", "10*3", "{request}" and
+// "[in_i]".
+func Validate(u Unit) error {
+	if u == "" {
+		return nil
+	}
+	p := parser{s: string(u)}
+	if p.peek() == '/' {
+		p.pos++
+	}
+	p.term()
+	if p.err == nil && p.pos < len(p.s) {
+		p.fail("unexpected %q", p.s[p.pos])
+	}
+	if p.err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidUnit, u, p.err)
+	}
+	return nil
+}
+
+// parser is a recursive descent parser of the UCUM grammar.
+type parser struct {
+	s   string
+	pos int
+	err error
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) fail(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("at offset %d: "+format, append([]interface{}{p.pos}, args...)...)
+	}
+}
+
+// term := component (('.' | '/') component)*
+func (p *parser) term() {
+	p.component()
+	for p.err == nil && (p.peek() == '.' || p.peek() == '/') {
+		p.pos++
+		p.component()
+	}
+}
+
+// component := '(' term ')' | annotation | factor annotation? |
+// simpleUnit exponent? annotation?
+func (p *parser) component() {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		p.term()
+		if p.err == nil && p.peek() != ')' {
+			p.fail("missing ')'")
+		}
+		p.pos++
+		return
+	case c == '{':
+		p.annotation()
+		return
+	case isDigit(c):
+		p.digits()
+		if p.peek() == '*' || p.peek() == '^' {
+			// A power of ten, e.g. "10*3" or "10^-6".
+			p.pos++
+			p.exponent(true)
+		}
+	default:
+		p.simpleUnit()
+		p.exponent(false)
+	}
+	if p.err == nil && p.peek() == '{' {
+		p.annotation()
+	}
+}
+
+// simpleUnit is a prefixed or unprefixed atom.  Atoms are made of
+// any printable ASCII characters except the ones with a syntactic
+// meaning and digits, or of bracketed sequences such as "[in_i]".
+func (p *parser) simpleUnit() {
+	start := p.pos
+	for p.err == nil && p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '[':
+			p.pos++
+			for p.pos < len(p.s) && p.s[p.pos] != ']' {
+				if !isPrintable(p.s[p.pos]) || p.s[p.pos] == '[' {
+					p.fail("invalid character %q in bracketed atom", p.s[p.pos])
+					return
+				}
+				p.pos++
+			}
+			if p.pos == len(p.s) {
+				p.fail("missing ']'")
+				return
+			}
+			p.pos++
+		case isAtomChar(c):
+			p.pos++
+		default:
+			if p.pos == start {
+				p.fail("unexpected %q", c)
+			}
+			return
+		}
+	}
+	if p.err == nil && p.pos == start {
+		p.fail("missing unit")
+	}
+}
+
+// exponent := ('+' | '-')? digits.  It is required after a power of
+// ten and optional after a simple unit.
+func (p *parser) exponent(required bool) {
+	if p.err != nil {
+		return
+	}
+	if c := p.peek(); c == '+' || c == '-' {
+		p.pos++
+		required = true
+	}
+	if isDigit(p.peek()) {
+		p.digits()
+	} else if required {
+		p.fail("missing exponent")
+	}
+}
+
+// annotation := '{' printable ASCII characters except '{' and '}' '}'
+func (p *parser) annotation() {
+	p.pos++ // '{'
+	for p.pos < len(p.s) && p.s[p.pos] != '}' {
+		if !isPrintable(p.s[p.pos]) || p.s[p.pos] == '{' {
+			p.fail("invalid character %q in annotation", p.s[p.pos])
+			return
+		}
+		p.pos++
+	}
+	if p.pos == len(p.s) {
+		p.fail("missing '}'")
+		return
+	}
+	p.pos++
+}
+
+func (p *parser) digits() {
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isPrintable reports whether c is printable ASCII, excluding space.
+func isPrintable(c byte) bool {
+	return c > ' ' && c <= '~'
+}
+
+func isAtomChar(c byte) bool {
+	if !isPrintable(c) || isDigit(c) {
+		return false
+	}
+	switch c {
+	case '.', '/', '(', ')', '[', ']', '{', '}', '+', '-', '=':
+		return false
+	}
+	return true
+}
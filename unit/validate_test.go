@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unit
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []Unit{
+		"",
+		Dimensionless,
+		Bytes,
+		Milliseconds,
+		BytesPerSec,
+		PerSecond,
+		Percent,
+		Requests,
+		RequestsPerSec,
+		"m2",
+		"s-1",
+		"m/s2",
+		"kg.m/s2",
+		"10*3",
+		"10^-6",
+		"10*3{request}",
+		"[in_i]",
+		"[ppm]",
+		"(By/s).h",
+		"mm[Hg]",
+		"/min",
+		"1{utilization}",
+	}
+	for _, u := range valid {
+		if err := Validate(u); err != nil {
+			t.Errorf("Validate(%q): unexpected error: %v", u, err)
+		}
+	}
+
+	invalid := []Unit{
+		" ",
+		"ms ",
+		"milli seconds",
+		"By//s",
+		".s",
+		"s.",
+		"s/",
+		"()",
+		"(m/s",
+		"m/s)",
+		"{request",
+		"request}",
+		"[in_i",
+		"10*",
+		"m-",
+		"s{re{q}}",
+		"µs",
+	}
+	for _, u := range invalid {
+		err := Validate(u)
+		if err == nil {
+			t.Errorf("Validate(%q): expected an error", u)
+			continue
+		}
+		if !errors.Is(err, ErrInvalidUnit) {
+			t.Errorf("Validate(%q): expected ErrInvalidUnit, got %v", u, err)
+		}
+	}
+}